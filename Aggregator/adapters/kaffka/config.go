@@ -9,6 +9,8 @@ import (
 type kafkaConfig struct {
 	Brockers     []string 
 	Topic        string   
+	KlineTopic   string
+	TradeTopic   string
 	BatchSize    int
 	BatchTimeOut time.Duration
 	RequiredAcks int
@@ -20,6 +22,8 @@ func LoadKafkaConfig() kafkaConfig {
 	return kafkaConfig{
 		Brockers:     getenv.GetSlice("BROKERS", []string{"localhost:9092"}),
 		Topic:        getenv.GetString("TOPIC", "binance.miniticker"),
+		KlineTopic:   getenv.GetString("KLINE_TOPIC", "binance.klines"),
+		TradeTopic:   getenv.GetString("TRADE_TOPIC", "market.trades"),
 		BatchSize:    getenv.GetInt("BATCH_SIZE", 120),
 		BatchTimeOut: getenv.GetTime("BATCH_TIMEOUT", 2*time.Second),
 		RequiredAcks: getenv.GetInt("ACK", 1),
@@ -12,14 +12,14 @@ import (
 	"github.com/segmentio/kafka-go"
 )
 
-type producer struct {
+type producer[T models.KafkaRecord] struct {
 	writer      *kafka.Writer
 	config      kafkaConfig
-	batchBuffer []*models.KafkaMsg
+	batchBuffer []T
 	batchTimer  *time.Timer
 }
 
-func NewProducer(cfg kafkaConfig) *producer {
+func NewProducer[T models.KafkaRecord](cfg kafkaConfig) *producer[T] {
 	slog.Info("🔄 Initializing Kafka producer", "brokers", cfg.Brockers, "topic", cfg.Topic)
 
 	
@@ -84,19 +84,19 @@ func NewProducer(cfg kafkaConfig) *producer {
 
 	slog.Info("✅ Kafka producer initialized successfully")
 
-	return &producer{
+	return &producer[T]{
 		writer:      writer,
 		config:      cfg,
-		batchBuffer: make([]*models.KafkaMsg, 0, cfg.BatchSize),
+		batchBuffer: make([]T, 0, cfg.BatchSize),
 		batchTimer:  time.NewTimer(cfg.BatchTimeOut),
 	}
 }
 
-func (p *producer) Start(ctx context.Context, wg *sync.WaitGroup, inputChan chan models.KafkaMsg) {
+func (p *producer[T]) Start(ctx context.Context, wg *sync.WaitGroup, inputChan chan T) {
 	defer wg.Done()
 	defer p.writer.Close() 
 
-	slog.Info("🚀 Kafka producer started")
+	slog.Info("🚀 Kafka producer started", "topic", p.config.Topic)
 
 	for {
 		select {
@@ -131,7 +131,7 @@ func (p *producer) Start(ctx context.Context, wg *sync.WaitGroup, inputChan chan
 			}
 
 			
-			p.batchBuffer = append(p.batchBuffer, &msg)
+			p.batchBuffer = append(p.batchBuffer, msg)
 
 			
 			if len(p.batchBuffer) >= p.config.BatchSize {
@@ -143,7 +143,7 @@ func (p *producer) Start(ctx context.Context, wg *sync.WaitGroup, inputChan chan
 	}
 }
 
-func (p *producer) sendToKafka(ctx context.Context) {
+func (p *producer[T]) sendToKafka(ctx context.Context) {
 	if len(p.batchBuffer) == 0 {
 		return
 	}
@@ -157,11 +157,11 @@ func (p *producer) sendToKafka(ctx context.Context) {
 			continue
 		}
 		arrMsgs = append(arrMsgs, kafka.Message{
-			Key:   []byte(msg.Symbol),
+			Key:   []byte(msg.KafkaKey()),
 			Value: jsonData,
-			Time:  time.UnixMilli(msg.RecvTime),
+			Time:  msg.KafkaTime(),
 			Headers: []kafka.Header{
-				{Key: "message_id", Value: []byte(msg.MessageID)},
+				{Key: "message_id", Value: []byte(msg.KafkaID())},
 			},
 		})
 	}
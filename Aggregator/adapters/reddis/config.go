@@ -1,9 +1,11 @@
 package reddis
 
 import (
+	"log/slog"
 	"time"
 
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
 )
 
 type redisConfig struct {
@@ -14,10 +16,19 @@ type redisConfig struct {
 	TTL         time.Duration
 	RetryDelay  time.Duration
 	PingTimeout time.Duration
+
+	ResyncInterval time.Duration
+	PriceTTL       time.Duration
+
+	// Transport is "pubsub", or "streams" to append the prices to the
+	// Redis stream Stream, trimmed to about StreamMaxLen entries.
+	Transport    string
+	Stream       string
+	StreamMaxLen int64
 }
 
 func LoadRedisConfig() redisConfig {
-	return redisConfig{
+	cfg := redisConfig{
 		Addr:        getenv.GetString("REDIS_ADDR", "redis:6379"),
 		Password:    getenv.GetString("REDIS_PWD", ""),
 		DBnum:       getenv.GetInt("REDIS_DB", 0),
@@ -25,5 +36,18 @@ func LoadRedisConfig() redisConfig {
 		TTL:         getenv.GetTime("REDIS_TTL", 30*time.Second),
 		RetryDelay:  getenv.GetTime("REDIS_RETRY_DELAY", 2*time.Second),
 		PingTimeout: getenv.GetTime("REDIS_PING_TIMEOUT", 5*time.Second),
+
+		ResyncInterval: getenv.GetTime("SUBSCRIPTIONS_RESYNC_INTERVAL", 15*time.Second),
+		PriceTTL:       getenv.GetTime("PRICE_CACHE_TTL", 24*time.Hour),
+
+		Transport:    getenv.GetString("PRICE_TRANSPORT", models.PriceTransportPubSub),
+		Stream:       getenv.GetString("PRICE_STREAM", "prices"),
+		StreamMaxLen: int64(getenv.GetInt("PRICE_STREAM_MAXLEN", 10000)),
+	}
+
+	if cfg.Transport != models.PriceTransportPubSub && cfg.Transport != models.PriceTransportStreams {
+		slog.Warn("Unknown price transport, using Pub/Sub", "transport", cfg.Transport)
+		cfg.Transport = models.PriceTransportPubSub
 	}
+	return cfg
 }
@@ -0,0 +1,71 @@
+package reddis
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// Followers persists the followers of every streamed symbol in Redis, so
+// the streams can be rebuilt after a restart. The state in memory stays
+// the source of truth: a failed write is only logged.
+type Followers struct {
+	rdb *redis.Client
+}
+
+func NewFollowers(cfg redisConfig) *Followers {
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DBnum,
+	})
+
+	return &Followers{
+		rdb: rdb,
+	}
+}
+
+func (f *Followers) Add(symbol, follower string) {
+	err := f.rdb.HSetNX(context.Background(), models.FollowersKeyPrefix+symbol, follower, time.Now().Unix()).Err()
+	if err != nil {
+		slog.Error("Could not persist follower", "symbol", symbol, "follower", follower, "error", err)
+	}
+}
+
+func (f *Followers) Remove(symbol, follower string) {
+	err := f.rdb.HDel(context.Background(), models.FollowersKeyPrefix+symbol, follower).Err()
+	if err != nil {
+		slog.Error("Could not remove persisted follower", "symbol", symbol, "follower", follower, "error", err)
+	}
+}
+
+// Load returns the followers of every symbol.
+func (f *Followers) Load(ctx context.Context) (map[string][]string, error) {
+	followers := make(map[string][]string)
+
+	iter := f.rdb.Scan(ctx, 0, models.FollowersKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		ids, err := f.rdb.HKeys(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) > 0 {
+			followers[strings.TrimPrefix(key, models.FollowersKeyPrefix)] = ids
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return followers, nil
+}
+
+func (f *Followers) Close() {
+	if err := f.rdb.Close(); err != nil {
+		slog.Warn("Could not close Redis followers client", "error", err)
+	}
+}
@@ -0,0 +1,99 @@
+package reddis
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// Subscriptions reads the subscription intents the Profile replicas keep in
+// Redis. The intents are read in full on start, on every change
+// notification and every ResyncInterval, so a lost notification or a
+// replica whose key expired is caught up with.
+type Subscriptions struct {
+	rdb *redis.Client
+	cfg redisConfig
+}
+
+func NewSubscriptions(cfg redisConfig) *Subscriptions {
+	rdb := redis.NewClient(&redis.Options{
+		Addr:     cfg.Addr,
+		Password: cfg.Password,
+		DB:       cfg.DBnum,
+	})
+
+	return &Subscriptions{
+		rdb: rdb,
+		cfg: cfg,
+	}
+}
+
+// Watch passes the intents of every replica, keyed by its Redis key, to
+// apply until ctx is done.
+func (s *Subscriptions) Watch(ctx context.Context, wg *sync.WaitGroup, apply func(intents map[string][]string)) {
+	defer wg.Done()
+	defer s.rdb.Close()
+
+	pubsub := s.rdb.Subscribe(ctx, models.SubscriptionsChannel)
+	defer pubsub.Close()
+	changes := pubsub.Channel()
+
+	ticker := time.NewTicker(s.cfg.ResyncInterval)
+	defer ticker.Stop()
+
+	slog.Info("📋 Watching subscription intents", "resync_interval", s.cfg.ResyncInterval)
+
+	s.sync(ctx, apply)
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Got interruption signal, stopping subscriptions watcher")
+			return
+		case _, ok := <-changes:
+			if !ok {
+				return
+			}
+			s.sync(ctx, apply)
+		case <-ticker.C:
+			s.sync(ctx, apply)
+		}
+	}
+}
+
+// sync leaves the streams as they are when Redis can't be read, instead of
+// dropping every subscription.
+func (s *Subscriptions) sync(ctx context.Context, apply func(intents map[string][]string)) {
+	intents, err := s.load(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Failed to load subscription intents", "error", err)
+		}
+		return
+	}
+	apply(intents)
+}
+
+func (s *Subscriptions) load(ctx context.Context) (map[string][]string, error) {
+	intents := make(map[string][]string)
+
+	iter := s.rdb.Scan(ctx, 0, models.SubscriptionsKeyPrefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		symbols, err := s.rdb.SMembers(ctx, key).Result()
+		if err != nil {
+			return nil, err
+		}
+		if len(symbols) > 0 {
+			intents[key] = symbols
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+	return intents, nil
+}
@@ -46,8 +46,21 @@ func (s *saver) saveSecondStat(ctx context.Context, msg models.SecondStat) error
 	
 	
 
-	cmd := s.rdb.Publish(ctx, msg.Symbol, data)
-	if cmd.Err() != nil {
+	// The last price is cached as well, so a new client does not wait for
+	// the next tick.
+	pipe := s.rdb.Pipeline()
+	if s.cfg.Transport == models.PriceTransportStreams {
+		pipe.XAdd(ctx, &redis.XAddArgs{
+			Stream: s.cfg.Stream,
+			MaxLen: s.cfg.StreamMaxLen,
+			Approx: true,
+			Values: map[string]any{"symbol": msg.Symbol, "data": data},
+		})
+	} else {
+		pipe.Publish(ctx, msg.Symbol, data)
+	}
+	pipe.Set(ctx, models.PriceKeyPrefix+msg.Symbol, data, s.cfg.PriceTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
 		slog.Error("Could not sent msg to Redis", "error", err)
 		return err
 	}
@@ -55,7 +68,18 @@ func (s *saver) saveSecondStat(ctx context.Context, msg models.SecondStat) error
 	return nil
 }
 
-func (s *saver) Start(ctx context.Context, wg *sync.WaitGroup, inChan chan models.SecondStat) {
+func (s *saver) saveRates(ctx context.Context, msg models.Rates) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	return s.rdb.Publish(ctx, models.RatesChannel, data).Err()
+}
+
+// Start publishes the per-second prices and the rate table until both
+// channels are closed.
+func (s *saver) Start(ctx context.Context, wg *sync.WaitGroup, inChan chan models.SecondStat, ratesChan chan models.Rates) {
 	defer wg.Done()
 	defer s.rdb.Close()
 
@@ -64,17 +88,34 @@ func (s *saver) Start(ctx context.Context, wg *sync.WaitGroup, inChan chan model
 		return
 	}
 
-	slog.Info("✍️ Starting Redis writer")
+	slog.Info("✍️ Starting Redis writer", "transport", s.cfg.Transport)
 
 	for {
 		select {
 		case <-ctx.Done():
 			slog.Info("Got interruption signal, stopping Redis writer")
 			return
+		case rates, ok := <-ratesChan:
+			if !ok {
+				ratesChan = nil
+				if inChan == nil {
+					slog.Info("Input channels closed, stopping Redis writer")
+					return
+				}
+				continue
+			}
+
+			if err := s.saveRates(ctx, rates); err != nil {
+				slog.Error("Failed to publish rates to Redis", "error", err)
+			}
 		case stat, ok := <-inChan:
 			if !ok {
-				slog.Info("Input channel closed, stopping Redis writer")
-				return
+				inChan = nil
+				if ratesChan == nil {
+					slog.Info("Input channels closed, stopping Redis writer")
+					return
+				}
+				continue
 			}
 
 			if err := s.saveSecondStat(ctx, stat); err != nil {
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requireAdmin lets a request through when it carries token as a bearer
+// token. Without a configured token every request is refused.
+func requireAdmin(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+				"error": "admin API is disabled, ADMIN_TOKEN is not set",
+			})
+			return
+		}
+
+		got, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"error": "admin token is missing or invalid",
+			})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -26,16 +29,25 @@ func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	wg := new(sync.WaitGroup)
 
-	rawMsgsChan := make(chan []byte, 300)
-
-	rawAggTradeChan := make(chan []byte, 100)
-	rawMiniTickerChan := make(chan []byte, 100)
+	aggTradeChan := make(chan models.AggTrade, 300)
+	miniTickerChan := make(chan models.MiniTicker, 500)
 
 	secondStatChan := make(chan models.SecondStat, 100)
 	dailyStatChan := make(chan models.DailyStat, 500)
 	kafkaMsgChan := make(chan models.KafkaMsg, 500)
+	klineChan := make(chan models.Kline, 500)
+	ratesChan := make(chan models.Rates, 1)
 
-	streamManager := strman.NewStreamManager()
+	tradeStream := converting.NewTradeStream()
+	orderBooks := converting.NewOrderBooks()
+	klines := converting.NewKlines()
+	cfgRedis := reddis.LoadRedisConfig()
+	followers := reddis.NewFollowers(cfgRedis)
+	defer followers.Close()
+	streamManager := strman.NewStreamManager(tradeStream, orderBooks, klines, followers)
+	dailyOpens := converting.NewDailyOpens()
+	rates := converting.NewRates()
+	symbols := converting.NewSymbols()
 
 	r := gin.Default()
 
@@ -50,7 +62,20 @@ func main() {
 			return
 		}
 
-		started := streamManager.AddCoin(ctx, wg, symbol, id, rawMsgsChan)
+		info, err := symbols.Resolve(symbol)
+		if err != nil {
+			status := http.StatusBadRequest
+			if errors.Is(err, converting.ErrRegistryUnavailable) {
+				status = http.StatusServiceUnavailable
+			}
+			c.JSON(status, gin.H{
+				"error": err.Error(),
+			})
+			return
+		}
+		symbol = strings.ToLower(info.Symbol)
+
+		started := streamManager.AddCoin(symbol, id)
 
 		if started {
 			c.JSON(http.StatusOK, gin.H{
@@ -78,6 +103,7 @@ func main() {
 			return
 		}
 
+		symbol = converting.NormalizeSymbol(symbol)
 		streamManager.DeleteCoin(symbol, id)
 
 		c.JSON(http.StatusOK, gin.H{
@@ -87,6 +113,93 @@ func main() {
 		})
 	})
 
+	r.GET("/symbols", func(c *gin.Context) {
+		limit := 20
+		if raw := c.Query("limit"); raw != "" {
+			n, err := strconv.Atoi(raw)
+			if err != nil || n < 1 || n > 100 {
+				c.JSON(http.StatusBadRequest, gin.H{
+					"error": "limit must be between 1 and 100",
+				})
+				return
+			}
+			limit = n
+		}
+
+		found, err := symbols.Search(c.Request.Context(), c.Query("search"), limit)
+		if err != nil {
+			slog.Error("Failed to search symbols", "error", err)
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"error": "symbol registry is unavailable",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"symbols": found,
+		})
+	})
+
+	adminToken := getenv.GetString("ADMIN_TOKEN", "")
+	if adminToken == "" {
+		slog.Warn("ADMIN_TOKEN is not set, the stream admin routes are disabled")
+	}
+	admin := r.Group("", requireAdmin(adminToken))
+
+	admin.GET("/streams", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"streams": streamManager.Streams(),
+		})
+	})
+
+	admin.POST("/streams/:symbol/restart", func(c *gin.Context) {
+		symbol := converting.NormalizeSymbol(c.Param("symbol"))
+
+		if !streamManager.Restart(symbol) {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "symbol is not streamed",
+			})
+			return
+		}
+
+		c.JSON(http.StatusOK, gin.H{
+			"status": "restarting",
+			"symbol": symbol,
+		})
+	})
+
+	admin.DELETE("/streams/:symbol", func(c *gin.Context) {
+		symbol := converting.NormalizeSymbol(c.Param("symbol"))
+
+		removed, intents, ok := streamManager.Drop(symbol)
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": "symbol is not streamed",
+			})
+			return
+		}
+
+		resp := gin.H{
+			"status":    "stream_removed",
+			"symbol":    symbol,
+			"followers": removed,
+			"temporary": intents > 0,
+		}
+		if intents > 0 {
+			resp["note"] = "Profile replicas still subscribe to the symbol, its streams reopen on the next reconcile"
+		}
+		c.JSON(http.StatusOK, resp)
+	})
+
+	admin.GET("/followers/:userID", func(c *gin.Context) {
+		id := c.Param("userID")
+
+		c.JSON(http.StatusOK, gin.H{
+			"userID":  id,
+			"symbols": streamManager.FollowedBy(id),
+		})
+	})
+
 	server := http.Server{
 		Addr:    getenv.GetString("SERVER_ADDR", ":8088"),
 		Handler: r,
@@ -100,24 +213,57 @@ func main() {
 	}()
 
 	cfgKafka := kaffka.LoadKafkaConfig()
-	producer := kaffka.NewProducer(cfgKafka)
+	producer := kaffka.NewProducer[models.KafkaMsg](cfgKafka)
+
+	cfgKlines := cfgKafka
+	cfgKlines.Topic = cfgKafka.KlineTopic
+	klineProducer := kaffka.NewProducer[models.Kline](cfgKlines)
 
-	cfgRedis := reddis.LoadRedisConfig()
 	saver := reddis.NewSaver(cfgRedis)
+	subscriptions := reddis.NewSubscriptions(cfgRedis)
+
+	// With tick storage on, the trades reach the per-second prices through
+	// the tee.
+	priceTradeChan := aggTradeChan
+	if converting.TradeTicksEnabled {
+		priceTradeChan = make(chan models.AggTrade, 300)
+		tradeTickChan := make(chan models.TradeTick, 5000)
+
+		cfgTrades := cfgKafka
+		cfgTrades.Topic = cfgKafka.TradeTopic
+		tradeProducer := kaffka.NewProducer[models.TradeTick](cfgTrades)
+
+		wg.Add(2)
+		go converting.TeeTradeTicks(ctx, wg, aggTradeChan, priceTradeChan, tradeTickChan)
+		go tradeProducer.Start(ctx, wg, tradeTickChan)
+	}
 
-	wg.Add(7)
+	// The persisted followers are restored before the subscription intents
+	// are watched, so the first reconcile sees them.
+	streamManager.Restore(ctx)
 
-	go converting.DistributeMessages(ctx, wg, rawMsgsChan, rawAggTradeChan, rawMiniTickerChan)
+	wg.Add(14)
 
-	go converting.ReceiveMiniTickerMessage(ctx, wg, rawMsgsChan)
+	go symbols.Run(ctx, wg)
+	go streamManager.Run(ctx, wg)
+	go tradeStream.Run(ctx, wg, aggTradeChan)
+	go orderBooks.Run(ctx, wg)
+	go klines.Run(ctx, wg, klineChan)
+	go converting.ReceiveMiniTickerMessage(ctx, wg, miniTickerChan)
 
-	go converting.ConvertRawToArrDS(ctx, wg, rawMiniTickerChan, dailyStatChan)
-	go converting.ConvertRawToSS(ctx, wg, rawAggTradeChan, secondStatChan)
+	go converting.ConvertMiniTickersToDS(ctx, wg, miniTickerChan, dailyStatChan, dailyOpens, rates)
+	go converting.ConvertAggTradesToSS(ctx, wg, priceTradeChan, secondStatChan, dailyOpens, orderBooks)
 
 	go converting.ReceiveKafkaMsg(ctx, wg, dailyStatChan, kafkaMsgChan)
 	go producer.Start(ctx, wg, kafkaMsgChan)
+	go klineProducer.Start(ctx, wg, klineChan)
+
+	go converting.PublishRates(ctx, wg, rates, ratesChan)
+	go saver.Start(ctx, wg, secondStatChan, ratesChan)
 
-	go saver.Start(ctx, wg, secondStatChan)
+	go subscriptions.Watch(ctx, wg, func(intents map[string][]string) {
+		streamManager.Reconcile(intents, symbols)
+	})
 
 	<-c
 	slog.Info("👾 Received Interruption signal")
@@ -2,7 +2,6 @@ package converting
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"strings"
 	"sync"
@@ -11,57 +10,21 @@ import (
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
 )
 
-func ConvertRawToSS(
-	ctx context.Context,
-	wg *sync.WaitGroup,
-	inChan chan []byte,
-	outChan chan models.SecondStat,
-) {
-	defer wg.Done()
-	defer close(outChan)
-
-	wgWorker := new(sync.WaitGroup)
-	aggTrChan := make(chan models.AggTrade, 100)
-
-	wgWorker.Add(1)
-	go receiveSecondStat(ctx, wgWorker, aggTrChan, outChan)
-
-	for {
-		select {
-		case <-ctx.Done():
-			slog.Info("Got Interruption signal, stopping to converting messages from stream")
-			wgWorker.Wait()
-			return
-		case msg, ok := <-inChan:
-			if !ok {
-				return
-			}
-			var aggTrade models.AggTrade
-			if err := json.Unmarshal(msg, &aggTrade); err != nil {
-				slog.Error("Could not parse bytes into aggTrade struct", "error", err)
-				continue
-			}
-
-			select {
-			case <-ctx.Done():
-				slog.Info("Got Interruption signal, stopping to converting messages from stream")
-				wgWorker.Wait()
-				return
-			case aggTrChan <- aggTrade:
-			}
-		}
-	}
-}
-
-func receiveSecondStat(
+// ConvertAggTradesToSS keeps the latest trade of every symbol and publishes
+// its price once per second, together with the 24h range and the top of its
+// order book.
+func ConvertAggTradesToSS(
 	ctx context.Context,
 	wg *sync.WaitGroup,
 	inChan chan models.AggTrade,
 	outChan chan models.SecondStat,
+	opens *DailyOpens,
+	books *OrderBooks,
 ) {
 	defer wg.Done()
+	defer close(outChan)
 
-	latestPrices := make(map[string]float64)
+	latestTrades := make(map[string]models.AggTrade)
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
@@ -76,13 +39,22 @@ func receiveSecondStat(
 			if !ok {
 				return
 			}
-			symbol := strings.ToLower(msg.Symbol)
-			latestPrices[symbol] = msg.PriceFloat()
+			latestTrades[strings.ToLower(msg.Symbol)] = msg
 		case <-ticker.C:
-			for symbol, price := range latestPrices {
+			for symbol, trade := range latestTrades {
 				secondStat := models.SecondStat{
 					Symbol: symbol,
-					Price:  price,
+					Quote:  QuoteAsset(symbol),
+					Price:  trade.PriceFloat(),
+					Open:   opens.Get(symbol),
+					Time:   trade.TradeTime,
+				}
+				secondStat.High, secondStat.Low = opens.Range(symbol)
+				if top, ok := books.Top(symbol); ok {
+					secondStat.Bid = top.Bid
+					secondStat.Ask = top.Ask
+					secondStat.Mid = top.Mid()
+					secondStat.Spread = top.Spread()
 				}
 				select {
 				case <-ctx.Done():
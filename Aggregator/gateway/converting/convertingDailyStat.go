@@ -2,7 +2,6 @@ package converting
 
 import (
 	"context"
-	"encoding/json"
 	"log/slog"
 	"sync"
 	"time"
@@ -17,11 +16,13 @@ type miniTickerEnvelope struct {
 	ingestTime int64
 }
 
-func ConvertRawToArrDS(
+func ConvertMiniTickersToDS(
 	ctx context.Context,
 	wg *sync.WaitGroup,
-	inputChan chan []byte,
+	inputChan chan models.MiniTicker,
 	outputChan chan models.DailyStat,
+	opens *DailyOpens,
+	rates *Rates,
 ) {
 	defer wg.Done()
 	defer close(outputChan)
@@ -34,48 +35,30 @@ func ConvertRawToArrDS(
 
 	wgWorker.Add(numWorkers)
 	for range 4 {
-		go ReceiveDailyStat(ctx, wgWorker, workerChan, outputChan)
+		go ReceiveDailyStat(ctx, wgWorker, workerChan, outputChan, opens, rates)
 	}
 
 	for {
 		select {
 		case <-ctx.Done():
 			slog.Info("Got Interruption signal, stopping to converting messages from stream")
+			wgWorker.Wait()
 			return
-		case arrBytes, ok := <-inputChan:
+		case msg, ok := <-inputChan:
 			if !ok {
 				return
 			}
-			var arrMsgs []models.MiniTicker
-			if err := json.Unmarshal(arrBytes, &arrMsgs); err != nil {
-				slog.Error("Could not parse bytes into array of miniTickers", "error", err)
-				continue
+			envelope := miniTickerEnvelope{
+				msg:        msg,
+				ingestTime: time.Now().UnixMilli(),
 			}
-
-			slog.Debug("Received batch", "size", len(arrMsgs))
-
 			select {
 			case <-ctx.Done():
 				wgWorker.Wait()
 				slog.Info("Got Interruption signal, stopping to converting messages from stream")
-			default:
-				for _, msg := range arrMsgs {
-					envelope := miniTickerEnvelope{
-						msg:        msg,
-						ingestTime: time.Now().UnixMilli(),
-					}
-					select {
-					case <-ctx.Done():
-						wgWorker.Wait()
-						slog.Info(
-							"Got Interruption signal, stopping to converting messages from stream",
-						)
-						return
-					case workerChan <- envelope:
-					}
-				}
+				return
+			case workerChan <- envelope:
 			}
-
 		}
 	}
 }
@@ -85,6 +68,8 @@ func ReceiveDailyStat(
 	wg *sync.WaitGroup,
 	inputChan chan miniTickerEnvelope,
 	outputChan chan models.DailyStat,
+	opens *DailyOpens,
+	rates *Rates,
 ) {
 	defer wg.Done()
 
@@ -110,6 +95,9 @@ func ReceiveDailyStat(
 				HighPrice:  msg.msg.HighPriceFloat(),
 				LowPrice:   msg.msg.LowPriceFloat(),
 			}
+			opens.Set(dailyStat.Symbol, dailyStat.OpenPrice, dailyStat.HighPrice, dailyStat.LowPrice)
+			rates.Set(dailyStat.Symbol, dailyStat.ClosePrice)
+
 			select {
 			case <-ctx.Done():
 				slog.Info(
@@ -0,0 +1,49 @@
+package converting
+
+import (
+	"strings"
+	"sync"
+)
+
+// DailyOpens keeps the rolling 24h open, high and low prices of every
+// symbol seen on the miniTicker stream, so the per-second prices can carry
+// the daily change and range.
+type DailyOpens struct {
+	mu     sync.RWMutex
+	prices map[string]dailyRange
+}
+
+type dailyRange struct {
+	open, high, low float64
+}
+
+func NewDailyOpens() *DailyOpens {
+	return &DailyOpens{
+		prices: make(map[string]dailyRange),
+	}
+}
+
+func (d *DailyOpens) Set(symbol string, open, high, low float64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.prices[strings.ToLower(symbol)] = dailyRange{open: open, high: high, low: low}
+}
+
+// Get returns 0 while no miniTicker for symbol has been seen.
+func (d *DailyOpens) Get(symbol string) float64 {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	return d.prices[strings.ToLower(symbol)].open
+}
+
+// Range returns the 24h high and low of symbol, both 0 while no miniTicker
+// for it has been seen.
+func (d *DailyOpens) Range(symbol string) (high, low float64) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	day := d.prices[strings.ToLower(symbol)]
+	return day.high, day.low
+}
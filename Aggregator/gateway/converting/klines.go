@@ -0,0 +1,106 @@
+package converting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+)
+
+var KlineIntervals = getenv.GetSlice("KLINE_INTERVALS", []string{"1m"})
+
+// Klines streams the candles of every followed symbol over each of
+// KlineIntervals and passes on the closed ones.
+type Klines struct {
+	*symbolRunner
+	intervals []string
+}
+
+func NewKlines() *Klines {
+	return &Klines{
+		symbolRunner: newSymbolRunner(),
+		intervals:    KlineIntervals,
+	}
+}
+
+func (k *Klines) Run(ctx context.Context, wg *sync.WaitGroup, outChan chan models.Kline) {
+	defer wg.Done()
+	defer close(outChan)
+
+	conn, err := createClientConn(ctx)
+	if err != nil {
+		slog.Error("Failed to connect after retries",
+			"address", Address,
+			"error", err,
+		)
+		return
+	}
+	defer conn.Close()
+
+	client := socket.NewSocketServiceClient(conn)
+
+	k.run(ctx, func(ctx context.Context, symbol string) {
+		intervals := new(sync.WaitGroup)
+		for _, interval := range k.intervals {
+			intervals.Add(1)
+			go func() {
+				defer intervals.Done()
+				k.follow(ctx, client, symbol, interval, outChan)
+			}()
+		}
+		intervals.Wait()
+	}, func(string) {})
+	slog.Info("Got Interruption signal, stopping kline streams")
+}
+
+func (k *Klines) follow(ctx context.Context, client socket.SocketServiceClient, symbol, interval string, outChan chan<- models.Kline) {
+	retry(ctx, func() error {
+		return k.stream(ctx, client, symbol, interval, outChan)
+	}, func(err error, delay time.Duration) {
+		k.fail(symbol, fmt.Errorf("%s: %w", interval, err))
+		slog.Warn("Kline stream broken, reopening", "symbol", symbol, "interval", interval, "error", err, "delay", delay)
+	})
+}
+
+// stream forwards the closed candles of one ReceiveKline call until it
+// fails.
+func (k *Klines) stream(ctx context.Context, client socket.SocketServiceClient, symbol, interval string, outChan chan<- models.Kline) error {
+	stream, err := client.ReceiveKline(ctx, &socket.KlineRequest{Symbol: symbol, Interval: interval})
+	if err != nil {
+		return err
+	}
+	slog.Info("📞 Kline stream opened", "symbol", symbol, "interval", interval)
+	k.recovered(symbol)
+
+	for {
+		candle, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("stream closed by server")
+			}
+			return err
+		}
+		if !candle.GetClosed() {
+			continue
+		}
+
+		kline, err := models.KlineFromProto(candle)
+		if err != nil {
+			slog.Warn("Dropping invalid kline", "symbol", symbol, "interval", interval, "error", err)
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case outChan <- kline:
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package converting
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+	"github.com/shopspring/decimal"
+)
+
+var errSequenceGap = errors.New("depth update sequence gap")
+
+// orderBook is the local book of one symbol, built from a depth snapshot
+// and kept current with the diff updates that follow it.
+type orderBook struct {
+	lastUpdateID int64
+	// synced is set once the first update after the snapshot was applied.
+	synced bool
+	bids   map[string]decimal.Decimal
+	asks   map[string]decimal.Decimal
+}
+
+func newOrderBook(snapshot *socket.DepthSnapshot) (*orderBook, error) {
+	book := &orderBook{
+		lastUpdateID: snapshot.GetLastUpdateId(),
+		bids:         make(map[string]decimal.Decimal),
+		asks:         make(map[string]decimal.Decimal),
+	}
+	if err := setLevels(book.bids, snapshot.GetBids()); err != nil {
+		return nil, err
+	}
+	if err := setLevels(book.asks, snapshot.GetAsks()); err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// apply returns errSequenceGap when updates were missed; the book has to
+// be rebuilt from a new snapshot then. Updates the snapshot already holds
+// are skipped.
+func (b *orderBook) apply(update *socket.DepthUpdate) error {
+	if update.GetFinalUpdateId() <= b.lastUpdateID {
+		return nil
+	}
+
+	next := b.lastUpdateID + 1
+	if b.synced && update.GetFirstUpdateId() != next || !b.synced && update.GetFirstUpdateId() > next {
+		return fmt.Errorf("%w: expected update %d, got %d", errSequenceGap, next, update.GetFirstUpdateId())
+	}
+
+	if err := setLevels(b.bids, update.GetBids()); err != nil {
+		return err
+	}
+	if err := setLevels(b.asks, update.GetAsks()); err != nil {
+		return err
+	}
+	b.lastUpdateID = update.GetFinalUpdateId()
+	b.synced = true
+	return nil
+}
+
+// top reports false while a side of the book is empty. A crossed book
+// means the diffs went wrong and is reported as an error.
+func (b *orderBook) top() (models.TopOfBook, bool, error) {
+	bid, ok := best(b.bids, decimal.Decimal.GreaterThan)
+	if !ok {
+		return models.TopOfBook{}, false, nil
+	}
+	ask, ok := best(b.asks, decimal.Decimal.LessThan)
+	if !ok {
+		return models.TopOfBook{}, false, nil
+	}
+	if !bid.LessThan(ask) {
+		return models.TopOfBook{}, false, fmt.Errorf("crossed book: bid %s, ask %s", bid, ask)
+	}
+
+	return models.TopOfBook{Bid: bid.InexactFloat64(), Ask: ask.InexactFloat64()}, true, nil
+}
+
+func best(levels map[string]decimal.Decimal, better func(decimal.Decimal, decimal.Decimal) bool) (decimal.Decimal, bool) {
+	var top decimal.Decimal
+	found := false
+	for _, price := range levels {
+		if !found || better(price, top) {
+			top = price
+			found = true
+		}
+	}
+	return top, found
+}
+
+// setLevels keys the levels by their canonical price, so "1.50" and "1.5"
+// are the same level. A zero quantity removes the level.
+func setLevels(side map[string]decimal.Decimal, levels []*socket.PriceLevel) error {
+	for _, level := range levels {
+		price, err := decimal.NewFromString(level.GetPrice())
+		if err != nil {
+			return fmt.Errorf("invalid price %q: %w", level.GetPrice(), err)
+		}
+		quantity, err := decimal.NewFromString(level.GetQuantity())
+		if err != nil {
+			return fmt.Errorf("invalid quantity %q: %w", level.GetQuantity(), err)
+		}
+
+		key := price.String()
+		if quantity.IsZero() {
+			delete(side, key)
+			continue
+		}
+		side[key] = price
+	}
+	return nil
+}
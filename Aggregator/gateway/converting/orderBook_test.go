@@ -0,0 +1,108 @@
+package converting
+
+import (
+	"errors"
+	"testing"
+
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+)
+
+// levels builds price levels from price, quantity pairs.
+func levels(pairs ...string) []*socket.PriceLevel {
+	out := make([]*socket.PriceLevel, 0, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		out = append(out, &socket.PriceLevel{Price: pairs[i], Quantity: pairs[i+1]})
+	}
+	return out
+}
+
+func TestOrderBook(t *testing.T) {
+	snapshot := &socket.DepthSnapshot{
+		LastUpdateId: 100,
+		Bids:         levels("10", "1", "9", "2"),
+		Asks:         levels("11", "1", "12", "2"),
+	}
+
+	cases := []struct {
+		name     string
+		updates  []*socket.DepthUpdate
+		gap      bool
+		crossed  bool
+		bid, ask float64
+	}{
+		{
+			name:    "update_in_snapshot_is_skipped",
+			updates: []*socket.DepthUpdate{{FirstUpdateId: 90, FinalUpdateId: 100, Bids: levels("10.5", "1")}},
+			bid:     10,
+			ask:     11,
+		},
+		{
+			name:    "first_update_straddles_snapshot",
+			updates: []*socket.DepthUpdate{{FirstUpdateId: 95, FinalUpdateId: 105, Bids: levels("10.5", "1")}},
+			bid:     10.5,
+			ask:     11,
+		},
+		{
+			name:    "gap_before_sync",
+			updates: []*socket.DepthUpdate{{FirstUpdateId: 102, FinalUpdateId: 110}},
+			gap:     true,
+		},
+		{
+			name: "gap_after_sync",
+			updates: []*socket.DepthUpdate{
+				{FirstUpdateId: 101, FinalUpdateId: 105},
+				{FirstUpdateId: 107, FinalUpdateId: 110},
+			},
+			gap: true,
+		},
+		{
+			name:    "zero_quantity_removes_level",
+			updates: []*socket.DepthUpdate{{FirstUpdateId: 101, FinalUpdateId: 101, Bids: levels("10.00", "0"), Asks: levels("11", "0")}},
+			bid:     9,
+			ask:     12,
+		},
+		{
+			name:    "crossed_book",
+			updates: []*socket.DepthUpdate{{FirstUpdateId: 101, FinalUpdateId: 101, Bids: levels("11.5", "1")}},
+			crossed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			book, err := newOrderBook(snapshot)
+			if err != nil {
+				t.Fatalf("newOrderBook failed: %v", err)
+			}
+
+			for _, update := range tc.updates {
+				if err = book.apply(update); err != nil {
+					break
+				}
+			}
+			if tc.gap {
+				if !errors.Is(err, errSequenceGap) {
+					t.Errorf("Expected errSequenceGap, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("apply failed: %v", err)
+			}
+
+			top, ok, err := book.top()
+			if tc.crossed {
+				if err == nil {
+					t.Errorf("Expected a crossed book error, got top %+v", top)
+				}
+				return
+			}
+			if err != nil || !ok {
+				t.Fatalf("Expected a top of book, got ok %v (err: %v)", ok, err)
+			}
+			if top.Bid != tc.bid || top.Ask != tc.ask {
+				t.Errorf("Expected top %v/%v, got %v/%v", tc.bid, tc.ask, top.Bid, top.Ask)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package converting
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+)
+
+var DepthLimit = getenv.GetInt("DEPTH_SNAPSHOT_LIMIT", 1000)
+
+// OrderBooks keeps a local order book of every followed symbol in sync
+// with the depth stream of the Socket service and exposes its best bid and
+// ask. A book with a gap in the update sequence is rebuilt from a new
+// snapshot.
+type OrderBooks struct {
+	*symbolRunner
+
+	mu   sync.RWMutex
+	tops map[string]models.TopOfBook
+}
+
+func NewOrderBooks() *OrderBooks {
+	return &OrderBooks{
+		symbolRunner: newSymbolRunner(),
+		tops:         make(map[string]models.TopOfBook),
+	}
+}
+
+// Top reports false while the book of symbol is not in sync.
+func (ob *OrderBooks) Top(symbol string) (models.TopOfBook, bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	top, ok := ob.tops[strings.ToLower(symbol)]
+	return top, ok
+}
+
+func (ob *OrderBooks) setTop(symbol string, top models.TopOfBook, ok bool) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	if ok {
+		ob.tops[symbol] = top
+	} else {
+		delete(ob.tops, symbol)
+	}
+}
+
+func (ob *OrderBooks) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	conn, err := createClientConn(ctx)
+	if err != nil {
+		slog.Error("Failed to connect after retries",
+			"address", Address,
+			"error", err,
+		)
+		return
+	}
+	defer conn.Close()
+
+	client := socket.NewSocketServiceClient(conn)
+
+	ob.run(ctx, func(ctx context.Context, symbol string) {
+		ob.follow(ctx, client, symbol)
+	}, func(symbol string) {
+		ob.setTop(symbol, models.TopOfBook{}, false)
+	})
+	slog.Info("Got Interruption signal, stopping order books")
+}
+
+// follow keeps the book of symbol in sync until ctx is cancelled.
+func (ob *OrderBooks) follow(ctx context.Context, client socket.SocketServiceClient, symbol string) {
+	retry(ctx, func() error {
+		err := ob.sync(ctx, client, symbol)
+		ob.setTop(symbol, models.TopOfBook{}, false)
+		return err
+	}, func(err error, delay time.Duration) {
+		ob.fail(symbol, err)
+		slog.Warn("Order book out of sync, rebuilding", "symbol", symbol, "error", err, "delay", delay)
+	})
+}
+
+// sync builds the book from a snapshot taken after the depth stream has
+// started and applies the updates until one of them is out of sequence.
+func (ob *OrderBooks) sync(ctx context.Context, client socket.SocketServiceClient, symbol string) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.ReceiveDepth(ctx, &socket.DepthRequest{Symbol: symbol})
+	if err != nil {
+		return err
+	}
+
+	updates := make(chan *socket.DepthUpdate, 1000)
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- receiveDepth(ctx, stream, updates)
+	}()
+
+	// The snapshot must not be older than the first buffered update.
+	var first *socket.DepthUpdate
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err := <-recvErr:
+		return err
+	case first = <-updates:
+	}
+
+	snapshot, err := client.GetDepthSnapshot(ctx, &socket.DepthSnapshotRequest{Symbol: symbol, Limit: int32(DepthLimit)})
+	if err != nil {
+		return err
+	}
+	book, err := newOrderBook(snapshot)
+	if err != nil {
+		return err
+	}
+	slog.Info("📖 Order book snapshot loaded", "symbol", symbol, "last_update_id", book.lastUpdateID)
+	ob.recovered(symbol)
+
+	update := first
+	for {
+		if err := book.apply(update); err != nil {
+			return err
+		}
+		if book.synced {
+			top, ok, err := book.top()
+			if err != nil {
+				return err
+			}
+			ob.setTop(symbol, top, ok)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-recvErr:
+			return err
+		case update = <-updates:
+		}
+	}
+}
+
+func receiveDepth(ctx context.Context, stream StreamReceiver[*socket.DepthUpdate], outChan chan<- *socket.DepthUpdate) error {
+	for {
+		update, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("stream closed by server")
+			}
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case outChan <- update:
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package converting
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+)
+
+// RatesBase is the asset every rate is expressed in.
+const RatesBase = "usdt"
+
+var (
+	// QuoteAssets are the assets a Binance symbol can be quoted in, the most
+	// liquid first. A symbol is split at the longest of them it ends with.
+	QuoteAssets = getenv.GetSlice("QUOTE_ASSETS", []string{
+		"usdt", "fdusd", "usdc", "btc", "eth", "bnb",
+		"eur", "try", "brl", "jpy", "mxn", "ars", "pln", "uah", "zar", "idr",
+	})
+
+	RatesInterval = getenv.GetTime("RATES_INTERVAL", 5*time.Second)
+)
+
+// QuoteAsset returns the asset symbol is quoted in, or "" when it ends with
+// none of the QuoteAssets.
+func QuoteAsset(symbol string) string {
+	_, quote, _ := splitSymbol(strings.ToLower(symbol))
+	return quote
+}
+
+func splitSymbol(symbol string) (string, string, bool) {
+	var quote string
+	for _, asset := range QuoteAssets {
+		if len(asset) > len(quote) && len(asset) < len(symbol) && strings.HasSuffix(symbol, asset) {
+			quote = asset
+		}
+	}
+	if quote == "" {
+		return "", "", false
+	}
+	return strings.TrimSuffix(symbol, quote), quote, true
+}
+
+// Rates keeps the last price of every pair seen on the miniTicker stream
+// and derives from them the USDT price of every asset.
+type Rates struct {
+	mu     sync.RWMutex
+	prices map[string]float64
+}
+
+func NewRates() *Rates {
+	return &Rates{
+		prices: make(map[string]float64),
+	}
+}
+
+func (r *Rates) Set(symbol string, price float64) {
+	if price <= 0 {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prices[strings.ToLower(symbol)] = price
+}
+
+type rateEdge struct {
+	asset  string
+	factor float64
+}
+
+// Table returns the price of one unit of every asset in USDT. Assets
+// without a USDT pair are triangulated over the fewest pairs possible,
+// going through the more liquid quote assets first, e.g. an asset quoted
+// only in BTC is priced by its BTC pair times BTCUSDT. Binance lists no USD
+// pairs, so USD is taken at par with USDT.
+func (r *Rates) Table() map[string]float64 {
+	edges := make(map[string][]rateEdge)
+
+	r.mu.RLock()
+	for symbol, price := range r.prices {
+		base, quote, ok := splitSymbol(symbol)
+		if !ok {
+			continue
+		}
+		// One unit of base is worth price units of quote.
+		edges[base] = append(edges[base], rateEdge{asset: quote, factor: price})
+		edges[quote] = append(edges[quote], rateEdge{asset: base, factor: 1 / price})
+	}
+	r.mu.RUnlock()
+
+	for asset := range edges {
+		slices.SortFunc(edges[asset], func(a, b rateEdge) int {
+			if d := quoteRank(a.asset) - quoteRank(b.asset); d != 0 {
+				return d
+			}
+			return strings.Compare(a.asset, b.asset)
+		})
+	}
+
+	table := map[string]float64{RatesBase: 1}
+	queue := []string{RatesBase}
+	for len(queue) > 0 {
+		asset := queue[0]
+		queue = queue[1:]
+
+		for _, edge := range edges[asset] {
+			if _, ok := table[edge.asset]; ok {
+				continue
+			}
+			table[edge.asset] = table[asset] / edge.factor
+			queue = append(queue, edge.asset)
+		}
+	}
+
+	if _, ok := table["usd"]; !ok {
+		table["usd"] = 1
+	}
+	return table
+}
+
+// quoteRank orders assets by their position in QuoteAssets; other assets
+// come last.
+func quoteRank(asset string) int {
+	if i := slices.Index(QuoteAssets, asset); i >= 0 {
+		return i
+	}
+	return len(QuoteAssets)
+}
+
+// PublishRates sends the rate table every RatesInterval.
+func PublishRates(ctx context.Context, wg *sync.WaitGroup, rates *Rates, outChan chan models.Rates) {
+	defer wg.Done()
+	defer close(outChan)
+
+	ticker := time.NewTicker(RatesInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Got Interruption signal, stopping to publish rates")
+			return
+		case now := <-ticker.C:
+			msg := models.Rates{
+				Base:  RatesBase,
+				Time:  now.UnixMilli(),
+				Rates: rates.Table(),
+			}
+			select {
+			case outChan <- msg:
+			default:
+				slog.Warn("Rates channel is full, dropping rates")
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package converting
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRatesTable(t *testing.T) {
+	rates := NewRates()
+	rates.Set("BTCUSDT", 60000)
+	rates.Set("USDTTRY", 30)
+	rates.Set("ETHBTC", 0.05)
+	rates.Set("XYZBNB", 2)
+	table := rates.Table()
+
+	cases := []struct {
+		name  string
+		asset string
+		want  float64
+	}{
+		{name: "direct_pair", asset: "btc", want: 60000},
+		{name: "inverse_pair", asset: "try", want: 1.0 / 30},
+		{name: "two_hops_through_btc", asset: "eth", want: 3000},
+		{name: "usd_at_par", asset: "usd", want: 1},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := table[tc.asset]
+			if !ok || math.Abs(got-tc.want) > 1e-9*tc.want {
+				t.Errorf("Expected %s at %v USDT, got %v (found: %v)", tc.asset, tc.want, got, ok)
+			}
+		})
+	}
+
+	t.Run("unreachable_asset", func(t *testing.T) {
+		for _, asset := range []string{"xyz", "bnb"} {
+			if rate, ok := table[asset]; ok {
+				t.Errorf("Expected no rate for %s without a path to USDT, got %v", asset, rate)
+			}
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
 	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -18,51 +19,12 @@ var (
 	MaxRetries = getenv.GetInt("SOCKET_SERVICE_MAX_RETRIES", 10)
 )
 
-type StreamReceiver interface {
-	Recv() (*socket.RawResponse, error)
+// StreamReceiver is a server stream of typed market data.
+type StreamReceiver[T any] interface {
+	Recv() (T, error)
 }
 
-func DistributeMessages(
-	ctx context.Context,
-	wg *sync.WaitGroup,
-	rawChan, aggTradeChan, miniTickerChan chan []byte,
-) {
-	defer wg.Done()
-	defer close(aggTradeChan)
-	defer close(miniTickerChan)
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case msg, ok := <-rawChan:
-			if !ok {
-				return
-			}
-			if len(msg) == 0 {
-				continue
-			}
-
-			if msg[0] == '{' {
-				select {
-				case <-ctx.Done():
-					return
-				case aggTradeChan <- msg:
-				}
-			}
-
-			if msg[0] == '[' {
-				select {
-				case <-ctx.Done():
-					return
-				case miniTickerChan <- msg:
-				}
-			}
-		}
-	}
-}
-
-func ReceiveMiniTickerMessage(ctx context.Context, wg *sync.WaitGroup, outChan chan []byte) {
+func ReceiveMiniTickerMessage(ctx context.Context, wg *sync.WaitGroup, outChan chan models.MiniTicker) {
 	defer wg.Done()
 
 	conn, err := createClientConn(ctx)
@@ -82,33 +44,7 @@ func ReceiveMiniTickerMessage(ctx context.Context, wg *sync.WaitGroup, outChan c
 		return 
 	}
 
-	receiveMessages(ctx, stream, outChan)
-}
-
-func ReceiveAggTradeMessage(
-	ctx context.Context,
-	symbol string,
-	outChan chan []byte,
-) {
-	conn, err := createClientConn(ctx)
-	if err != nil {
-		slog.Error("Failed to connect after retries",
-			"address", Address,
-			"error", err,
-		)
-		return
-	}
-	defer conn.Close()
-
-	client := socket.NewSocketServiceClient(conn)
-
-	stream, err := createAggTradeStream(ctx, client, symbol)
-	if err != nil {
-		slog.Error("Could not set up AggTrade stream", "error", err)
-		return
-	}
-
-	receiveMessages(ctx, stream, outChan)
+	receiveMessages(ctx, stream, outChan, models.MiniTickerFromProto)
 }
 
 func createClientConn(ctx context.Context) (*grpc.ClientConn, error) {
@@ -150,8 +86,8 @@ func createClientConn(ctx context.Context) (*grpc.ClientConn, error) {
 func createMiniTickerStream(
 	ctx context.Context,
 	client socket.SocketServiceClient,
-) (StreamReceiver, error) {
-	var stream socket.SocketService_ReceiveRawMiniTickerClient
+) (StreamReceiver[*socket.MiniTicker], error) {
+	var stream socket.SocketService_ReceiveMiniTickerClient
 	var err error
 
 	for i := 0; i < MaxRetries; i++ {
@@ -160,7 +96,7 @@ func createMiniTickerStream(
 
 			return nil, ctx.Err()
 		default:
-			stream, err = client.ReceiveRawMiniTicker(ctx, &socket.RawMiniTickerRequest{})
+			stream, err = client.ReceiveMiniTicker(ctx, &socket.MiniTickerRequest{})
 			if err == nil {
 				return stream, err
 			}
@@ -182,44 +118,12 @@ func createMiniTickerStream(
 	return nil, err
 }
 
-func createAggTradeStream(
+func receiveMessages[T, M any](
 	ctx context.Context,
-	client socket.SocketServiceClient,
-	symbol string,
-) (StreamReceiver, error) {
-	var stream socket.SocketService_ReceiveRawAggTradeClient
-	var err error
-
-	for i := 0; i < MaxRetries; i++ {
-		select {
-		case <-ctx.Done():
-			slog.Info("Stream creation cancelled by context")
-			return nil, ctx.Err()
-		default:
-			stream, err = client.ReceiveRawAggTrade(ctx, &socket.RawAggTradeRequest{Symbol: symbol})
-			if err == nil {
-				return stream, err
-			}
-
-			delay := time.Duration(i+1) * time.Second
-			slog.Warn("Failed to connect, retrying...",
-				"attempt", i+1,
-				"delay", delay,
-				"error", err,
-			)
-
-			select {
-			case <-ctx.Done():
-				slog.Info("Stream creation cancelled during retry delay")
-				return nil, ctx.Err()
-			case <-time.After(delay):
-			}
-		}
-	}
-	return nil, err
-}
-
-func receiveMessages(ctx context.Context, stream StreamReceiver, outChan chan<- []byte) {
+	stream StreamReceiver[T],
+	outChan chan<- M,
+	convert func(T) M,
+) {
 	slog.Info("📞 Starting to receive messages from stream")
 
 	for {
@@ -240,7 +144,7 @@ func receiveMessages(ctx context.Context, stream StreamReceiver, outChan chan<-
 			select {
 			case <-ctx.Done():
 				return
-			case outChan <- resp.Data:
+			case outChan <- convert(resp):
 			}
 		}
 	}
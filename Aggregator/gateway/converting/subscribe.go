@@ -0,0 +1,348 @@
+package converting
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+)
+
+var MaxResubscribeDelay = getenv.GetTime("SOCKET_SERVICE_MAX_RETRY_DELAY", 30*time.Second)
+
+// TradeStream receives the trades of every followed symbol over a single
+// Subscribe call to the Socket service. Symbols are added and removed on the
+// open stream; after the stream breaks it is reopened with the whole set.
+// The trades of every symbol are counted, so a feed that went quiet can be
+// subscribed again.
+type TradeStream struct {
+	mu          sync.Mutex
+	symbols     map[string]struct{}
+	added       map[string]struct{}
+	removed     map[string]struct{}
+	resubscribe map[string]struct{}
+	feeds       map[string]*tradeFeed
+	err         *models.StreamError
+	changed     chan struct{}
+}
+
+// rateWindow is how long trades are counted for the message rate.
+const rateWindow = 10 * time.Second
+
+// tradeFeed counts the trades of one symbol since it was added. checked is
+// the time of the last trade or (re)subscription.
+type tradeFeed struct {
+	started     time.Time
+	checked     time.Time
+	last        time.Time
+	windowStart time.Time
+	count       int
+	rate        float64
+}
+
+func newTradeFeed() *tradeFeed {
+	now := time.Now()
+	return &tradeFeed{started: now, checked: now, windowStart: now}
+}
+
+func (f *tradeFeed) trade(at time.Time) {
+	if elapsed := at.Sub(f.windowStart); elapsed >= rateWindow {
+		f.rate = float64(f.count) / elapsed.Seconds()
+		f.windowStart = at
+		f.count = 0
+	}
+	f.count++
+	f.checked = at
+	f.last = at
+}
+
+// messageRate is the rate of the last full window, or of the current one
+// once it has run longer than a window without a trade.
+func (f *tradeFeed) messageRate(now time.Time) float64 {
+	elapsed := now.Sub(f.windowStart)
+	if elapsed < rateWindow {
+		return f.rate
+	}
+	return float64(f.count) / elapsed.Seconds()
+}
+
+func NewTradeStream() *TradeStream {
+	return &TradeStream{
+		symbols:     make(map[string]struct{}),
+		added:       make(map[string]struct{}),
+		removed:     make(map[string]struct{}),
+		resubscribe: make(map[string]struct{}),
+		feeds:       make(map[string]*tradeFeed),
+		changed:     make(chan struct{}, 1),
+	}
+}
+
+func (ts *TradeStream) Add(symbol string) {
+	symbol = strings.ToLower(symbol)
+
+	ts.mu.Lock()
+	if _, ok := ts.symbols[symbol]; !ok {
+		ts.feeds[symbol] = newTradeFeed()
+	}
+	ts.symbols[symbol] = struct{}{}
+	ts.added[symbol] = struct{}{}
+	delete(ts.removed, symbol)
+	ts.mu.Unlock()
+
+	ts.notify()
+}
+
+func (ts *TradeStream) Remove(symbol string) {
+	symbol = strings.ToLower(symbol)
+
+	ts.mu.Lock()
+	delete(ts.symbols, symbol)
+	delete(ts.added, symbol)
+	delete(ts.resubscribe, symbol)
+	delete(ts.feeds, symbol)
+	ts.removed[symbol] = struct{}{}
+	ts.mu.Unlock()
+
+	ts.notify()
+}
+
+// Has reports whether symbol is followed.
+func (ts *TradeStream) Has(symbol string) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	_, ok := ts.symbols[strings.ToLower(symbol)]
+	return ok
+}
+
+// Resubscribe drops symbol from the open stream and subscribes it again,
+// which makes the Socket service reattach it to its exchange feed.
+func (ts *TradeStream) Resubscribe(symbol string) {
+	symbol = strings.ToLower(symbol)
+
+	ts.mu.Lock()
+	if _, ok := ts.symbols[symbol]; !ok {
+		ts.mu.Unlock()
+		return
+	}
+	ts.resubscribe[symbol] = struct{}{}
+	ts.feeds[symbol].checked = time.Now()
+	ts.mu.Unlock()
+
+	ts.notify()
+}
+
+// Stale returns the followed symbols without a trade for longer than after.
+func (ts *TradeStream) Stale(after time.Duration) []string {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	var stale []string
+	for symbol := range ts.symbols {
+		if time.Since(ts.feeds[symbol].checked) > after {
+			stale = append(stale, symbol)
+		}
+	}
+	sort.Strings(stale)
+	return stale
+}
+
+func (ts *TradeStream) seen(symbol string) {
+	symbol = strings.ToLower(symbol)
+
+	ts.mu.Lock()
+	if feed, ok := ts.feeds[symbol]; ok {
+		feed.trade(time.Now())
+	}
+	ts.mu.Unlock()
+}
+
+// Feed fills in the trade statistics of symbol and the error the Subscribe
+// stream is recovering from. It reports false for a symbol not followed.
+func (ts *TradeStream) Feed(symbol string, info *models.StreamInfo) bool {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	feed, ok := ts.feeds[strings.ToLower(symbol)]
+	if !ok {
+		return false
+	}
+
+	now := time.Now()
+	info.StartedAt = feed.started.UTC()
+	if !feed.last.IsZero() {
+		last := feed.last.UTC()
+		info.LastMessageAt = &last
+	}
+	info.MessageRate = feed.messageRate(now)
+	if ts.err != nil {
+		info.Errors = append(info.Errors, *ts.err)
+	}
+	return true
+}
+
+func (ts *TradeStream) setErr(err error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	if err == nil {
+		ts.err = nil
+		return
+	}
+	ts.err = &models.StreamError{Stream: "trades", Error: err.Error(), At: time.Now().UTC()}
+}
+
+func (ts *TradeStream) notify() {
+	select {
+	case ts.changed <- struct{}{}:
+	default:
+	}
+}
+
+// pending returns the changes made since the last request sent. The Socket
+// service handles the unsubscriptions first, so a symbol in both lists is
+// subscribed again.
+func (ts *TradeStream) pending() *socket.SubscribeRequest {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	for symbol := range ts.resubscribe {
+		ts.added[symbol] = struct{}{}
+		ts.removed[symbol] = struct{}{}
+	}
+
+	req := &socket.SubscribeRequest{
+		Subscribe:   sortedKeys(ts.added),
+		Unsubscribe: sortedKeys(ts.removed),
+	}
+	clear(ts.added)
+	clear(ts.removed)
+	clear(ts.resubscribe)
+	return req
+}
+
+// snapshot returns the request subscribing every symbol, which supersedes
+// the pending changes.
+func (ts *TradeStream) snapshot() *socket.SubscribeRequest {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	clear(ts.added)
+	clear(ts.removed)
+	clear(ts.resubscribe)
+	return &socket.SubscribeRequest{Subscribe: sortedKeys(ts.symbols)}
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (ts *TradeStream) Run(ctx context.Context, wg *sync.WaitGroup, outChan chan models.AggTrade) {
+	defer wg.Done()
+
+	conn, err := createClientConn(ctx)
+	if err != nil {
+		slog.Error("Failed to connect after retries",
+			"address", Address,
+			"error", err,
+		)
+		return
+	}
+	defer conn.Close()
+
+	client := socket.NewSocketServiceClient(conn)
+
+	delay := time.Second
+	for {
+		started := time.Now()
+		err := ts.stream(ctx, client, outChan)
+		if ctx.Err() != nil {
+			slog.Info("Got Interruption signal, stopping Subscribe stream")
+			return
+		}
+
+		if time.Since(started) > MaxResubscribeDelay {
+			delay = time.Second
+		}
+		ts.setErr(err)
+		slog.Warn("Subscribe stream broken, reopening", "error", err, "delay", delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, MaxResubscribeDelay)
+	}
+}
+
+// stream runs one Subscribe call until it fails.
+func (ts *TradeStream) stream(ctx context.Context, client socket.SocketServiceClient, outChan chan<- models.AggTrade) error {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := client.Subscribe(streamCtx)
+	if err != nil {
+		return err
+	}
+
+	snapshot := ts.snapshot()
+	if err := stream.Send(snapshot); err != nil {
+		return err
+	}
+	slog.Info("📞 Subscribe stream opened", "symbols", len(snapshot.Subscribe))
+	ts.setErr(nil)
+
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- ts.receive(streamCtx, stream, outChan)
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-recvErr:
+			return err
+		case <-ts.changed:
+			req := ts.pending()
+			if len(req.Subscribe) == 0 && len(req.Unsubscribe) == 0 {
+				continue
+			}
+			if err := stream.Send(req); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (ts *TradeStream) receive(ctx context.Context, stream StreamReceiver[*socket.AggTrade], outChan chan<- models.AggTrade) error {
+	for {
+		trade, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return errors.New("stream closed by server")
+			}
+			return err
+		}
+		ts.seen(trade.GetSymbol())
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case outChan <- models.AggTradeFromProto(trade):
+		}
+	}
+}
@@ -0,0 +1,190 @@
+package converting
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+)
+
+// symbolRunner runs a goroutine for every added symbol and cancels it once
+// the symbol is removed. A goroutine that returns on its own is started
+// again.
+type symbolRunner struct {
+	mu      sync.Mutex
+	symbols map[string]struct{}
+	running map[string]context.CancelFunc
+	errs    map[string]models.StreamError
+	changed chan struct{}
+}
+
+func newSymbolRunner() *symbolRunner {
+	return &symbolRunner{
+		symbols: make(map[string]struct{}),
+		running: make(map[string]context.CancelFunc),
+		errs:    make(map[string]models.StreamError),
+		changed: make(chan struct{}, 1),
+	}
+}
+
+func (r *symbolRunner) Add(symbol string) {
+	r.mu.Lock()
+	r.symbols[strings.ToLower(symbol)] = struct{}{}
+	r.mu.Unlock()
+
+	r.notify()
+}
+
+func (r *symbolRunner) Remove(symbol string) {
+	r.mu.Lock()
+	delete(r.symbols, strings.ToLower(symbol))
+	delete(r.errs, strings.ToLower(symbol))
+	r.mu.Unlock()
+
+	r.notify()
+}
+
+// Restart cancels the goroutine of symbol; the next reconcile starts a new
+// one.
+func (r *symbolRunner) Restart(symbol string) {
+	symbol = strings.ToLower(symbol)
+
+	r.mu.Lock()
+	if cancel, ok := r.running[symbol]; ok {
+		cancel()
+		delete(r.running, symbol)
+	}
+	r.mu.Unlock()
+
+	r.notify()
+}
+
+// LastError reports the failure the stream of symbol is recovering from.
+func (r *symbolRunner) LastError(symbol string) (models.StreamError, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	streamErr, ok := r.errs[strings.ToLower(symbol)]
+	return streamErr, ok
+}
+
+func (r *symbolRunner) fail(symbol string, err error) {
+	r.mu.Lock()
+	if _, ok := r.symbols[symbol]; ok {
+		r.errs[symbol] = models.StreamError{Error: err.Error(), At: time.Now().UTC()}
+	}
+	r.mu.Unlock()
+}
+
+func (r *symbolRunner) recovered(symbol string) {
+	r.mu.Lock()
+	delete(r.errs, symbol)
+	r.mu.Unlock()
+}
+
+// Has reports whether symbol is followed.
+func (r *symbolRunner) Has(symbol string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	_, ok := r.symbols[strings.ToLower(symbol)]
+	return ok
+}
+
+func (r *symbolRunner) notify() {
+	select {
+	case r.changed <- struct{}{}:
+	default:
+	}
+}
+
+// run calls follow on its own goroutine for every symbol until ctx is
+// cancelled, then waits for them. stopped is called for every removed
+// symbol.
+func (r *symbolRunner) run(ctx context.Context, follow func(ctx context.Context, symbol string), stopped func(symbol string)) {
+	workers := new(sync.WaitGroup)
+	defer workers.Wait()
+
+	for {
+		r.reconcile(ctx, workers, follow, stopped)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.changed:
+		}
+	}
+}
+
+func (r *symbolRunner) reconcile(ctx context.Context, workers *sync.WaitGroup, follow func(ctx context.Context, symbol string), stopped func(symbol string)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for symbol, cancel := range r.running {
+		if _, ok := r.symbols[symbol]; !ok {
+			cancel()
+			delete(r.running, symbol)
+			stopped(symbol)
+		}
+	}
+
+	for symbol := range r.symbols {
+		if _, ok := r.running[symbol]; ok {
+			continue
+		}
+		symbolCtx, cancel := context.WithCancel(ctx)
+		r.running[symbol] = cancel
+
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			follow(symbolCtx, symbol)
+			if symbolCtx.Err() == nil {
+				r.exited(symbol)
+			}
+		}()
+	}
+}
+
+// exited forgets the goroutine of symbol, which returned while it was still
+// wanted, so the reconcile a second later starts it again.
+func (r *symbolRunner) exited(symbol string) {
+	r.mu.Lock()
+	if cancel, ok := r.running[symbol]; ok {
+		cancel()
+		delete(r.running, symbol)
+	}
+	r.mu.Unlock()
+
+	slog.Warn("Symbol stream exited, restarting", "symbol", symbol)
+	time.AfterFunc(time.Second, r.notify)
+}
+
+// retry calls fn until ctx is cancelled, waiting between the calls with an
+// exponential backoff that starts over once a call lasted longer than
+// MaxResubscribeDelay.
+func retry(ctx context.Context, fn func() error, failed func(err error, delay time.Duration)) {
+	delay := time.Second
+	for {
+		started := time.Now()
+		err := fn()
+		if ctx.Err() != nil {
+			return
+		}
+
+		if time.Since(started) > MaxResubscribeDelay {
+			delay = time.Second
+		}
+		failed(err, delay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, MaxResubscribeDelay)
+	}
+}
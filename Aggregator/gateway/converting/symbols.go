@@ -0,0 +1,147 @@
+package converting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+)
+
+var SymbolsRefreshInterval = getenv.GetTime("SYMBOLS_REFRESH_INTERVAL", time.Hour)
+
+var (
+	ErrUnknownSymbol       = errors.New("unknown symbol")
+	ErrSymbolNotTrading    = errors.New("symbol is not trading")
+	ErrRegistryUnavailable = errors.New("symbol registry is unavailable")
+)
+
+// NormalizeSymbol lowercases symbol and drops the separators people put
+// between the assets, so "BTC/USDT" and "btc-usdt" both become "btcusdt".
+func NormalizeSymbol(symbol string) string {
+	return strings.ToLower(strings.NewReplacer("/", "", "-", "", "_", "", " ", "").Replace(symbol))
+}
+
+// Symbols keeps a copy of the symbol registry of the Socket service, so
+// subscriptions can be checked without a call per request.
+type Symbols struct {
+	mu      sync.RWMutex
+	symbols map[string]models.Symbol
+	client  socket.SocketServiceClient
+}
+
+func NewSymbols() *Symbols {
+	return &Symbols{
+		symbols: make(map[string]models.Symbol),
+	}
+}
+
+// Resolve returns the listed symbol written as symbol. It fails with
+// ErrRegistryUnavailable until the registry has been loaded once.
+func (s *Symbols) Resolve(symbol string) (models.Symbol, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.symbols) == 0 {
+		return models.Symbol{}, ErrRegistryUnavailable
+	}
+
+	info, ok := s.symbols[NormalizeSymbol(symbol)]
+	if !ok {
+		return models.Symbol{}, fmt.Errorf("%w %q", ErrUnknownSymbol, symbol)
+	}
+	if info.Status != models.SymbolStatusTrading {
+		return models.Symbol{}, fmt.Errorf("%w: %s is %s", ErrSymbolNotTrading, info.Symbol, info.Status)
+	}
+	return info, nil
+}
+
+// Search asks the registry for the symbols matching query, best matches
+// first.
+func (s *Symbols) Search(ctx context.Context, query string, limit int) ([]models.Symbol, error) {
+	s.mu.RLock()
+	client := s.client
+	s.mu.RUnlock()
+
+	if client == nil {
+		return nil, ErrRegistryUnavailable
+	}
+
+	resp, err := client.ListSymbols(ctx, &socket.ListSymbolsRequest{Search: query, Limit: int32(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrRegistryUnavailable, err)
+	}
+
+	symbols := make([]models.Symbol, 0, len(resp.GetSymbols()))
+	for _, info := range resp.GetSymbols() {
+		symbols = append(symbols, models.SymbolFromProto(info))
+	}
+	return symbols, nil
+}
+
+// Run loads the registry and reloads it every SymbolsRefreshInterval. A
+// failed load keeps the symbols already known and is retried with backoff.
+func (s *Symbols) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	conn, err := createClientConn(ctx)
+	if err != nil {
+		slog.Error("Failed to connect after retries",
+			"address", Address,
+			"error", err,
+		)
+		return
+	}
+	defer conn.Close()
+
+	client := socket.NewSocketServiceClient(conn)
+
+	s.mu.Lock()
+	s.client = client
+	s.mu.Unlock()
+
+	delay := time.Second
+	for {
+		wait := SymbolsRefreshInterval
+		if err := s.load(ctx, client); err != nil {
+			slog.Warn("Could not load symbol registry", "error", err, "delay", delay)
+			wait = delay
+			delay = min(delay*2, MaxResubscribeDelay)
+		} else {
+			delay = time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Got Interruption signal, stopping symbol registry")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (s *Symbols) load(ctx context.Context, client socket.SocketServiceClient) error {
+	resp, err := client.ListSymbols(ctx, &socket.ListSymbolsRequest{})
+	if err != nil {
+		return err
+	}
+
+	symbols := make(map[string]models.Symbol, len(resp.GetSymbols()))
+	for _, info := range resp.GetSymbols() {
+		symbol := models.SymbolFromProto(info)
+		symbols[NormalizeSymbol(symbol.Symbol)] = symbol
+	}
+
+	s.mu.Lock()
+	s.symbols = symbols
+	s.mu.Unlock()
+
+	slog.Info("📚 Symbol registry loaded", "symbols", len(symbols))
+	return nil
+}
@@ -0,0 +1,65 @@
+package converting
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+)
+
+// TradeTicksEnabled turns on publishing every trade to Kafka besides the
+// per-second prices.
+var TradeTicksEnabled = getenv.GetString("TRADE_TICKS_ENABLED", "false") == "true"
+
+// TeeTradeTicks passes every trade on to pricesChan and a copy to
+// ticksChan. The per-second prices come first: a tick is dropped rather
+// than holding them up while the Kafka producer lags behind.
+func TeeTradeTicks(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	inChan chan models.AggTrade,
+	pricesChan chan models.AggTrade,
+	ticksChan chan models.TradeTick,
+) {
+	defer wg.Done()
+	defer close(pricesChan)
+	defer close(ticksChan)
+
+	var dropped uint64
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Got Interruption signal, stopping to tee trades", "dropped_ticks", dropped)
+			return
+		case msg, ok := <-inChan:
+			if !ok {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case pricesChan <- msg:
+			}
+
+			tick, err := models.TradeTickFromAggTrade(msg)
+			if err != nil {
+				slog.Warn("Dropping invalid trade tick", "symbol", msg.Symbol, "error", err)
+				continue
+			}
+
+			select {
+			case ticksChan <- tick:
+			default:
+				dropped++
+				if dropped%1000 != 1 {
+					continue
+				}
+				slog.Warn("Trade tick channel is full, dropping tick", "symbol", tick.Symbol, "dropped_ticks", dropped)
+			}
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package strman
+
+import (
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+)
+
+// Streams describes the streams of every followed symbol, sorted by
+// symbol.
+func (sm *StreamManager) Streams() []models.StreamInfo {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	streams := make([]models.StreamInfo, 0, len(sm.Followers))
+	for symbol, followers := range sm.Followers {
+		info := models.StreamInfo{
+			Symbol:    symbol,
+			Followers: len(followers),
+		}
+		sm.trades.Feed(symbol, &info)
+
+		since := info.StartedAt
+		if info.LastMessageAt != nil {
+			since = *info.LastMessageAt
+		}
+		info.Stale = time.Since(since) > StaleAfter
+
+		_, info.BookInSync = sm.books.Top(symbol)
+		if streamErr, ok := sm.books.LastError(symbol); ok {
+			streamErr.Stream = "book"
+			info.Errors = append(info.Errors, streamErr)
+		}
+		if streamErr, ok := sm.klines.LastError(symbol); ok {
+			streamErr.Stream = "klines"
+			info.Errors = append(info.Errors, streamErr)
+		}
+
+		streams = append(streams, info)
+	}
+
+	sort.Slice(streams, func(i, j int) bool { return streams[i].Symbol < streams[j].Symbol })
+	return streams
+}
+
+// Restart reopens every stream of symbol. It reports false when nobody
+// follows symbol.
+func (sm *StreamManager) Restart(symbol string) bool {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	symbol = strings.ToLower(symbol)
+	if _, ok := sm.Followers[symbol]; !ok {
+		return false
+	}
+
+	sm.trades.Resubscribe(symbol)
+	sm.books.Restart(symbol)
+	sm.klines.Restart(symbol)
+	slog.Info("Restarting streams", "symbol", symbol)
+	return true
+}
+
+// Drop stops the streams of symbol whoever follows it and returns the
+// number of followers removed, and how many of them are Profile replicas.
+// Those keep the symbol in their subscription intents, so the next
+// reconcile opens its streams again. It reports false when nobody follows
+// symbol.
+func (sm *StreamManager) Drop(symbol string) (int, int, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	symbol = strings.ToLower(symbol)
+	followers, ok := sm.Followers[symbol]
+	if !ok {
+		return 0, 0, false
+	}
+
+	intents := 0
+	for follower := range followers {
+		if strings.HasPrefix(follower, models.SubscriptionsKeyPrefix) {
+			intents++
+		}
+		sm.queue(symbol, follower, false)
+	}
+	delete(sm.Followers, symbol)
+	sm.trades.Remove(symbol)
+	sm.books.Remove(symbol)
+	sm.klines.Remove(symbol)
+	slog.Info("Streams dropped", "symbol", symbol, "followers", len(followers), "intents", intents)
+	return len(followers), intents, true
+}
+
+// FollowedBy returns the symbols follower follows, sorted.
+func (sm *StreamManager) FollowedBy(follower string) []string {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	symbols := make([]string, 0)
+	for symbol, followers := range sm.Followers {
+		if _, ok := followers[follower]; ok {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
@@ -0,0 +1,36 @@
+package strman
+
+// followerKey names a follower of a symbol in the pending changes.
+type followerKey struct {
+	symbol   string
+	follower string
+}
+
+// queue records that follower started (added) or stopped following symbol
+// and wakes Run to persist it, so Redis is never written under sm.mu. Only
+// the last change of a follower is kept. The caller must hold sm.mu.
+func (sm *StreamManager) queue(symbol, follower string, added bool) {
+	sm.pending[followerKey{symbol: symbol, follower: follower}] = added
+
+	select {
+	case sm.persist <- struct{}{}:
+	default:
+	}
+}
+
+// flush writes the pending changes to the store. Only Run calls it, so the
+// changes of a follower reach Redis in the order they were made.
+func (sm *StreamManager) flush() {
+	sm.mu.Lock()
+	pending := sm.pending
+	sm.pending = make(map[followerKey]bool)
+	sm.mu.Unlock()
+
+	for key, added := range pending {
+		if added {
+			sm.store.Add(key.symbol, key.follower)
+		} else {
+			sm.store.Remove(key.symbol, key.follower)
+		}
+	}
+}
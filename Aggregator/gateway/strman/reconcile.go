@@ -0,0 +1,72 @@
+package strman
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/gateway/converting"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/models"
+)
+
+// Reconcile makes the streams follow the subscription intents of the
+// Profile replicas. Every replica is a follower named after its Redis key;
+// a replica missing from intents has all its symbols dropped. Symbols the
+// registry doesn't list are skipped, and taken as they are while the
+// registry is unavailable.
+func (sm *StreamManager) Reconcile(intents map[string][]string, symbols *converting.Symbols) {
+	want := make(map[string]map[string]struct{}, len(intents))
+	for follower, list := range intents {
+		want[follower] = make(map[string]struct{}, len(list))
+		for _, symbol := range list {
+			info, err := symbols.Resolve(symbol)
+			switch {
+			case err == nil:
+				symbol = strings.ToLower(info.Symbol)
+			case errors.Is(err, converting.ErrRegistryUnavailable):
+				symbol = converting.NormalizeSymbol(symbol)
+			default:
+				slog.Warn("Skipping subscription intent", "follower", follower, "symbol", symbol, "error", err)
+				continue
+			}
+			want[follower][symbol] = struct{}{}
+		}
+	}
+
+	have := sm.intentFollowers()
+
+	for follower, followed := range have {
+		for symbol := range followed {
+			if _, ok := want[follower][symbol]; !ok {
+				sm.DeleteCoin(symbol, follower)
+			}
+		}
+	}
+	for follower, followed := range want {
+		for symbol := range followed {
+			if _, ok := have[follower][symbol]; !ok {
+				sm.AddCoin(symbol, follower)
+			}
+		}
+	}
+}
+
+// intentFollowers returns the symbols every Profile replica follows now.
+func (sm *StreamManager) intentFollowers() map[string]map[string]struct{} {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	have := make(map[string]map[string]struct{})
+	for symbol, followers := range sm.Followers {
+		for follower := range followers {
+			if !strings.HasPrefix(follower, models.SubscriptionsKeyPrefix) {
+				continue
+			}
+			if have[follower] == nil {
+				have[follower] = make(map[string]struct{})
+			}
+			have[follower][symbol] = struct{}{}
+		}
+	}
+	return have
+}
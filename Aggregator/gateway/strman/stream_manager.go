@@ -9,30 +9,53 @@ import (
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/gateway/converting"
 )
 
+// FollowerStore persists the followers of every symbol across restarts.
+type FollowerStore interface {
+	Add(symbol, follower string)
+	Remove(symbol, follower string)
+	Load(ctx context.Context) (map[string][]string, error)
+}
+
 type StreamManager struct {
-	streams   map[string]context.CancelFunc
+	trades    *converting.TradeStream
+	books     *converting.OrderBooks
+	klines    *converting.Klines
+	store     FollowerStore
 	Followers map[string]map[string]struct{} 
 	mu        sync.RWMutex
+
+	// pending holds the follower changes not persisted yet; see persist.go.
+	pending map[followerKey]bool
+	persist chan struct{}
 }
 
-func NewStreamManager() *StreamManager {
+func NewStreamManager(trades *converting.TradeStream, books *converting.OrderBooks, klines *converting.Klines, store FollowerStore) *StreamManager {
 	return &StreamManager{
-		streams:   make(map[string]context.CancelFunc),
+		trades:    trades,
+		books:     books,
+		klines:    klines,
+		store:     store,
 		Followers: make(map[string]map[string]struct{}),
+		pending:   make(map[followerKey]bool),
+		persist:   make(chan struct{}, 1),
 	}
 }
 
-func (sm *StreamManager) AddCoin(
-	ctxParent context.Context,
-	wg *sync.WaitGroup,
-	symbol string,
-	userID string, 
-	outChan chan []byte,
-) bool {
+func (sm *StreamManager) AddCoin(symbol string, userID string) bool {
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
 
 	symbol = strings.ToLower(symbol)
+	if !sm.follow(symbol, userID) {
+		return false
+	}
+	sm.queue(symbol, userID, true)
+	return true
+}
+
+// follow adds a follower of symbol and opens its streams for the first
+// one. The caller must hold sm.mu.
+func (sm *StreamManager) follow(symbol string, userID string) bool {
 
 	
 	if _, ok := sm.Followers[symbol]; !ok {
@@ -51,39 +74,17 @@ func (sm *StreamManager) AddCoin(
 	sm.Followers[symbol][userID] = struct{}{}
 
 	
-	if _, streamExists := sm.streams[symbol]; !streamExists {
-		slog.Info("First subscriber, starting new stream", "symbol", symbol, "userID", userID)
-		sm.start(ctxParent, wg, symbol, outChan)
+	if len(sm.Followers[symbol]) == 1 {
+		slog.Info("First subscriber, subscribing symbol", "symbol", symbol, "userID", userID)
+		sm.trades.Add(symbol)
+		sm.books.Add(symbol)
+		sm.klines.Add(symbol)
 	} else {
 		slog.Info("Adding subscriber to existing stream", "symbol", symbol, "userID", userID, "total_followers", len(sm.Followers[symbol]))
 	}
 	return true
 }
 
-func (sm *StreamManager) start(
-	ctxParent context.Context,
-	wg *sync.WaitGroup,
-	symbol string,
-	outChan chan []byte,
-) {
-	ctx, cancel := context.WithCancel(ctxParent)
-	sm.streams[symbol] = cancel
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		defer func() {
-			sm.mu.Lock()
-			delete(sm.streams, symbol) 
-			slog.Info(
-				"Stream processing stopped and cleaned up", "symbol", symbol,
-			)
-			sm.mu.Unlock()
-		}()
-		converting.ReceiveAggTradeMessage(ctx, symbol, outChan)
-	}()
-}
-
 func (sm *StreamManager) DeleteCoin(symbol string, userID string) { 
 	sm.mu.Lock()
 	defer sm.mu.Unlock()
@@ -95,16 +96,17 @@ func (sm *StreamManager) DeleteCoin(symbol string, userID string) {
 		
 		if _, exists := users[userID]; exists {
 			delete(sm.Followers[symbol], userID)
+			sm.queue(symbol, userID, false)
 			slog.Info("User removed from coin", "symbol", symbol, "userID", userID)
 		}
 
 		
 		if len(sm.Followers[symbol]) == 0 {
-			if cancel, exists := sm.streams[symbol]; exists {
-				cancel() 
-				
-				slog.Info("Last user unsubscribed, stream cancellation signal sent", "symbol", symbol)
-			}
+			delete(sm.Followers, symbol)
+			sm.trades.Remove(symbol)
+			sm.books.Remove(symbol)
+			sm.klines.Remove(symbol)
+			slog.Info("Last user unsubscribed, symbol unsubscribed", "symbol", symbol)
 		}
 	}
 }
\ No newline at end of file
@@ -0,0 +1,86 @@
+package strman
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Aggregator/lib/getenv"
+)
+
+var (
+	SuperviseInterval = getenv.GetTime("STREAMS_RECONCILE_INTERVAL", 30*time.Second)
+	StaleAfter        = getenv.GetTime("STREAMS_STALE_AFTER", 2*time.Minute)
+)
+
+// Run persists follower changes as they happen and checks every
+// SuperviseInterval that each followed symbol is still streamed, subscribing
+// again the trade feeds that have been quiet for StaleAfter. Changes still
+// pending on shutdown are written before it returns.
+func (sm *StreamManager) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(SuperviseInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Got interruption signal, stopping stream supervisor")
+			sm.flush()
+			return
+		case <-sm.persist:
+			sm.flush()
+		case <-ticker.C:
+			sm.supervise()
+		}
+	}
+}
+
+// Restore rebuilds the streams of the persisted followers. It must finish
+// before the subscription intents are reconciled, which would otherwise
+// race with it over the followers of the Profile replicas.
+func (sm *StreamManager) Restore(ctx context.Context) {
+	followers, err := sm.store.Load(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Could not load persisted followers, streams start empty", "error", err)
+		}
+		return
+	}
+
+	sm.mu.Lock()
+	for symbol, ids := range followers {
+		for _, id := range ids {
+			sm.follow(symbol, id)
+		}
+	}
+	sm.mu.Unlock()
+	slog.Info("♻️ Restored persisted followers", "symbols", len(followers))
+}
+
+func (sm *StreamManager) supervise() {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	for symbol := range sm.Followers {
+		if !sm.trades.Has(symbol) {
+			slog.Warn("Trade stream lost a followed symbol, adding it back", "symbol", symbol)
+			sm.trades.Add(symbol)
+		}
+		if !sm.books.Has(symbol) {
+			slog.Warn("Order book lost a followed symbol, adding it back", "symbol", symbol)
+			sm.books.Add(symbol)
+		}
+		if !sm.klines.Has(symbol) {
+			slog.Warn("Kline stream lost a followed symbol, adding it back", "symbol", symbol)
+			sm.klines.Add(symbol)
+		}
+	}
+
+	for _, symbol := range sm.trades.Stale(StaleAfter) {
+		slog.Warn("No trades received for a while, subscribing again", "symbol", symbol, "after", StaleAfter)
+		sm.trades.Resubscribe(symbol)
+	}
+}
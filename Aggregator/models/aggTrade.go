@@ -3,6 +3,8 @@ package models
 import (
 	"log/slog"
 	"strconv"
+
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
 )
 
 type AggTrade struct {
@@ -17,6 +19,7 @@ type AggTrade struct {
 	TradeTime        int64  `json:"T"` 
 	IsBuyer          bool   `json:"m"` 
 	Ignore           bool   `json:"M"` 
+	Exchange         string `json:"exchange"`
 }
 
 func (at *AggTrade) PriceFloat() float64 {
@@ -27,3 +30,20 @@ func (at *AggTrade) PriceFloat() float64 {
 	}
 	return pf
 }
+
+// AggTradeFromProto maps a trade streamed by the Socket service.
+func AggTradeFromProto(at *socket.AggTrade) AggTrade {
+	return AggTrade{
+		EventType:        "aggTrade",
+		EventTime:        at.GetEventTime(),
+		Symbol:           at.GetSymbol(),
+		AggregateTradeID: at.GetAggregateTradeId(),
+		Price:            at.GetPrice(),
+		Quantity:         at.GetQuantity(),
+		FirstTradeID:     at.GetFirstTradeId(),
+		LastTradeID:      at.GetLastTradeId(),
+		TradeTime:        at.GetTradeTime(),
+		IsBuyer:          at.GetBuyerMaker(),
+		Exchange:         at.GetExchange(),
+	}
+}
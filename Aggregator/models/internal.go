@@ -6,9 +6,59 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// SecondStat is published to Redis once per second for every streamed
+// symbol. Quote is the asset the prices are in and Time the Unix time in
+// milliseconds of the trade Price comes from. Open, High and Low are the
+// rolling 24h prices, 0 while they are unknown. The best bid and ask come
+// from the local order book and are left out while it is not in sync.
 type SecondStat struct {
 	Symbol string  `json:"s"`
+	Quote  string  `json:"q,omitempty"`
 	Price  float64 `json:"p"`
+	Time   int64   `json:"t,omitempty"`
+	Open   float64 `json:"o,omitempty"`
+	High   float64 `json:"h,omitempty"`
+	Low    float64 `json:"l,omitempty"`
+	Bid    float64 `json:"b,omitempty"`
+	Ask    float64 `json:"a,omitempty"`
+	Mid    float64 `json:"m,omitempty"`
+	Spread float64 `json:"sp,omitempty"`
+}
+
+// Prices go out over Redis Pub/Sub on a channel per symbol, or with
+// PriceTransportStreams as entries of one Redis stream, each holding the
+// symbol and the SecondStat as JSON in its "symbol" and "data" fields.
+const (
+	PriceTransportPubSub  = "pubsub"
+	PriceTransportStreams = "streams"
+)
+
+// PriceKeyPrefix plus a symbol names the Redis key its last SecondStat is
+// cached under.
+const PriceKeyPrefix = "price:"
+
+// RatesChannel is the Redis channel the rate table is published on.
+const RatesChannel = "rates"
+
+// Rates holds the price of one unit of every asset in Base.
+type Rates struct {
+	Base  string             `json:"base"`
+	Time  int64              `json:"t"`
+	Rates map[string]float64 `json:"r"`
+}
+
+// TopOfBook is the best bid and ask of a synced order book.
+type TopOfBook struct {
+	Bid float64
+	Ask float64
+}
+
+func (t TopOfBook) Mid() float64 {
+	return (t.Bid + t.Ask) / 2
+}
+
+func (t TopOfBook) Spread() float64 {
+	return t.Ask - t.Bid
 }
 
 type DailyStat struct {
@@ -1,6 +1,18 @@
 package models
 
-import "github.com/shopspring/decimal"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// KafkaRecord is a message the Kafka producer can send. Records are keyed
+// by symbol, so those of one symbol stay in order on one partition.
+type KafkaRecord interface {
+	KafkaKey() string
+	KafkaID() string
+	KafkaTime() time.Time
+}
 
 type KafkaMsg struct {
 	MessageID     string          `json:"message_id"`
@@ -16,3 +28,15 @@ type KafkaMsg struct {
 	ChangePrice   decimal.Decimal `json:"change_price"`
 	ChangePercent decimal.Decimal `json:"change_percent"`
 }
+
+func (m KafkaMsg) KafkaKey() string {
+	return m.Symbol
+}
+
+func (m KafkaMsg) KafkaID() string {
+	return m.MessageID
+}
+
+func (m KafkaMsg) KafkaTime() time.Time {
+	return time.UnixMilli(m.RecvTime)
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// Kline is a closed candle sent to the klines Kafka topic. Times are Unix
+// milliseconds.
+type Kline struct {
+	MessageID   string          `json:"message_id"`
+	Symbol      string          `json:"symbol"`
+	Interval    string          `json:"interval"`
+	OpenTime    int64           `json:"open_time"`
+	CloseTime   int64           `json:"close_time"`
+	Open        decimal.Decimal `json:"open"`
+	High        decimal.Decimal `json:"high"`
+	Low         decimal.Decimal `json:"low"`
+	Close       decimal.Decimal `json:"close"`
+	BaseVolume  decimal.Decimal `json:"base_volume"`
+	QuoteVolume decimal.Decimal `json:"quote_volume"`
+	Trades      int64           `json:"trades"`
+	EventTime   int64           `json:"event_time"`
+	IngestTime  int64           `json:"ingest_time"`
+}
+
+// KlineFromProto maps a candle streamed by the Socket service.
+func KlineFromProto(k *socket.Kline) (Kline, error) {
+	kline := Kline{
+		MessageID:  uuid.New().String(),
+		Symbol:     strings.ToUpper(k.GetSymbol()),
+		Interval:   k.GetInterval(),
+		OpenTime:   k.GetOpenTime(),
+		CloseTime:  k.GetCloseTime(),
+		Trades:     k.GetTrades(),
+		EventTime:  k.GetEventTime(),
+		IngestTime: time.Now().UnixMilli(),
+	}
+
+	fields := []struct {
+		name  string
+		value string
+		dst   *decimal.Decimal
+	}{
+		{"open", k.GetOpen(), &kline.Open},
+		{"high", k.GetHigh(), &kline.High},
+		{"low", k.GetLow(), &kline.Low},
+		{"close", k.GetClose(), &kline.Close},
+		{"base_volume", k.GetBaseVolume(), &kline.BaseVolume},
+		{"quote_volume", k.GetQuoteVolume(), &kline.QuoteVolume},
+	}
+	for _, field := range fields {
+		value, err := decimal.NewFromString(field.value)
+		if err != nil {
+			return Kline{}, fmt.Errorf("invalid %s %q: %w", field.name, field.value, err)
+		}
+		*field.dst = value
+	}
+	return kline, nil
+}
+
+func (k Kline) KafkaKey() string {
+	return k.Symbol
+}
+
+func (k Kline) KafkaID() string {
+	return k.MessageID
+}
+
+func (k Kline) KafkaTime() time.Time {
+	return time.UnixMilli(k.CloseTime)
+}
@@ -3,6 +3,9 @@ package models
 import (
 	"log/slog"
 	"strconv"
+	"strings"
+
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
 )
 
 type MiniTicker struct {
@@ -50,3 +53,19 @@ func (mk *MiniTicker) LowPriceFloat() float64 {
 	}
 	return loPrice
 }
+
+// MiniTickerFromProto maps a ticker streamed by the Socket service. Symbols
+// are upper-cased as Binance sends them, which Kafka consumers expect.
+func MiniTickerFromProto(mt *socket.MiniTicker) MiniTicker {
+	return MiniTicker{
+		EventType:     "24hrMiniTicker",
+		EventTime:     mt.GetEventTime(),
+		Symbol:        strings.ToUpper(mt.GetSymbol()),
+		ClosePrice:    mt.GetClose(),
+		OpenPrice:     mt.GetOpen(),
+		HighPrice:     mt.GetHigh(),
+		LowPrice:      mt.GetLow(),
+		TotalBaseVol:  mt.GetBaseVolume(),
+		TotalQuoteVol: mt.GetQuoteVolume(),
+	}
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// StreamInfo describes the streams of one followed symbol. MessageRate is
+// the number of trades per second, LastMessageAt is unset until the first
+// trade. Errors lists the failures the streams are recovering from.
+type StreamInfo struct {
+	Symbol        string        `json:"symbol"`
+	Followers     int           `json:"followers"`
+	StartedAt     time.Time     `json:"startedAt"`
+	LastMessageAt *time.Time    `json:"lastMessageAt,omitempty"`
+	MessageRate   float64       `json:"messageRate"`
+	Stale         bool          `json:"stale"`
+	BookInSync    bool          `json:"bookInSync"`
+	Errors        []StreamError `json:"errors,omitempty"`
+}
+
+// StreamError is the last failure of the trades, book or klines stream of a
+// symbol.
+type StreamError struct {
+	Stream string    `json:"stream"`
+	Error  string    `json:"error"`
+	At     time.Time `json:"at"`
+}
@@ -0,0 +1,14 @@
+package models
+
+// Every Profile replica keeps the symbols its clients follow in a Redis set
+// under SubscriptionsKeyPrefix plus its instance id, and publishes that key
+// on SubscriptionsChannel whenever the set changes.
+const (
+	SubscriptionsKeyPrefix = "subscriptions:"
+	SubscriptionsChannel   = "subscriptions"
+)
+
+// FollowersKeyPrefix plus a symbol names the Redis hash the Aggregator
+// keeps the followers of that symbol in, each with the Unix time it
+// followed the symbol at.
+const FollowersKeyPrefix = "aggregator:followers:"
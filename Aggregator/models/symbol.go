@@ -0,0 +1,36 @@
+package models
+
+import (
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+)
+
+// SymbolStatusTrading is the status of a symbol open for trading.
+const SymbolStatusTrading = "TRADING"
+
+// Symbol is the exchange metadata of a symbol listed in the registry of the
+// Socket service. Filters are decimal strings, empty when Binance sets none.
+type Symbol struct {
+	Symbol      string `json:"symbol"`
+	BaseAsset   string `json:"baseAsset"`
+	QuoteAsset  string `json:"quoteAsset"`
+	Status      string `json:"status"`
+	TickSize    string `json:"tickSize,omitempty"`
+	StepSize    string `json:"stepSize,omitempty"`
+	MinQty      string `json:"minQty,omitempty"`
+	MaxQty      string `json:"maxQty,omitempty"`
+	MinNotional string `json:"minNotional,omitempty"`
+}
+
+func SymbolFromProto(s *socket.SymbolInfo) Symbol {
+	return Symbol{
+		Symbol:      s.GetSymbol(),
+		BaseAsset:   s.GetBaseAsset(),
+		QuoteAsset:  s.GetQuoteAsset(),
+		Status:      s.GetStatus(),
+		TickSize:    s.GetTickSize(),
+		StepSize:    s.GetStepSize(),
+		MinQty:      s.GetMinQty(),
+		MaxQty:      s.GetMaxQty(),
+		MinNotional: s.GetMinNotional(),
+	}
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// TradeTick is a single trade sent to the trades Kafka topic. Times are
+// Unix milliseconds; BuyerMaker is set when the taker sold.
+type TradeTick struct {
+	MessageID        string          `json:"message_id"`
+	Symbol           string          `json:"symbol"`
+	Exchange         string          `json:"exchange"`
+	AggregateTradeID int64           `json:"agg_trade_id"`
+	FirstTradeID     int64           `json:"first_trade_id"`
+	LastTradeID      int64           `json:"last_trade_id"`
+	Price            decimal.Decimal `json:"price"`
+	Quantity         decimal.Decimal `json:"quantity"`
+	BuyerMaker       bool            `json:"buyer_maker"`
+	TradeTime        int64           `json:"trade_time"`
+	EventTime        int64           `json:"event_time"`
+	IngestTime       int64           `json:"ingest_time"`
+}
+
+func TradeTickFromAggTrade(at AggTrade) (TradeTick, error) {
+	price, err := decimal.NewFromString(at.Price)
+	if err != nil {
+		return TradeTick{}, fmt.Errorf("invalid price %q: %w", at.Price, err)
+	}
+	quantity, err := decimal.NewFromString(at.Quantity)
+	if err != nil {
+		return TradeTick{}, fmt.Errorf("invalid quantity %q: %w", at.Quantity, err)
+	}
+
+	return TradeTick{
+		MessageID:        uuid.New().String(),
+		Symbol:           strings.ToUpper(at.Symbol),
+		Exchange:         at.Exchange,
+		AggregateTradeID: at.AggregateTradeID,
+		FirstTradeID:     at.FirstTradeID,
+		LastTradeID:      at.LastTradeID,
+		Price:            price,
+		Quantity:         quantity,
+		BuyerMaker:       at.IsBuyer,
+		TradeTime:        at.TradeTime,
+		EventTime:        at.EventTime,
+		IngestTime:       time.Now().UnixMilli(),
+	}, nil
+}
+
+func (t TradeTick) KafkaKey() string {
+	return t.Symbol
+}
+
+func (t TradeTick) KafkaID() string {
+	return t.MessageID
+}
+
+func (t TradeTick) KafkaTime() time.Time {
+	return time.UnixMilli(t.TradeTime)
+}
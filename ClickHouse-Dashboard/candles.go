@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/shopspring/decimal"
+)
+
+const maxCandles = 1000
+
+var candleIntervals = map[string]time.Duration{
+	"1m": time.Minute,
+	"5m": 5 * time.Minute,
+	"1h": time.Hour,
+	"1d": 24 * time.Hour,
+}
+
+// klineDurations are the lengths of the Binance kline intervals candles can
+// be rolled up from.
+var klineDurations = map[string]time.Duration{
+	"1m":  time.Minute,
+	"3m":  3 * time.Minute,
+	"5m":  5 * time.Minute,
+	"15m": 15 * time.Minute,
+	"30m": 30 * time.Minute,
+	"1h":  time.Hour,
+	"2h":  2 * time.Hour,
+	"4h":  4 * time.Hour,
+	"6h":  6 * time.Hour,
+	"8h":  8 * time.Hour,
+	"12h": 12 * time.Hour,
+	"1d":  24 * time.Hour,
+}
+
+// Candle is one OHLCV bucket rolled up from the closed klines stored in
+// ClickHouse. Samples is the number of klines it was built from.
+type Candle struct {
+	OpenTime time.Time       `json:"openTime"`
+	Open     decimal.Decimal `json:"open"`
+	High     decimal.Decimal `json:"high"`
+	Low      decimal.Decimal `json:"low"`
+	Close    decimal.Decimal `json:"close"`
+	Volume   decimal.Decimal `json:"volume"`
+	Samples  uint64          `json:"samples"`
+}
+
+type candlesResponse struct {
+	Symbol   string   `json:"symbol"`
+	Interval string   `json:"interval"`
+	Candles  []Candle `json:"candles"`
+}
+
+// storedKlineIntervals reads KLINE_INTERVALS, the kline intervals the
+// Aggregator streams into crypto.klines.
+func storedKlineIntervals() []string {
+	raw := os.Getenv("KLINE_INTERVALS")
+	if raw == "" {
+		raw = "1m"
+	}
+
+	var intervals []string
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		if _, ok := klineDurations[name]; !ok {
+			log.Printf("Ignoring unknown kline interval %q", name)
+			continue
+		}
+		intervals = append(intervals, name)
+	}
+	return intervals
+}
+
+// sourceInterval picks the longest stored kline interval that divides
+// interval evenly, so every candle is made of whole klines.
+func sourceInterval(stored []string, interval time.Duration) (string, bool) {
+	var (
+		source string
+		length time.Duration
+	)
+	for _, name := range stored {
+		d := klineDurations[name]
+		if interval%d == 0 && d > length {
+			source, length = name, d
+		}
+	}
+	return source, source != ""
+}
+
+func fetchCandles(ctx context.Context, conn driver.Conn, symbol, source string, interval time.Duration, from, to time.Time) ([]Candle, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			toStartOfInterval(open_time, INTERVAL %d SECOND) AS bucket,
+			argMin(open, open_time),
+			max(high),
+			min(low),
+			argMax(close, open_time),
+			sum(base_volume),
+			count()
+		FROM crypto.klines FINAL
+		WHERE symbol = ? AND "interval" = ? AND open_time >= ? AND open_time < ?
+		GROUP BY bucket
+		ORDER BY bucket`, int64(interval/time.Second))
+
+	rows, err := conn.Query(ctx, query, symbol, source, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	candles := make([]Candle, 0)
+	for rows.Next() {
+		var candle Candle
+		if err := rows.Scan(
+			&candle.OpenTime,
+			&candle.Open,
+			&candle.High,
+			&candle.Low,
+			&candle.Close,
+			&candle.Volume,
+			&candle.Samples,
+		); err != nil {
+			return nil, err
+		}
+		candles = append(candles, candle)
+	}
+	return candles, rows.Err()
+}
+
+func candlesHandler(conn driver.Conn, stored []string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		rng, err := parseRange(q, "interval", "candles")
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		source, ok := sourceInterval(stored, rng.interval)
+		if !ok {
+			writeJSONError(w, http.StatusBadRequest, fmt.Sprintf("no stored klines fit interval %s", rng.name))
+			return
+		}
+
+		candles, err := fetchCandles(r.Context(), conn, rng.symbol, source, rng.interval, rng.from, rng.to)
+		if err != nil {
+			log.Printf("Failed to fetch candles: %v", err)
+			writeJSONError(w, http.StatusInternalServerError, "failed to fetch candles")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		json.NewEncoder(w).Encode(candlesResponse{
+			Symbol:   rng.symbol,
+			Interval: rng.name,
+			Candles:  candles,
+		})
+	}
+}
+
+// timeRange is a request for the buckets of one symbol between from and to.
+type timeRange struct {
+	symbol   string
+	name     string
+	interval time.Duration
+	from     time.Time
+	to       time.Time
+}
+
+// parseRange reads the symbol, the bucket length named by param and the
+// from/to bounds of q, defaulting to the last maxCandles buckets. unit names
+// the buckets in the error for a range that is too long.
+func parseRange(q url.Values, param, unit string) (timeRange, error) {
+	rng := timeRange{
+		symbol: strings.ToUpper(strings.TrimSpace(q.Get("symbol"))),
+		name:   q.Get(param),
+	}
+	if rng.symbol == "" {
+		return timeRange{}, errors.New("symbol is required")
+	}
+
+	if rng.name == "" {
+		rng.name = "1m"
+	}
+	interval, ok := candleIntervals[rng.name]
+	if !ok {
+		return timeRange{}, fmt.Errorf("%s must be one of 1m, 5m, 1h, 1d", param)
+	}
+	rng.interval = interval
+
+	rng.to = time.Now().UTC()
+	if raw := q.Get("to"); raw != "" {
+		t, err := parseTime(raw)
+		if err != nil {
+			return timeRange{}, fmt.Errorf("invalid to: %w", err)
+		}
+		rng.to = t
+	}
+
+	rng.from = rng.to.Add(-interval * maxCandles)
+	if raw := q.Get("from"); raw != "" {
+		t, err := parseTime(raw)
+		if err != nil {
+			return timeRange{}, fmt.Errorf("invalid from: %w", err)
+		}
+		rng.from = t
+	}
+
+	if !rng.from.Before(rng.to) {
+		return timeRange{}, errors.New("from must be before to")
+	}
+	if rng.to.Sub(rng.from)/interval > maxCandles {
+		return timeRange{}, fmt.Errorf("range exceeds %d %s of %s", maxCandles, unit, rng.name)
+	}
+
+	return rng, nil
+}
+
+// parseTime accepts unix milliseconds or RFC 3339.
+func parseTime(raw string) (time.Time, error) {
+	if ms, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return time.UnixMilli(ms).UTC(), nil
+	}
+	return time.Parse(time.RFC3339, raw)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": msg})
+}
@@ -105,6 +105,9 @@ func main() {
 		fmt.Fprint(w, sb.String())
 	})
 
+	http.HandleFunc("/candles", candlesHandler(conn, storedKlineIntervals()))
+	http.HandleFunc("/trades/stats", tradeStatsHandler(conn))
+
 	log.Println("Starting ClickHouse Dashboard server on :8083")
 	if err := http.ListenAndServe(":8083", nil); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
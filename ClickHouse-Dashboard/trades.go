@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ClickHouse/clickhouse-go/v2/lib/driver"
+	"github.com/shopspring/decimal"
+)
+
+// TradeStats summarises the trades of one bucket. Buy volume is the volume
+// of trades where the taker bought; Imbalance is (buy - sell) / (buy + sell)
+// and lies in [-1, 1].
+type TradeStats struct {
+	OpenTime   time.Time       `json:"openTime"`
+	VWAP       decimal.Decimal `json:"vwap"`
+	Volume     decimal.Decimal `json:"volume"`
+	BuyVolume  decimal.Decimal `json:"buyVolume"`
+	SellVolume decimal.Decimal `json:"sellVolume"`
+	Imbalance  decimal.Decimal `json:"imbalance"`
+	Trades     uint64          `json:"trades"`
+}
+
+type tradeStatsResponse struct {
+	Symbol   string       `json:"symbol"`
+	Exchange string       `json:"exchange,omitempty"`
+	Window   string       `json:"window"`
+	Stats    []TradeStats `json:"stats"`
+}
+
+func fetchTradeStats(ctx context.Context, conn driver.Conn, symbol, exchange string, window time.Duration, from, to time.Time) ([]TradeStats, error) {
+	query := fmt.Sprintf(`
+		SELECT
+			toStartOfInterval(trade_time, INTERVAL %d SECOND) AS bucket,
+			sum(price * quantity),
+			sum(quantity),
+			sumIf(quantity, NOT buyer_maker),
+			sumIf(quantity, buyer_maker),
+			count()
+		FROM crypto.trades FINAL
+		WHERE symbol = ? AND (? = '' OR exchange = ?) AND trade_time >= ? AND trade_time < ?
+		GROUP BY bucket
+		ORDER BY bucket`, int64(window/time.Second))
+
+	rows, err := conn.Query(ctx, query, symbol, exchange, exchange, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make([]TradeStats, 0)
+	for rows.Next() {
+		var (
+			s        TradeStats
+			notional decimal.Decimal
+		)
+		if err := rows.Scan(
+			&s.OpenTime,
+			&notional,
+			&s.Volume,
+			&s.BuyVolume,
+			&s.SellVolume,
+			&s.Trades,
+		); err != nil {
+			return nil, err
+		}
+		if !s.Volume.IsZero() {
+			s.VWAP = notional.Div(s.Volume)
+			s.Imbalance = s.BuyVolume.Sub(s.SellVolume).Div(s.Volume)
+		}
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+func tradeStatsHandler(conn driver.Conn) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		rng, err := parseRange(q, "window", "windows")
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		exchange := strings.ToLower(strings.TrimSpace(q.Get("exchange")))
+
+		stats, err := fetchTradeStats(r.Context(), conn, rng.symbol, exchange, rng.interval, rng.from, rng.to)
+		if err != nil {
+			log.Printf("Failed to fetch trade stats: %v", err)
+			writeJSONError(w, http.StatusInternalServerError, "failed to fetch trade stats")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		json.NewEncoder(w).Encode(tradeStatsResponse{
+			Symbol:   rng.symbol,
+			Exchange: exchange,
+			Window:   rng.name,
+			Stats:    stats,
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 type kafkaConfig struct {
 	Brokers           []string
 	Topic             string
+	KlineTopic        string
+	TradeTopic        string
 	GroupID           string
 	MaxRetries        int
 	RetryDelay        time.Duration
@@ -20,6 +22,8 @@ func LoadKafkaConfig() kafkaConfig {
 	return kafkaConfig{
 		Brokers:           getenv.GetSlice("KAFKA_BROKERS", []string{"localhost:9092"}),
 		Topic:             getenv.GetString("KAFKA_TOPIC", "binance.miniticker"),
+		KlineTopic:        getenv.GetString("KAFKA_KLINE_TOPIC", "binance.klines"),
+		TradeTopic:        getenv.GetString("KAFKA_TRADE_TOPIC", "market.trades"),
 		GroupID:           getenv.GetString("KAFKA_GROUP_ID", "clickhouse-consumer-group"),
 		MaxRetries:        getenv.GetInt("KAFKA_MAX_RETRIES", 5),
 		RetryDelay:        getenv.GetDuration("KAFKA_RETRY_DELAY", 2*time.Second),
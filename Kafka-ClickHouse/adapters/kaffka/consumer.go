@@ -8,16 +8,15 @@ import (
 	"sync"
 	"time"
 
-	"github.com/Tonic56/crypto-asset-tracker-microservice/Kafka-ClickHouse/models"
 	"github.com/segmentio/kafka-go"
 )
 
-type consumer struct {
+type consumer[T any] struct {
 	reader *kafka.Reader
 	config kafkaConfig
 }
 
-func NewConsumer(ctx context.Context, cfg kafkaConfig) *consumer {
+func NewConsumer[T any](ctx context.Context, cfg kafkaConfig) *consumer[T] {
 	slog.Info("Start initializing consumer",
 		"brockers", cfg.Brokers,
 		"topic", cfg.Topic,
@@ -73,13 +72,13 @@ func NewConsumer(ctx context.Context, cfg kafkaConfig) *consumer {
 
 	slog.Info("✅ Kafka consumer initialized successfully")
 
-	return &consumer{
+	return &consumer[T]{
 		reader: reader,
 		config: cfg,
 	}
 }
 
-func (c *consumer) Start(ctx context.Context, wg *sync.WaitGroup, outChan chan<- models.KafkaMsg) {
+func (c *consumer[T]) Start(ctx context.Context, wg *sync.WaitGroup, outChan chan<- T) {
 	defer wg.Done()
 	defer c.reader.Close()
 	defer close(outChan)
@@ -100,9 +99,10 @@ func (c *consumer) Start(ctx context.Context, wg *sync.WaitGroup, outChan chan<-
 				continue
 			}
 
-			var kafMsg models.KafkaMsg
+			var kafMsg T
 			if err := json.Unmarshal(msg.Value, &kafMsg); err != nil {
-				slog.Error("Failed to parse Kafka message into struct KafkaMsg",
+				slog.Error("Failed to parse Kafka message",
+					"topic", c.config.Topic,
 					"error", err,
 					"offset", msg.Offset,
 					"partition", msg.Partition,
@@ -126,7 +126,7 @@ func (c *consumer) Start(ctx context.Context, wg *sync.WaitGroup, outChan chan<-
 					slog.Debug("Valid message commited succesfully",
 						"offset", msg.Offset,
 						"patrition", msg.Partition,
-						"symbol", kafMsg.Symbol)
+						"key", string(msg.Key))
 				}
 			}
 		}
@@ -39,13 +39,41 @@ func main() {
 		os.Exit(1)
 	}
 
+	klineRepo := repository.NewKlineRepository(chClient, clickHouseCfg)
+
+	if err := klineRepo.CreateTable(ctx); err != nil {
+		slog.Error("Failed to create kline table", "error", err)
+		os.Exit(1)
+	}
+
+	tradeRepo := repository.NewTradeRepository(chClient, clickHouseCfg)
+
+	if err := tradeRepo.CreateTable(ctx); err != nil {
+		slog.Error("Failed to create trade table", "error", err)
+		os.Exit(1)
+	}
+
 	kafkaMsgs := make(chan models.KafkaMsg, 500)
+	klines := make(chan models.Kline, 500)
+	trades := make(chan models.TradeTick, 5000)
+
+	cons := kaffka.NewConsumer[models.KafkaMsg](ctx, kafkaCfg)
+
+	klineCfg := kafkaCfg
+	klineCfg.Topic = kafkaCfg.KlineTopic
+	klineCons := kaffka.NewConsumer[models.Kline](ctx, klineCfg)
 
-	cons := kaffka.NewConsumer(ctx, kafkaCfg)
+	tradeCfg := kafkaCfg
+	tradeCfg.Topic = kafkaCfg.TradeTopic
+	tradeCons := kaffka.NewConsumer[models.TradeTick](ctx, tradeCfg)
 
-	wg.Add(2)
+	wg.Add(6)
 	go cons.Start(ctx, wg, kafkaMsgs)
 	go repo.BatchInsert(ctx, wg, kafkaMsgs)
+	go klineCons.Start(ctx, wg, klines)
+	go klineRepo.BatchInsert(ctx, wg, klines)
+	go tradeCons.Start(ctx, wg, trades)
+	go tradeRepo.BatchInsert(ctx, wg, trades)
 
 	<-c
 	cancel()
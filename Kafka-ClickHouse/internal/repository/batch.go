@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Kafka-ClickHouse/adapters/clkhouse"
+)
+
+// batchInsert buffers the messages of inputChan and hands them to insert
+// once BatchSize are buffered or BatchTimeout has passed. A failed batch
+// is logged and dropped.
+func batchInsert[T any](
+	ctx context.Context,
+	cfg clkhouse.ClickHouseConfig,
+	table string,
+	inputChan <-chan T,
+	insert func(ctx context.Context, batch []T) error,
+) {
+	slog.Info("🚀 Batch inserter started",
+		"table", table,
+		"batch_size", cfg.BatchSize,
+		"batch_timeout", cfg.BatchTimeout,
+	)
+
+	buffer := make([]T, 0, cfg.BatchSize)
+	timer := time.NewTimer(cfg.BatchTimeout)
+	defer timer.Stop()
+
+	flush := func() {
+		if len(buffer) == 0 {
+			return
+		}
+		start := time.Now()
+		if err := insert(ctx, buffer); err != nil {
+			slog.Error("Failed to insert batch", "table", table, "size", len(buffer), "error", err)
+		} else {
+			slog.Info("✍️ Batch inserted successfully", "table", table, "size", len(buffer), "duration", time.Since(start))
+		}
+		buffer = buffer[:0]
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			flush()
+			slog.Info("Batch inserter stopped", "table", table)
+			return
+
+		case <-timer.C:
+			flush()
+			timer.Reset(cfg.BatchTimeout)
+
+		case msg, ok := <-inputChan:
+			if !ok {
+				flush()
+				slog.Info("Batch inserter stopped", "table", table)
+				return
+			}
+
+			buffer = append(buffer, msg)
+			if len(buffer) >= cfg.BatchSize {
+				flush()
+				timer.Reset(cfg.BatchTimeout)
+			}
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Kafka-ClickHouse/adapters/clkhouse"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Kafka-ClickHouse/models"
+)
+
+// KlineRepository writes closed candles to the klines table. A candle sent
+// twice, e.g. after a Kafka redelivery, shares its sorting key with the
+// first copy and is merged away by the ReplacingMergeTree engine.
+type KlineRepository struct {
+	client *clkhouse.Client
+	cfg    clkhouse.ClickHouseConfig
+}
+
+func NewKlineRepository(client *clkhouse.Client, cfg clkhouse.ClickHouseConfig) *KlineRepository {
+	return &KlineRepository{
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+func (r *KlineRepository) CreateTable(ctx context.Context) error {
+	query := fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s.%s (
+				symbol LowCardinality(String),
+				"interval" LowCardinality(String),
+				open_time DateTime64(3),
+				close_time DateTime64(3),
+				open Decimal64(8),
+				high Decimal64(8),
+				low Decimal64(8),
+				close Decimal64(8),
+				base_volume Decimal128(8),
+				quote_volume Decimal128(8),
+				trades UInt64,
+				event_time DateTime64(3),
+				ingest_time DateTime64(3)
+			) ENGINE = ReplacingMergeTree(ingest_time)
+			ORDER BY (symbol, "interval", open_time)
+			PARTITION BY toYYYYMM(open_time)
+			SETTINGS index_granularity = 8192
+		`, r.cfg.Database, r.cfg.KlineTable)
+
+	slog.Info("Creating table if not exists", "table", r.cfg.KlineTable)
+
+	if err := r.client.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to create kline table: %w", err)
+	}
+
+	slog.Info("Table ready", "table", r.cfg.KlineTable)
+	return nil
+}
+
+func (r *KlineRepository) BatchInsert(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	inputChan <-chan models.Kline,
+) {
+	defer wg.Done()
+
+	batchInsert(ctx, r.cfg, r.cfg.KlineTable, inputChan, r.insert)
+}
+
+func (r *KlineRepository) insert(ctx context.Context, klines []models.Kline) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s.%s (
+		symbol, "interval", open_time, close_time, open, high, low, close, base_volume, quote_volume, trades, event_time, ingest_time)`, r.cfg.Database, r.cfg.KlineTable)
+
+	batch, err := r.client.PrepareBatch(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare batch: %w", err)
+	}
+
+	for _, kline := range klines {
+		err := batch.Append(
+			kline.Symbol,
+			kline.Interval,
+			time.UnixMilli(kline.OpenTime),
+			time.UnixMilli(kline.CloseTime),
+			kline.Open,
+			kline.High,
+			kline.Low,
+			kline.Close,
+			kline.BaseVolume,
+			kline.QuoteVolume,
+			uint64(kline.Trades),
+			time.UnixMilli(kline.EventTime),
+			time.UnixMilli(kline.IngestTime),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to append to batch: %w", err)
+		}
+	}
+
+	if err := batch.Send(); err != nil {
+		return fmt.Errorf("failed to sent batch: %w", err)
+	}
+	return nil
+}
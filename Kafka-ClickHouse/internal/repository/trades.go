@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Kafka-ClickHouse/adapters/clkhouse"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Kafka-ClickHouse/models"
+)
+
+// TradeRepository writes single trades to the trades table. A redelivered
+// trade has the sorting key of the first copy and is merged away; ticks
+// expire after TradesTTLDays.
+type TradeRepository struct {
+	client *clkhouse.Client
+	cfg    clkhouse.ClickHouseConfig
+}
+
+func NewTradeRepository(client *clkhouse.Client, cfg clkhouse.ClickHouseConfig) *TradeRepository {
+	return &TradeRepository{
+		client: client,
+		cfg:    cfg,
+	}
+}
+
+func (r *TradeRepository) CreateTable(ctx context.Context) error {
+	query := fmt.Sprintf(`
+			CREATE TABLE IF NOT EXISTS %s.%s (
+				symbol LowCardinality(String),
+				exchange LowCardinality(String),
+				agg_trade_id Int64,
+				first_trade_id Int64,
+				last_trade_id Int64,
+				price Decimal64(8),
+				quantity Decimal128(8),
+				buyer_maker Bool,
+				trade_time DateTime64(3),
+				event_time DateTime64(3),
+				ingest_time DateTime64(3)
+			) ENGINE = ReplacingMergeTree(ingest_time)
+			ORDER BY (symbol, trade_time, exchange, agg_trade_id)
+			PARTITION BY toYYYYMMDD(trade_time)
+			TTL toDateTime(trade_time) + INTERVAL %d DAY
+			SETTINGS index_granularity = 8192
+		`, r.cfg.Database, r.cfg.TradeTable, r.cfg.TradesTTLDays)
+
+	slog.Info("Creating table if not exists", "table", r.cfg.TradeTable)
+
+	if err := r.client.Exec(ctx, query); err != nil {
+		return fmt.Errorf("failed to create trade table: %w", err)
+	}
+
+	slog.Info("Table ready", "table", r.cfg.TradeTable)
+	return nil
+}
+
+func (r *TradeRepository) BatchInsert(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	inputChan <-chan models.TradeTick,
+) {
+	defer wg.Done()
+
+	batchInsert(ctx, r.cfg, r.cfg.TradeTable, inputChan, r.insert)
+}
+
+func (r *TradeRepository) insert(ctx context.Context, trades []models.TradeTick) error {
+	query := fmt.Sprintf(`
+		INSERT INTO %s.%s (
+		symbol, exchange, agg_trade_id, first_trade_id, last_trade_id, price, quantity, buyer_maker, trade_time, event_time, ingest_time)`, r.cfg.Database, r.cfg.TradeTable)
+
+	batch, err := r.client.PrepareBatch(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to prepare batch: %w", err)
+	}
+
+	for _, trade := range trades {
+		err := batch.Append(
+			trade.Symbol,
+			trade.Exchange,
+			trade.AggregateTradeID,
+			trade.FirstTradeID,
+			trade.LastTradeID,
+			trade.Price,
+			trade.Quantity,
+			trade.BuyerMaker,
+			time.UnixMilli(trade.TradeTime),
+			time.UnixMilli(trade.EventTime),
+			time.UnixMilli(trade.IngestTime),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to append to batch: %w", err)
+		}
+	}
+
+	if err := batch.Send(); err != nil {
+		return fmt.Errorf("failed to sent batch: %w", err)
+	}
+	return nil
+}
@@ -16,3 +16,39 @@ type KafkaMsg struct {
 	ChangePrice   decimal.Decimal `json:"change_price"`
 	ChangePercent decimal.Decimal `json:"change_percent"`
 }
+
+// TradeTick is a single trade produced by the Aggregator. Times are Unix
+// milliseconds; BuyerMaker is set when the taker sold.
+type TradeTick struct {
+	MessageID        string          `json:"message_id"`
+	Symbol           string          `json:"symbol"`
+	Exchange         string          `json:"exchange"`
+	AggregateTradeID int64           `json:"agg_trade_id"`
+	FirstTradeID     int64           `json:"first_trade_id"`
+	LastTradeID      int64           `json:"last_trade_id"`
+	Price            decimal.Decimal `json:"price"`
+	Quantity         decimal.Decimal `json:"quantity"`
+	BuyerMaker       bool            `json:"buyer_maker"`
+	TradeTime        int64           `json:"trade_time"`
+	EventTime        int64           `json:"event_time"`
+	IngestTime       int64           `json:"ingest_time"`
+}
+
+// Kline is a closed candle produced by the Aggregator. Times are Unix
+// milliseconds.
+type Kline struct {
+	MessageID   string          `json:"message_id"`
+	Symbol      string          `json:"symbol"`
+	Interval    string          `json:"interval"`
+	OpenTime    int64           `json:"open_time"`
+	CloseTime   int64           `json:"close_time"`
+	Open        decimal.Decimal `json:"open"`
+	High        decimal.Decimal `json:"high"`
+	Low         decimal.Decimal `json:"low"`
+	Close       decimal.Decimal `json:"close"`
+	BaseVolume  decimal.Decimal `json:"base_volume"`
+	QuoteVolume decimal.Decimal `json:"quote_volume"`
+	Trades      int64           `json:"trades"`
+	EventTime   int64           `json:"event_time"`
+	IngestTime  int64           `json:"ingest_time"`
+}
@@ -65,3 +65,5 @@ require (
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 	olympos.io/encoding/edn v0.0.0-20201019073823-d3554ca0b0a3 // indirect
 )
+
+replace github.com/Tonic56/proto-crypto-asset-tracker => ../proto-crypto-asset-tracker
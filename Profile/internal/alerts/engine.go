@@ -0,0 +1,399 @@
+package alerts
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/safehttp"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// historyStep is the spacing of the price samples kept for change rules.
+const historyStep = 10 * time.Second
+
+// FollowerID identifies the engine among the subscribers of a symbol, so the
+// price streams of alert symbols stay open while their owners are offline.
+var FollowerID = uuid.NewSHA1(uuid.NameSpaceURL, []byte("profile-service/alerts"))
+
+// Notifier delivers a firing to the websocket of a user, on whichever
+// replica it is connected to.
+type Notifier interface {
+	PublishAlert(ctx context.Context, userID uuid.UUID, event models.AlertEvent)
+}
+
+// Feed opens and closes the price streams the engine listens to.
+type Feed interface {
+	Follow(followerID uuid.UUID, symbol string)
+	Unfollow(followerID uuid.UUID, symbol string)
+}
+
+// Rates converts the prices of symbols quoted in other assets into USDT.
+type Rates interface {
+	// USDTRate returns the USDT price of one unit of the quote of symbol.
+	USDTRate(symbol string) (decimal.Decimal, bool)
+}
+
+type Config struct {
+	RefreshInterval time.Duration
+	WebhookTimeout  time.Duration
+}
+
+type tick struct {
+	symbol string
+	price  decimal.Decimal
+	at     time.Time
+}
+
+type sample struct {
+	at    time.Time
+	price decimal.Decimal
+}
+
+type firing struct {
+	event      models.AlertEvent
+	cutoff     time.Time // the previous firing must be older to fire again
+	userID     uuid.UUID
+	active     bool
+	webhookURL string
+}
+
+// Engine evaluates the active alert rules of all users against the prices
+// published by the Aggregator. Rules and holdings are reloaded from the
+// database every RefreshInterval.
+type Engine struct {
+	log        *slog.Logger
+	alertsRepo repository.AlertsRepository
+	coinsRepo  repository.CoinsRepository
+	notifier   Notifier
+	feed       Feed
+	rates      Rates
+	cfg        Config
+	httpClient *http.Client
+	ticks      chan tick
+
+	mu       sync.Mutex
+	alerts   []*models.Alert
+	holdings map[uuid.UUID][]models.Coin
+	prices   map[string]decimal.Decimal
+	history  map[string][]sample
+	followed map[string]struct{}
+}
+
+func NewEngine(
+	log *slog.Logger,
+	alertsRepo repository.AlertsRepository,
+	coinsRepo repository.CoinsRepository,
+	notifier Notifier,
+	feed Feed,
+	rates Rates,
+	cfg Config,
+) *Engine {
+	return &Engine{
+		log:        log,
+		alertsRepo: alertsRepo,
+		coinsRepo:  coinsRepo,
+		notifier:   notifier,
+		feed:       feed,
+		rates:      rates,
+		cfg:        cfg,
+		httpClient: safehttp.NewClient(cfg.WebhookTimeout),
+		ticks:      make(chan tick, 1000),
+		holdings:   make(map[uuid.UUID][]models.Coin),
+		prices:     make(map[string]decimal.Decimal),
+		history:    make(map[string][]sample),
+		followed:   make(map[string]struct{}),
+	}
+}
+
+func (e *Engine) Run(ctx context.Context) {
+	if err := e.Reload(); err != nil {
+		e.log.Error("alerts: failed to load rules", "error", err)
+	}
+
+	ticker := time.NewTicker(e.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			e.log.Info("alerts engine stopping...")
+			return
+		case <-ticker.C:
+			if err := e.Reload(); err != nil {
+				e.log.Error("alerts: failed to reload rules", "error", err)
+			}
+		case t := <-e.ticks:
+			e.Process(t.symbol, t.price, t.at)
+		}
+	}
+}
+
+// ObservePrice implements websocket.PriceObserver. It never blocks the
+// caller; ticks are dropped when the engine falls behind.
+func (e *Engine) ObservePrice(symbol string, price decimal.Decimal, at time.Time) {
+	select {
+	case e.ticks <- tick{symbol: symbol, price: price, at: at}:
+	default:
+		e.log.Warn("alerts: tick channel is full, dropping price", "symbol", symbol)
+	}
+}
+
+// Reload replaces the rules with the active ones stored in the database and
+// follows every symbol they depend on.
+func (e *Engine) Reload() error {
+	alerts, err := e.alertsRepo.ListActiveAlerts()
+	if err != nil {
+		return err
+	}
+
+	rules := make([]*models.Alert, 0, len(alerts))
+	holdings := make(map[uuid.UUID][]models.Coin)
+	symbols := make(map[string]struct{})
+
+	for i := range alerts {
+		alert := &alerts[i]
+		rules = append(rules, alert)
+
+		if !alert.Kind.IsPortfolio() {
+			symbols[alert.Symbol] = struct{}{}
+			continue
+		}
+
+		if _, ok := holdings[alert.UserID]; ok {
+			continue
+		}
+		coins, err := e.coinsRepo.GetCoins(alert.UserID)
+		if err != nil {
+			return err
+		}
+		holdings[alert.UserID] = coins
+		for _, coin := range coins {
+			symbols[coin.Symbol] = struct{}{}
+		}
+	}
+
+	e.mu.Lock()
+	e.alerts = rules
+	e.holdings = holdings
+	var follow, unfollow []string
+	for symbol := range symbols {
+		if _, ok := e.followed[symbol]; !ok {
+			follow = append(follow, symbol)
+		}
+	}
+	for symbol := range e.followed {
+		if _, ok := symbols[symbol]; !ok {
+			unfollow = append(unfollow, symbol)
+			delete(e.history, symbol)
+			delete(e.prices, symbol)
+		}
+	}
+	e.followed = symbols
+	e.mu.Unlock()
+
+	for _, symbol := range follow {
+		e.feed.Follow(FollowerID, symbol)
+	}
+	for _, symbol := range unfollow {
+		e.feed.Unfollow(FollowerID, symbol)
+	}
+
+	return nil
+}
+
+// Process records a price and fires every rule it satisfies.
+func (e *Engine) Process(symbol string, price decimal.Decimal, at time.Time) {
+	var fired []firing
+
+	e.mu.Lock()
+	e.prices[symbol] = price
+	e.record(symbol, price, at)
+
+	for _, alert := range e.alerts {
+		if !alert.Active {
+			continue
+		}
+		cutoff := at.Add(-alert.Cooldown())
+		if alert.LastFiredAt != nil && !alert.LastFiredAt.Before(cutoff) {
+			continue
+		}
+
+		value, ok := e.evaluate(alert, symbol, price, at)
+		if !ok {
+			continue
+		}
+
+		firedAt := at
+		alert.LastFiredAt = &firedAt
+		alert.Active = alert.Recurring
+
+		fired = append(fired, firing{
+			event: models.AlertEvent{
+				Type:      models.FrameAlert,
+				AlertID:   alert.ID,
+				Kind:      alert.Kind,
+				Symbol:    alert.Symbol,
+				Threshold: alert.Threshold,
+				Value:     value,
+				FiredAt:   firedAt,
+			},
+			cutoff:     cutoff,
+			userID:     alert.UserID,
+			active:     alert.Active,
+			webhookURL: alert.WebhookURL,
+		})
+	}
+	e.mu.Unlock()
+
+	for _, f := range fired {
+		e.deliver(f)
+	}
+}
+
+func (e *Engine) deliver(f firing) {
+	event := f.event
+
+	stored, err := e.alertsRepo.MarkFired(event.AlertID, event.FiredAt, f.cutoff, event.Value, f.active)
+	if err != nil {
+		e.log.Error("alerts: failed to store firing", "alertID", event.AlertID, "error", err)
+		return
+	}
+	if !stored {
+		e.log.Debug("alerts: firing already stored by another replica", "alertID", event.AlertID)
+		return
+	}
+
+	e.log.Info("alert fired", "alertID", event.AlertID, "userID", f.userID, "kind", event.Kind, "value", event.Value.String())
+	e.notifier.PublishAlert(context.Background(), f.userID, event)
+
+	if f.webhookURL == "" {
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		e.log.Error("alerts: failed to marshal alert event", "alertID", event.AlertID, "error", err)
+		return
+	}
+	go e.postWebhook(f.webhookURL, event.AlertID, payload)
+}
+
+func (e *Engine) postWebhook(webhookURL string, alertID uint, payload []byte) {
+	resp, err := e.httpClient.Post(webhookURL, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		e.log.Warn("alerts: webhook delivery failed", "alertID", alertID, "error", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		e.log.Warn("alerts: webhook returned non-2xx status", "alertID", alertID, "status", resp.Status)
+	}
+}
+
+// evaluate reports whether the rule holds after a price update of symbol and
+// the value it was compared on. The caller must hold e.mu.
+func (e *Engine) evaluate(alert *models.Alert, symbol string, price decimal.Decimal, at time.Time) (decimal.Decimal, bool) {
+	switch alert.Kind {
+	case models.AlertPriceAbove:
+		return price, alert.Symbol == symbol && price.GreaterThanOrEqual(alert.Threshold)
+	case models.AlertPriceBelow:
+		return price, alert.Symbol == symbol && price.LessThanOrEqual(alert.Threshold)
+	case models.AlertChangeUp, models.AlertChangeDown:
+		if alert.Symbol != symbol {
+			return decimal.Zero, false
+		}
+		base, ok := e.priceAt(symbol, at.Add(-alert.Window()))
+		if !ok || base.IsZero() {
+			return decimal.Zero, false
+		}
+		change := price.Sub(base).Div(base).Mul(decimal.NewFromInt(100))
+		if alert.Kind == models.AlertChangeUp {
+			return change, change.GreaterThanOrEqual(alert.Threshold)
+		}
+		return change, change.LessThanOrEqual(alert.Threshold.Neg())
+	case models.AlertPortfolioAbove, models.AlertPortfolioBelow:
+		value, ok := e.portfolioValue(alert, symbol)
+		if !ok {
+			return decimal.Zero, false
+		}
+		if alert.Kind == models.AlertPortfolioAbove {
+			return value, value.GreaterThanOrEqual(alert.Threshold)
+		}
+		return value, value.LessThanOrEqual(alert.Threshold)
+	}
+	return decimal.Zero, false
+}
+
+// portfolioValue values the holdings watched by a portfolio rule in USDT. It
+// only succeeds when symbol is one of them and every one of them has a price
+// and a USDT rate, so a missing quote never reads as a drop in value.
+func (e *Engine) portfolioValue(alert *models.Alert, symbol string) (decimal.Decimal, bool) {
+	value := decimal.Zero
+	relevant := false
+
+	for _, coin := range e.holdings[alert.UserID] {
+		if alert.PortfolioID != 0 && coin.PortfolioID != alert.PortfolioID {
+			continue
+		}
+		if !coin.Quantity.IsPositive() {
+			continue
+		}
+
+		price, ok := e.prices[coin.Symbol]
+		if !ok {
+			return decimal.Zero, false
+		}
+		rate, ok := e.rates.USDTRate(coin.Symbol)
+		if !ok {
+			return decimal.Zero, false
+		}
+		if coin.Symbol == symbol {
+			relevant = true
+		}
+		value = value.Add(coin.Quantity.Mul(price).Mul(rate))
+	}
+
+	return value, relevant
+}
+
+// record keeps one sample per historyStep and drops the samples no change
+// rule can reach any more. The caller must hold e.mu.
+func (e *Engine) record(symbol string, price decimal.Decimal, at time.Time) {
+	samples := e.history[symbol]
+	if n := len(samples); n > 0 && at.Sub(samples[n-1].at) < historyStep {
+		return
+	}
+	samples = append(samples, sample{at: at, price: price})
+
+	cutoff := at.Add(-models.MaxAlertWindow)
+	for len(samples) > 1 && !samples[1].at.After(cutoff) {
+		samples = samples[1:]
+	}
+	e.history[symbol] = samples
+}
+
+// priceAt returns the last sampled price at or before t. The caller must
+// hold e.mu.
+func (e *Engine) priceAt(symbol string, t time.Time) (decimal.Decimal, bool) {
+	samples := e.history[symbol]
+	if len(samples) == 0 || samples[0].at.After(t) {
+		return decimal.Zero, false
+	}
+
+	price := samples[0].price
+	for _, s := range samples[1:] {
+		if s.at.After(t) {
+			break
+		}
+		price = s.price
+	}
+	return price, true
+}
@@ -0,0 +1,240 @@
+package alerts_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/alerts"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type recorder struct {
+	mu       sync.Mutex
+	events   []models.AlertEvent
+	followed map[string]bool
+	rates    map[string]decimal.Decimal
+}
+
+func (r *recorder) PublishAlert(_ context.Context, _ uuid.UUID, event models.AlertEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, event)
+}
+
+func (r *recorder) Follow(_ uuid.UUID, symbol string) {
+	r.followed[symbol] = true
+}
+
+func (r *recorder) Unfollow(_ uuid.UUID, symbol string) {
+	delete(r.followed, symbol)
+}
+
+// USDTRate prices the quote of USDT pairs at 1 and of any other symbol at
+// r.rates[symbol].
+func (r *recorder) USDTRate(symbol string) (decimal.Decimal, bool) {
+	if strings.HasSuffix(symbol, "usdt") {
+		return decimal.NewFromInt(1), true
+	}
+	rate, ok := r.rates[symbol]
+	return rate, ok
+}
+
+func (r *recorder) fired() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.events)
+}
+
+func setupEngine(t *testing.T, coins []models.Coin, rules ...models.Alert) (*alerts.Engine, *recorder, repository.AlertsRepository) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(&models.User{}, &models.Portfolio{}, &models.Coin{}, &models.Alert{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	user := &models.User{ID: uuid.New(), Name: "alerts_user"}
+	if err := repository.NewUsersRepository(db).CreateUserProfile(user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	coinsRepo := repository.NewCoinsRepository(db)
+	for _, coin := range coins {
+		coin.UserID = user.ID
+		if err := coinsRepo.AddCoin(&coin); err != nil {
+			t.Fatalf("failed to add coin: %v", err)
+		}
+	}
+
+	alertsRepo := repository.NewAlertsRepository(db)
+	for i := range rules {
+		rules[i].UserID = user.ID
+		rules[i].Active = true
+		if err := alertsRepo.CreateAlert(&rules[i]); err != nil {
+			t.Fatalf("failed to create alert: %v", err)
+		}
+	}
+
+	rec := &recorder{followed: make(map[string]bool), rates: make(map[string]decimal.Decimal)}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	engine := alerts.NewEngine(log, alertsRepo, coinsRepo, rec, rec, rec, alerts.Config{
+		RefreshInterval: time.Minute,
+		WebhookTimeout:  time.Second,
+	})
+	if err := engine.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	return engine, rec, alertsRepo
+}
+
+func TestPriceAlert(t *testing.T) {
+	engine, rec, alertsRepo := setupEngine(t, nil, models.Alert{
+		Kind:      models.AlertPriceAbove,
+		Symbol:    "btcusdt",
+		Threshold: decimal.NewFromInt(70000),
+	})
+	now := time.Now()
+
+	if !rec.followed["btcusdt"] {
+		t.Fatalf("Expected engine to follow btcusdt")
+	}
+
+	engine.Process("btcusdt", decimal.NewFromInt(69000), now)
+	if rec.fired() != 0 {
+		t.Fatalf("Expected no alert below threshold, got %d", rec.fired())
+	}
+
+	engine.Process("btcusdt", decimal.NewFromInt(70500), now.Add(time.Second))
+	engine.Process("btcusdt", decimal.NewFromInt(71000), now.Add(2*time.Second))
+	if rec.fired() != 1 {
+		t.Fatalf("Expected one-shot alert to fire once, got %d", rec.fired())
+	}
+
+	active, err := alertsRepo.ListActiveAlerts()
+	if err != nil || len(active) != 0 {
+		t.Errorf("Expected one-shot alert to be deactivated, got %d active (err: %v)", len(active), err)
+	}
+}
+
+func TestChangeAlertCooldown(t *testing.T) {
+	engine, rec, _ := setupEngine(t, nil, models.Alert{
+		Kind:        models.AlertChangeDown,
+		Symbol:      "ethusdt",
+		Threshold:   decimal.NewFromInt(5),
+		WindowSec:   3600,
+		Recurring:   true,
+		CooldownSec: 600,
+	})
+	start := time.Now().Add(-2 * time.Hour)
+
+	engine.Process("ethusdt", decimal.NewFromInt(3000), start)
+
+	t.Run("no_history_for_window", func(t *testing.T) {
+		engine.Process("ethusdt", decimal.NewFromInt(2000), start.Add(30*time.Minute))
+		if rec.fired() != 0 {
+			t.Errorf("Expected no alert without an hour of history, got %d", rec.fired())
+		}
+	})
+
+	t.Run("drop_fires_once_per_cooldown", func(t *testing.T) {
+		engine.Process("ethusdt", decimal.NewFromInt(2800), start.Add(time.Hour))
+		engine.Process("ethusdt", decimal.NewFromInt(2800), start.Add(time.Hour+time.Minute))
+		if rec.fired() != 1 {
+			t.Fatalf("Expected alert to fire once within cooldown, got %d", rec.fired())
+		}
+
+		engine.Process("ethusdt", decimal.NewFromInt(2800), start.Add(time.Hour+11*time.Minute))
+		if rec.fired() != 2 {
+			t.Errorf("Expected recurring alert to fire again after cooldown, got %d", rec.fired())
+		}
+	})
+}
+
+func TestPortfolioAlertMixedQuotes(t *testing.T) {
+	engine, rec, _ := setupEngine(t, []models.Coin{
+		{PortfolioID: 1, Symbol: "btcusdt", Quantity: decimal.NewFromInt(1)},
+		{PortfolioID: 1, Symbol: "ethbtc", Quantity: decimal.NewFromInt(10)},
+	}, models.Alert{
+		Kind:      models.AlertPortfolioAbove,
+		Threshold: decimal.NewFromInt(100000),
+	})
+	now := time.Now()
+
+	engine.Process("btcusdt", decimal.NewFromInt(60000), now)
+
+	t.Run("missing_rate_skips_user", func(t *testing.T) {
+		engine.Process("ethbtc", decimal.NewFromFloat(0.05), now)
+		if rec.fired() != 0 {
+			t.Errorf("Expected no alert without a btc rate, got %d", rec.fired())
+		}
+	})
+
+	t.Run("value_is_summed_in_usdt", func(t *testing.T) {
+		// 1 × 60000 + 10 × 0.05 × 60000 = 90000 USDT, not 60000.5.
+		rec.rates["ethbtc"] = decimal.NewFromInt(60000)
+		engine.Process("ethbtc", decimal.NewFromFloat(0.05), now.Add(time.Second))
+		if rec.fired() != 0 {
+			t.Fatalf("Expected no alert at 90000 USDT, got %d", rec.fired())
+		}
+
+		engine.Process("ethbtc", decimal.NewFromFloat(0.07), now.Add(2*time.Second))
+		if rec.fired() != 1 {
+			t.Fatalf("Expected alert at 102000 USDT, got %d", rec.fired())
+		}
+		if value := rec.events[0].Value; !value.Equal(decimal.NewFromInt(102000)) {
+			t.Errorf("Expected value 102000, got %s", value)
+		}
+	})
+}
+
+func TestAlertFiresOnceAcrossReplicas(t *testing.T) {
+	first, rec, alertsRepo := setupEngine(t, nil, models.Alert{
+		Kind:        models.AlertPriceAbove,
+		Symbol:      "btcusdt",
+		Threshold:   decimal.NewFromInt(70000),
+		Recurring:   true,
+		CooldownSec: 600,
+	})
+
+	other := &recorder{followed: make(map[string]bool)}
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	second := alerts.NewEngine(log, alertsRepo, nil, other, other, other, alerts.Config{
+		RefreshInterval: time.Minute,
+		WebhookTimeout:  time.Second,
+	})
+	if err := second.Reload(); err != nil {
+		t.Fatalf("Reload failed: %v", err)
+	}
+
+	now := time.Now()
+	first.Process("btcusdt", decimal.NewFromInt(71000), now)
+	second.Process("btcusdt", decimal.NewFromInt(71000), now)
+	if got := rec.fired() + other.fired(); got != 1 {
+		t.Fatalf("Expected one delivery across replicas, got %d", got)
+	}
+
+	second.Process("btcusdt", decimal.NewFromInt(71000), now.Add(11*time.Minute))
+	first.Process("btcusdt", decimal.NewFromInt(71000), now.Add(11*time.Minute))
+	if got := rec.fired() + other.fired(); got != 2 {
+		t.Errorf("Expected one more delivery after cooldown, got %d", got)
+	}
+}
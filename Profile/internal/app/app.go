@@ -9,16 +9,21 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/alerts"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/config"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/grpc/profile"
 	httphandler "github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/handler/http"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/history"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/service"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/symbols"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/websocket"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/storage/postgres"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/storage/redis"
 	"github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/auth"
 	grpc_profile "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/profile"
+	"github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -31,7 +36,13 @@ type App struct {
 	httpServer      *http.Server
 	storage         *postgres.Storage
 	redisSubscriber *redis.Subscriber
+	redisPublisher  *redis.Publisher
+	redisIntents    *redis.Intents
+	redisPrices     *redis.Prices
 	wsManager       *websocket.Manager
+	alertsEngine    *alerts.Engine
+	historyRecorder *history.Recorder
+	symbolRegistry  *symbols.Registry
 
 	
 	ctx    context.Context
@@ -42,22 +53,62 @@ func New(log *slog.Logger, cfg *config.Config) *App {
 	
 	ctx, cancel := context.WithCancel(context.Background())
 
-	storage, err := postgres.New(cfg.Database)
+	storage, err := postgres.New(cfg.Database, cfg.Symbols.QuotePreference)
 	if err != nil {
 		panic(fmt.Errorf("failed to init storage: %w", err))
 	}
 
-	redisSubscriber := redis.NewSubscriber(log)
+	var redisSubscriber *redis.Subscriber
+	if cfg.Prices.Transport == models.PriceTransportStreams {
+		redisSubscriber = redis.NewStreamSubscriber(log, cfg.Prices.Stream, cfg.Prices.StreamGroup)
+	} else {
+		redisSubscriber = redis.NewSubscriber(log)
+	}
+	redisPublisher := redis.NewPublisher(log)
+	redisIntents := redis.NewIntents(log, cfg.Subscriptions.TTL)
+	redisPrices := redis.NewPrices(log)
+
+	socketConn, err := grpc.NewClient(cfg.GRPC.SocketServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		panic(fmt.Errorf("failed to connect to socket service: %w", err))
+	}
+	symbolRegistry := symbols.NewRegistry(log, socket.NewSocketServiceClient(socketConn), cfg.Symbols.RefreshInterval, cfg.Symbols.QuotePreference)
 
 	usersRepo := repository.NewUsersRepository(storage.DB)
 	usersService := service.NewUsersService(usersRepo)
 
 	coinsRepo := repository.NewCoinsRepository(storage.DB)
-	coinsService := service.NewCoinsService(coinsRepo, storage.DB)
+	transactionsRepo := repository.NewTransactionsRepository(storage.DB)
+	coinsService := service.NewCoinsService(coinsRepo, transactionsRepo, storage.DB, redisPublisher, symbolRegistry)
+
+	portfoliosRepo := repository.NewPortfoliosRepository(storage.DB)
+	portfoliosService := service.NewPortfoliosService(portfoliosRepo, storage.DB, redisPublisher)
+
+	alertsRepo := repository.NewAlertsRepository(storage.DB)
+	alertsService := service.NewAlertsService(alertsRepo, portfoliosRepo, symbolRegistry)
+
+	watchlistsRepo := repository.NewWatchlistsRepository(storage.DB)
+	watchlistsService := service.NewWatchlistsService(watchlistsRepo, storage.DB, redisPublisher, symbolRegistry)
+
+	wsManager := websocket.NewManager(log, redisSubscriber, redisIntents, redisPrices, symbolRegistry, usersService, coinsService, alertsService, cfg.Prices.MaxAge)
+
+	alertsEngine := alerts.NewEngine(log, alertsRepo, coinsRepo, redisPublisher, wsManager, wsManager, alerts.Config{
+		RefreshInterval: cfg.Alerts.RefreshInterval,
+		WebhookTimeout:  cfg.Alerts.WebhookTimeout,
+	})
+	wsManager.AddObserver(alertsEngine)
 
-	wsManager := websocket.NewManager(log, redisSubscriber, coinsService)
+	snapshotsRepo := repository.NewSnapshotsRepository(storage.DB)
+	historyService := service.NewHistoryService(snapshotsRepo)
 
-	grpcHandler := profile.NewServer(usersService, coinsService, log)
+	historyRecorder := history.NewRecorder(log, coinsRepo, snapshotsRepo, wsManager, wsManager, history.Config{
+		SnapshotInterval: cfg.History.SnapshotInterval,
+		MinuteRetention:  cfg.History.MinuteRetention,
+		HourRetention:    cfg.History.HourRetention,
+	})
+	wsManager.AddObserver(historyRecorder)
+
+	grpcHandler := profile.NewServer(usersService, coinsService, portfoliosService, log)
 	grpcServer := grpc.NewServer()
 	grpc_profile.RegisterProfileServer(grpcServer, grpcHandler)
 
@@ -68,7 +119,7 @@ func New(log *slog.Logger, cfg *config.Config) *App {
 	authClient := auth.NewAuthClient(authConn)
 
 	ginEngine := gin.New()
-	httpHandler := httphandler.NewHandler(usersService, coinsService, wsManager, log, cfg.Security.JWTSecret, authClient)
+	httpHandler := httphandler.NewHandler(usersService, coinsService, portfoliosService, alertsService, watchlistsService, historyService, symbolRegistry, wsManager, log, cfg.Security.JWTSecret, authClient)
 	httpHandler.RegisterRoutes(ginEngine)
 
 	httpServer := &http.Server{
@@ -83,7 +134,13 @@ func New(log *slog.Logger, cfg *config.Config) *App {
 		httpServer:      httpServer,
 		storage:         storage,
 		redisSubscriber: redisSubscriber,
+		redisPublisher:  redisPublisher,
+		redisIntents:    redisIntents,
+		redisPrices:     redisPrices,
 		wsManager:       wsManager,
+		alertsEngine:    alertsEngine,
+		historyRecorder: historyRecorder,
+		symbolRegistry:  symbolRegistry,
 		ctx:             ctx,
 		cancel:          cancel,
 	}
@@ -100,6 +157,24 @@ func (a *App) Run() error {
 		a.log.Info("websocket manager stopped")
 	}()
 
+	go func() {
+		a.log.Info("alerts engine started")
+		a.alertsEngine.Run(a.ctx)
+		a.log.Info("alerts engine stopped")
+	}()
+
+	go func() {
+		a.log.Info("history recorder started")
+		a.historyRecorder.Run(a.ctx)
+		a.log.Info("history recorder stopped")
+	}()
+
+	go func() {
+		a.log.Info("symbol registry started")
+		a.symbolRegistry.Run(a.ctx)
+		a.log.Info("symbol registry stopped")
+	}()
+
 	
 	go func() {
 		if err := a.runGRPC(); err != nil {
@@ -144,6 +219,9 @@ func (a *App) Stop() {
 
 	
 	a.redisSubscriber.Close()
+	a.redisPublisher.Close()
+	a.redisIntents.Close()
+	a.redisPrices.Close()
 
 	
 	if err := a.storage.Stop(); err != nil {
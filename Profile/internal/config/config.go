@@ -5,23 +5,30 @@ import (
 	"os"
 	"time"
 
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
 	"github.com/ilyakaznacheev/cleanenv"
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Env      string `env:"ENV" env-default:"local"`
-	GRPC     GRPCConfig
-	HTTP     HTTPConfig
-	Database DBConfig
-	Security SecConfig
+	Env           string `env:"ENV" env-default:"local"`
+	GRPC          GRPCConfig
+	HTTP          HTTPConfig
+	Database      DBConfig
+	Security      SecConfig
+	Alerts        AlertsConfig
+	History       HistoryConfig
+	Symbols       SymbolsConfig
+	Subscriptions SubscriptionsConfig
+	Prices        PricesConfig
 }
 
 type GRPCConfig struct {
-	Port             uint16        `env:"GRPC_PORT" env-default:"50052"`
-	Timeout          time.Duration `env:"GRPC_TIMEOUT" env-default:"1h"`
-	EnableReflection bool          `env:"GRPC_ENABLE_REFLECTION" env-default:"true"`
-	AuthServiceAddr  string        `env:"AUTH_SERVICE_ADDR" env-required:"true"`
+	Port              uint16        `env:"GRPC_PORT" env-default:"50052"`
+	Timeout           time.Duration `env:"GRPC_TIMEOUT" env-default:"1h"`
+	EnableReflection  bool          `env:"GRPC_ENABLE_REFLECTION" env-default:"true"`
+	AuthServiceAddr   string        `env:"AUTH_SERVICE_ADDR" env-required:"true"`
+	SocketServiceAddr string        `env:"SOCKET_SERVICE_ADDR" env-default:"socket-service:50051"`
 }
 
 type HTTPConfig struct {
@@ -37,6 +44,39 @@ type DBConfig struct {
 	DBName   string `env:"POSTGRES_DB" env-default:"profile_db"`
 }
 
+type AlertsConfig struct {
+	RefreshInterval time.Duration `env:"ALERTS_REFRESH_INTERVAL" env-default:"15s"`
+	WebhookTimeout  time.Duration `env:"ALERTS_WEBHOOK_TIMEOUT" env-default:"5s"`
+}
+
+type HistoryConfig struct {
+	SnapshotInterval time.Duration `env:"HISTORY_SNAPSHOT_INTERVAL" env-default:"1m"`
+	MinuteRetention  time.Duration `env:"HISTORY_MINUTE_RETENTION" env-default:"24h"`
+	HourRetention    time.Duration `env:"HISTORY_HOUR_RETENTION" env-default:"720h"`
+}
+
+type SymbolsConfig struct {
+	RefreshInterval time.Duration `env:"SYMBOLS_REFRESH_INTERVAL" env-default:"1h"`
+	QuotePreference []string      `env:"QUOTE_PREFERENCE" env-default:"usdt,fdusd,usdc,btc,eth,bnb,eur,try"`
+}
+
+type SubscriptionsConfig struct {
+	TTL time.Duration `env:"SUBSCRIPTIONS_TTL" env-default:"30s"`
+}
+
+// PricesConfig picks how prices reach the replica: "pubsub", or "streams"
+// to read them from a Redis stream through a consumer group. StreamGroup is
+// required with streams: every replica needs a name of its own that
+// survives restarts, which a host name does not under most orchestrators,
+// and each name left behind keeps a group on the stream. Stream entries
+// older than MaxAge are not passed to alerts and history.
+type PricesConfig struct {
+	Transport   string        `env:"PRICE_TRANSPORT" env-default:"pubsub"`
+	Stream      string        `env:"PRICE_STREAM" env-default:"prices"`
+	StreamGroup string        `env:"PRICE_STREAM_GROUP"`
+	MaxAge      time.Duration `env:"PRICE_MAX_AGE" env-default:"1m"`
+}
+
 type SecConfig struct {
 	JWTSecret string `env:"JWT_SECRET" env-required:"true"`
 }
@@ -53,5 +93,16 @@ func MustLoad() *Config {
 		os.Exit(1)
 	}
 
+	switch cfg.Prices.Transport {
+	case models.PriceTransportPubSub, models.PriceTransportStreams:
+	default:
+		slog.Error("unknown price transport", "transport", cfg.Prices.Transport)
+		os.Exit(1)
+	}
+	if cfg.Prices.Transport == models.PriceTransportStreams && cfg.Prices.StreamGroup == "" {
+		slog.Error("PRICE_STREAM_GROUP is required with the streams price transport")
+		os.Exit(1)
+	}
+
 	return &cfg
 }
\ No newline at end of file
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"time"
 
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/service"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
 	grpc_profile "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/profile"
@@ -18,16 +20,18 @@ import (
 
 type server struct {
 	grpc_profile.UnimplementedProfileServer
-	usersService service.UsersService
-	coinsService service.CoinsService
-	log          *slog.Logger
+	usersService      service.UsersService
+	coinsService      service.CoinsService
+	portfoliosService service.PortfoliosService
+	log               *slog.Logger
 }
 
-func NewServer(usersService service.UsersService, coinsService service.CoinsService, log *slog.Logger) *server {
+func NewServer(usersService service.UsersService, coinsService service.CoinsService, portfoliosService service.PortfoliosService, log *slog.Logger) *server {
 	return &server{
-		usersService: usersService,
-		coinsService: coinsService,
-		log:          log,
+		usersService:      usersService,
+		coinsService:      coinsService,
+		portfoliosService: portfoliosService,
+		log:               log,
 	}
 }
 
@@ -45,18 +49,17 @@ func (s *server) GetUserProfile(ctx context.Context, req *grpc_profile.GetUserPr
 		return nil, status.Error(codes.Internal, "failed to get user profile")
 	}
 
-	cryptoCoins := make([]*grpc_profile.Coin, 0, len(user.Coins))
-	for _, coin := range user.Coins {
-		cryptoCoins = append(cryptoCoins, &grpc_profile.Coin{
-			Symbol:   coin.Symbol,
-			Quantity: coin.Quantity.String(),
-		})
+	portfolios := make([]*grpc_profile.Portfolio, 0, len(user.Portfolios))
+	for i := range user.Portfolios {
+		portfolios = append(portfolios, toProtoPortfolio(&user.Portfolios[i]))
 	}
 
 	return &grpc_profile.GetUserProfileResponse{
-		UserId: user.ID.String(),
-		Name:   user.Name,
-		Coins:  cryptoCoins,
+		UserId:     user.ID.String(),
+		Name:       user.Name,
+		Coins:      toProtoCoins(user.Coins),
+		CostMethod: string(user.CostMethod),
+		Portfolios: portfolios,
 	}, nil
 }
 
@@ -67,9 +70,9 @@ func (s *server) UpdateCoinQuantity(ctx context.Context, req *grpc_profile.Updat
 		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
 	}
 
-	symbol := req.GetSymbol()
-	if symbol == "" {
-		return nil, status.Error(codes.InvalidArgument, "invalid symbol")
+	asset, symbol := req.GetAsset(), req.GetSymbol()
+	if asset == "" && symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "asset or symbol is required")
 	}
 
 	quantityDecimal, err := decimal.NewFromString(req.GetQuantity())
@@ -81,18 +84,36 @@ func (s *server) UpdateCoinQuantity(ctx context.Context, req *grpc_profile.Updat
 		return nil, status.Error(codes.InvalidArgument, "quantity cannot be zero")
 	}
 
-	_, err = s.coinsService.UpdateCoinQuantity(ctx, userID, symbol, quantityDecimal)
-	
+	price, err := parseOptionalDecimal(req.GetPrice())
 	if err != nil {
-		if errors.Is(err, errs.ErrInsufficientFunds) {
-			return nil, status.Error(codes.FailedPrecondition, "insufficient funds")
-		}
-		
-        s.log.Error("failed to update coin quantity", slog.Any("error", err))
-        return nil, status.Error(codes.Internal, "failed to process request")
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid price format: %v", err))
+	}
+
+	fee, err := parseOptionalDecimal(req.GetFee())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid fee format: %v", err))
+	}
+
+	side := models.SideBuy
+	if quantityDecimal.IsNegative() {
+		side = models.SideSell
+	}
+
+	_, err = s.coinsService.RecordTransaction(ctx, userID, &models.Transaction{
+		PortfolioID: uint(req.GetPortfolioId()),
+		Asset:       asset,
+		Symbol:      symbol,
+		Side:        side,
+		Quantity:    quantityDecimal.Abs(),
+		Price:       price,
+		Fee:         fee,
+		Note:        req.GetNote(),
+	})
+	if err != nil {
+		return nil, s.transactionError(err, "portfolio not found", "failed to update coin quantity")
 	}
 
-	s.log.Info("Addcoin called", "userID", userID, "symbol", symbol, "quantity", quantityDecimal.String())
+	s.log.Info("Addcoin called", "userID", userID, "asset", asset, "symbol", symbol, "quantity", quantityDecimal.String())
 
 	return &grpc_profile.UpdateCoinQuantityResponse{Success: true}, nil
 }
@@ -103,12 +124,15 @@ func (s *server) DeleteCoin(ctx context.Context, req *grpc_profile.DeleteCoinReq
 		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
 	}
 
-	symbol := req.GetSymbol() 
-	if symbol == "" {
-		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	asset := req.GetAsset()
+	if asset == "" {
+		asset = req.GetSymbol()
+	}
+	if asset == "" {
+		return nil, status.Error(codes.InvalidArgument, "asset is required")
 	}
 
-	if err := s.coinsService.DeleteCoin(ctx, userID, symbol); err != nil {
+	if err := s.coinsService.DeleteCoin(ctx, userID, uint(req.GetPortfolioId()), asset); err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
 			return nil, status.Error(codes.NotFound, "coin not found in portfolio")
 		}
@@ -118,4 +142,286 @@ func (s *server) DeleteCoin(ctx context.Context, req *grpc_profile.DeleteCoinReq
 	}
 
 	return &grpc_profile.DeleteCoinResponse{Success: true}, nil
-}
\ No newline at end of file
+}
+
+func (s *server) ListTransactions(ctx context.Context, req *grpc_profile.ListTransactionsRequest) (*grpc_profile.ListTransactionsResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	asset := req.GetAsset()
+	if asset == "" {
+		asset = req.GetSymbol()
+	}
+
+	txs, err := s.coinsService.ListTransactions(ctx, userID, uint(req.GetPortfolioId()), asset, req.GetIncludeVoided())
+	if err != nil {
+		s.log.Error("failed to list transactions", slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "failed to process request")
+	}
+
+	transactions := make([]*grpc_profile.Transaction, 0, len(txs))
+	for i := range txs {
+		transactions = append(transactions, toProtoTransaction(&txs[i]))
+	}
+
+	return &grpc_profile.ListTransactionsResponse{Transactions: transactions}, nil
+}
+
+func (s *server) EditTransaction(ctx context.Context, req *grpc_profile.EditTransactionRequest) (*grpc_profile.EditTransactionResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	if req.GetTransactionId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "transaction ID is required")
+	}
+
+	var patch service.TransactionPatch
+
+	if req.GetSide() != "" {
+		side := models.Side(req.GetSide())
+		patch.Side = &side
+	}
+	if req.GetQuantity() != "" {
+		quantity, err := decimal.NewFromString(req.GetQuantity())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid quantity format: %v", err))
+		}
+		patch.Quantity = &quantity
+	}
+	if req.GetPrice() != "" {
+		price, err := decimal.NewFromString(req.GetPrice())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid price format: %v", err))
+		}
+		patch.Price = &price
+	}
+	if req.GetFee() != "" {
+		fee, err := decimal.NewFromString(req.GetFee())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("invalid fee format: %v", err))
+		}
+		patch.Fee = &fee
+	}
+	if req.GetExecutedAt() != 0 {
+		executedAt := time.UnixMilli(req.GetExecutedAt()).UTC()
+		patch.ExecutedAt = &executedAt
+	}
+	if req.Note != nil {
+		note := req.GetNote()
+		patch.Note = &note
+	}
+
+	tx, err := s.coinsService.EditTransaction(ctx, userID, uint(req.GetTransactionId()), patch)
+	if err != nil {
+		return nil, s.transactionError(err, "transaction not found", "failed to edit transaction")
+	}
+
+	return &grpc_profile.EditTransactionResponse{Transaction: toProtoTransaction(tx)}, nil
+}
+
+func (s *server) VoidTransaction(ctx context.Context, req *grpc_profile.VoidTransactionRequest) (*grpc_profile.VoidTransactionResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	if req.GetTransactionId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "transaction ID is required")
+	}
+
+	if err := s.coinsService.VoidTransaction(ctx, userID, uint(req.GetTransactionId())); err != nil {
+		return nil, s.transactionError(err, "transaction not found", "failed to void transaction")
+	}
+
+	return &grpc_profile.VoidTransactionResponse{Success: true}, nil
+}
+
+func (s *server) SetCostMethod(ctx context.Context, req *grpc_profile.SetCostMethodRequest) (*grpc_profile.SetCostMethodResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	if err := s.coinsService.SetCostMethod(ctx, userID, models.CostMethod(req.GetMethod())); err != nil {
+		return nil, s.transactionError(err, "user not found", "failed to set cost method")
+	}
+
+	return &grpc_profile.SetCostMethodResponse{Success: true}, nil
+}
+
+func (s *server) ListPortfolios(ctx context.Context, req *grpc_profile.ListPortfoliosRequest) (*grpc_profile.ListPortfoliosResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	list, err := s.portfoliosService.ListPortfolios(ctx, userID)
+	if err != nil {
+		s.log.Error("failed to list portfolios", slog.Any("error", err))
+		return nil, status.Error(codes.Internal, "failed to process request")
+	}
+
+	portfolios := make([]*grpc_profile.Portfolio, 0, len(list))
+	for i := range list {
+		portfolios = append(portfolios, toProtoPortfolio(&list[i]))
+	}
+
+	return &grpc_profile.ListPortfoliosResponse{Portfolios: portfolios}, nil
+}
+
+func (s *server) GetPortfolio(ctx context.Context, req *grpc_profile.GetPortfolioRequest) (*grpc_profile.GetPortfolioResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	if req.GetPortfolioId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "portfolio ID is required")
+	}
+
+	portfolio, err := s.portfoliosService.GetPortfolio(ctx, userID, uint(req.GetPortfolioId()))
+	if err != nil {
+		return nil, s.portfolioError(err, "failed to get portfolio")
+	}
+
+	return &grpc_profile.GetPortfolioResponse{Portfolio: toProtoPortfolio(portfolio)}, nil
+}
+
+func (s *server) CreatePortfolio(ctx context.Context, req *grpc_profile.CreatePortfolioRequest) (*grpc_profile.CreatePortfolioResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	portfolio, err := s.portfoliosService.CreatePortfolio(ctx, userID, req.GetName())
+	if err != nil {
+		return nil, s.portfolioError(err, "failed to create portfolio")
+	}
+
+	return &grpc_profile.CreatePortfolioResponse{Portfolio: toProtoPortfolio(portfolio)}, nil
+}
+
+func (s *server) RenamePortfolio(ctx context.Context, req *grpc_profile.RenamePortfolioRequest) (*grpc_profile.RenamePortfolioResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	if req.GetPortfolioId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "portfolio ID is required")
+	}
+
+	if err := s.portfoliosService.RenamePortfolio(ctx, userID, uint(req.GetPortfolioId()), req.GetName()); err != nil {
+		return nil, s.portfolioError(err, "failed to rename portfolio")
+	}
+
+	return &grpc_profile.RenamePortfolioResponse{Success: true}, nil
+}
+
+func (s *server) DeletePortfolio(ctx context.Context, req *grpc_profile.DeletePortfolioRequest) (*grpc_profile.DeletePortfolioResponse, error) {
+	userID, err := uuid.Parse(req.GetUserId())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid user ID")
+	}
+
+	if req.GetPortfolioId() == 0 {
+		return nil, status.Error(codes.InvalidArgument, "portfolio ID is required")
+	}
+
+	if err := s.portfoliosService.DeletePortfolio(ctx, userID, uint(req.GetPortfolioId())); err != nil {
+		return nil, s.portfolioError(err, "failed to delete portfolio")
+	}
+
+	return &grpc_profile.DeletePortfolioResponse{Success: true}, nil
+}
+
+func (s *server) portfolioError(err error, msg string) error {
+	switch {
+	case errors.Is(err, errs.ErrInvalidPortfolio):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errs.ErrNotFound):
+		return status.Error(codes.NotFound, "portfolio not found")
+	case errors.Is(err, errs.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, "portfolio with this name already exists")
+	case errors.Is(err, errs.ErrPortfolioNotEmpty):
+		return status.Error(codes.FailedPrecondition, "portfolio still holds coins")
+	}
+
+	s.log.Error(msg, slog.Any("error", err))
+	return status.Error(codes.Internal, "failed to process request")
+}
+
+func (s *server) transactionError(err error, notFound, msg string) error {
+	switch {
+	case errors.Is(err, errs.ErrInvalidTransaction):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, errs.ErrSymbolsUnavailable):
+		return status.Error(codes.Unavailable, err.Error())
+	case errors.Is(err, errs.ErrNotFound):
+		return status.Error(codes.NotFound, notFound)
+	case errors.Is(err, errs.ErrInsufficientFunds):
+		return status.Error(codes.FailedPrecondition, "insufficient funds")
+	case errors.Is(err, errs.ErrTransactionVoided):
+		return status.Error(codes.FailedPrecondition, "transaction already voided")
+	}
+
+	s.log.Error(msg, slog.Any("error", err))
+	return status.Error(codes.Internal, "failed to process request")
+}
+
+func toProtoTransaction(tx *models.Transaction) *grpc_profile.Transaction {
+	out := &grpc_profile.Transaction{
+		Id:          uint64(tx.ID),
+		Asset:       tx.Asset,
+		Symbol:      tx.Symbol,
+		Side:        string(tx.Side),
+		Quantity:    tx.Quantity.String(),
+		Price:       tx.Price.String(),
+		Fee:         tx.Fee.String(),
+		ExecutedAt:  tx.ExecutedAt.UnixMilli(),
+		Note:        tx.Note,
+		PortfolioId: uint64(tx.PortfolioID),
+	}
+	if tx.VoidedAt != nil {
+		out.VoidedAt = tx.VoidedAt.UnixMilli()
+	}
+	if tx.ReplacedBy != nil {
+		out.ReplacedBy = uint64(*tx.ReplacedBy)
+	}
+	return out
+}
+
+func toProtoPortfolio(portfolio *models.Portfolio) *grpc_profile.Portfolio {
+	return &grpc_profile.Portfolio{
+		Id:        uint64(portfolio.ID),
+		Name:      portfolio.Name,
+		IsDefault: portfolio.IsDefault,
+		Coins:     toProtoCoins(portfolio.Coins),
+	}
+}
+
+func toProtoCoins(coins []models.Coin) []*grpc_profile.Coin {
+	out := make([]*grpc_profile.Coin, 0, len(coins))
+	for _, coin := range coins {
+		out = append(out, &grpc_profile.Coin{
+			Asset:       coin.Asset,
+			Symbol:      coin.Symbol,
+			Quantity:    coin.Quantity.String(),
+			CostBasis:   coin.CostBasis.String(),
+			RealizedPnl: coin.RealizedPnL.String(),
+			PortfolioId: uint64(coin.PortfolioID),
+		})
+	}
+	return out
+}
+
+func parseOptionalDecimal(raw string) (decimal.Decimal, error) {
+	if raw == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(raw)
+}
@@ -2,12 +2,17 @@ package http
 
 import (
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/handler/middleware"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/service"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/symbols"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/websocket"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
 	"github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/auth"
@@ -24,23 +29,33 @@ const (
 )
 
 type Handler struct {
-	usersService service.UsersService
-	coinsService service.CoinsService
-	log          *slog.Logger
-	jwtSecret    string
-	wsManager    *websocket.Manager
-	upgrader     gorilla_ws.Upgrader
-	httpClient   *http.Client
-	authClient   auth.AuthClient
+	usersService      service.UsersService
+	coinsService      service.CoinsService
+	portfoliosService service.PortfoliosService
+	alertsService     service.AlertsService
+	watchlistsService service.WatchlistsService
+	historyService    service.HistoryService
+	symbols           *symbols.Registry
+	log               *slog.Logger
+	jwtSecret         string
+	wsManager         *websocket.Manager
+	upgrader          gorilla_ws.Upgrader
+	httpClient        *http.Client
+	authClient        auth.AuthClient
 }
 
-func NewHandler(usersService service.UsersService, coinsService service.CoinsService, wsManager *websocket.Manager, log *slog.Logger, jwtSecret string, authClient auth.AuthClient) *Handler {
+func NewHandler(usersService service.UsersService, coinsService service.CoinsService, portfoliosService service.PortfoliosService, alertsService service.AlertsService, watchlistsService service.WatchlistsService, historyService service.HistoryService, symbols *symbols.Registry, wsManager *websocket.Manager, log *slog.Logger, jwtSecret string, authClient auth.AuthClient) *Handler {
 	return &Handler{
-		usersService: usersService,
-		coinsService: coinsService,
-		wsManager:    wsManager,
-		log:          log,
-		jwtSecret:    jwtSecret,
+		usersService:      usersService,
+		coinsService:      coinsService,
+		portfoliosService: portfoliosService,
+		alertsService:     alertsService,
+		watchlistsService: watchlistsService,
+		historyService:    historyService,
+		symbols:           symbols,
+		wsManager:         wsManager,
+		log:               log,
+		jwtSecret:         jwtSecret,
 		upgrader: gorilla_ws.Upgrader{
 			CheckOrigin: func(r *http.Request) bool { return true },
 		},
@@ -58,12 +73,36 @@ func (h *Handler) RegisterRoutes(router *gin.Engine) {
 			auth.POST("/login", h.login)
 		}
 
+		api.GET("/symbols", h.searchSymbols)
+		api.GET("/prices", h.getPrices)
+
 		profile := api.Group("/profile", middleware.AuthMiddleware(h.jwtSecret, h.log))
 		{
 			profile.GET("", h.getUserProfile)
 			profile.POST("", h.createUserProfile)
 			profile.POST("/coins", h.updateCoinQuantity)
 			profile.DELETE("/coins", h.deleteCoin)
+			profile.GET("/transactions", h.listTransactions)
+			profile.POST("/transactions", h.addTransaction)
+			profile.PATCH("/transactions/:id", h.editTransaction)
+			profile.DELETE("/transactions/:id", h.voidTransaction)
+			profile.PUT("/cost-method", h.setCostMethod)
+			profile.GET("/portfolios", h.listPortfolios)
+			profile.POST("/portfolios", h.createPortfolio)
+			profile.GET("/portfolios/:id", h.getPortfolio)
+			profile.PATCH("/portfolios/:id", h.renamePortfolio)
+			profile.DELETE("/portfolios/:id", h.deletePortfolio)
+			profile.GET("/alerts", h.listAlerts)
+			profile.POST("/alerts", h.createAlert)
+			profile.GET("/alerts/:id", h.getAlert)
+			profile.PATCH("/alerts/:id", h.updateAlert)
+			profile.DELETE("/alerts/:id", h.deleteAlert)
+			profile.GET("/watchlists", h.listWatchlists)
+			profile.POST("/watchlists", h.createWatchlist)
+			profile.GET("/watchlists/:id", h.getWatchlist)
+			profile.PATCH("/watchlists/:id", h.updateWatchlist)
+			profile.DELETE("/watchlists/:id", h.deleteWatchlist)
+			profile.GET("/history", h.getHistory)
 		}
 		ws := api.Group("/ws", middleware.AuthMiddleware(h.jwtSecret, h.log))
 		{
@@ -153,6 +192,16 @@ func (h *Handler) wsConnect(c *gin.Context) {
 		}
 	}
 
+	var portfolioID uint
+	if raw := c.Query("portfolio"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil || !ownsPortfolio(userProfile, uint(id)) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "portfolio not found"})
+			return
+		}
+		portfolioID = uint(id)
+	}
+
 	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		h.log.Error("failed to upgrade connection", "error", err)
@@ -160,11 +209,12 @@ func (h *Handler) wsConnect(c *gin.Context) {
 	}
 
 	client := &websocket.Client{
-		Manager: h.wsManager,
-		Conn:    conn,
-		UserID:  userID,
-		Profile: userProfile,
-		Send:    make(chan []byte, 256),
+		Manager:     h.wsManager,
+		Conn:        conn,
+		UserID:      userID,
+		PortfolioID: portfolioID,
+		Profile:     userProfile,
+		Send:        make(chan []byte, 256),
 	}
 
 	client.Manager.Register(client)
@@ -202,9 +252,16 @@ func (h *Handler) getUserProfile(c *gin.Context) {
 	c.JSON(http.StatusOK, user)
 }
 
+// coinRequest names the holding by asset; symbol, a pair of the asset, is
+// still accepted and picks the pair a new holding is valued with.
 type coinRequest struct {
-	Symbol   string `json:"symbol" binding:"required"`
-	Quantity string `json:"quantity"`
+	PortfolioID uint   `json:"portfolioId"`
+	Asset       string `json:"asset"`
+	Symbol      string `json:"symbol"`
+	Quantity    string `json:"quantity"`
+	Price       string `json:"price"`
+	Fee         string `json:"fee"`
+	Note        string `json:"note"`
 }
 
 func (h *Handler) updateCoinQuantity(c *gin.Context) {
@@ -227,14 +284,35 @@ func (h *Handler) updateCoinQuantity(c *gin.Context) {
 		return
 	}
 
-	updatedCoin, err := h.coinsService.UpdateCoinQuantity(c.Request.Context(), userID, req.Symbol, quantityChange)
+	price, err := parseOptionalDecimal(req.Price)
 	if err != nil {
-		if errors.Is(err, errs.ErrInsufficientFunds) {
-			c.JSON(http.StatusConflict, gin.H{"error": "insufficient funds"})
-			return
-		}
-		h.log.Error("failed to update coin quantity", slog.Any("error", err))
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not update coin"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid price format"})
+		return
+	}
+
+	fee, err := parseOptionalDecimal(req.Fee)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fee format"})
+		return
+	}
+
+	side := models.SideBuy
+	if quantityChange.IsNegative() {
+		side = models.SideSell
+	}
+
+	updatedCoin, err := h.coinsService.RecordTransaction(c.Request.Context(), userID, &models.Transaction{
+		PortfolioID: req.PortfolioID,
+		Asset:       req.Asset,
+		Symbol:      req.Symbol,
+		Side:        side,
+		Quantity:    quantityChange.Abs(),
+		Price:       price,
+		Fee:         fee,
+		Note:        req.Note,
+	})
+	if err != nil {
+		h.writeTransactionError(c, err, "portfolio not found", "could not update coin")
 		return
 	}
 
@@ -243,15 +321,20 @@ func (h *Handler) updateCoinQuantity(c *gin.Context) {
 
 func (h *Handler) deleteCoin(c *gin.Context) {
 	var req coinRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body, 'symbol' is required"})
+	if err := c.ShouldBindJSON(&req); err != nil || (req.Asset == "" && req.Symbol == "") {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body, 'asset' is required"})
 		return
 	}
 
 	userIDRaw, _ := c.Get(userCtx)
 	userID, _ := uuid.Parse(userIDRaw.(string))
 
-	if err := h.coinsService.DeleteCoin(c.Request.Context(), userID, req.Symbol); err != nil {
+	asset := req.Asset
+	if asset == "" {
+		asset = req.Symbol
+	}
+
+	if err := h.coinsService.DeleteCoin(c.Request.Context(), userID, req.PortfolioID, asset); err != nil {
 		if errors.Is(err, errs.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "coin not found in portfolio"})
 			return
@@ -288,6 +371,725 @@ func (h *Handler) createUserProfile(c *gin.Context) {
 	c.JSON(http.StatusCreated, gin.H{"message": "profile created successfully"})
 }
 
+type transactionRequest struct {
+	PortfolioID uint       `json:"portfolioId"`
+	Asset       string     `json:"asset"`
+	Symbol      string     `json:"symbol"`
+	Side        string     `json:"side" binding:"required"`
+	Quantity    string     `json:"quantity" binding:"required"`
+	Price       string     `json:"price"`
+	Fee         string     `json:"fee"`
+	ExecutedAt  *time.Time `json:"executedAt"`
+	Note        string     `json:"note"`
+}
+
+type transactionPatchRequest struct {
+	Side       *string    `json:"side"`
+	Quantity   *string    `json:"quantity"`
+	Price      *string    `json:"price"`
+	Fee        *string    `json:"fee"`
+	ExecutedAt *time.Time `json:"executedAt"`
+	Note       *string    `json:"note"`
+}
+
+func (h *Handler) listTransactions(c *gin.Context) {
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	var portfolioID uint
+	if raw := c.Query("portfolioId"); raw != "" {
+		id, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid portfolio id"})
+			return
+		}
+		portfolioID = uint(id)
+	}
+
+	includeVoided := c.Query("includeVoided") == "true"
+
+	asset := c.Query("asset")
+	if asset == "" {
+		asset = c.Query("symbol")
+	}
+
+	txs, err := h.coinsService.ListTransactions(c.Request.Context(), userID, portfolioID, asset, includeVoided)
+	if err != nil {
+		h.log.Error("failed to list transactions", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not list transactions"})
+		return
+	}
+
+	c.JSON(http.StatusOK, txs)
+}
+
+func (h *Handler) addTransaction(c *gin.Context) {
+	var req transactionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	quantity, err := decimal.NewFromString(req.Quantity)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid quantity format"})
+		return
+	}
+
+	price, err := parseOptionalDecimal(req.Price)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid price format"})
+		return
+	}
+
+	fee, err := parseOptionalDecimal(req.Fee)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fee format"})
+		return
+	}
+
+	tx := &models.Transaction{
+		PortfolioID: req.PortfolioID,
+		Asset:       req.Asset,
+		Symbol:      req.Symbol,
+		Side:        models.Side(req.Side),
+		Quantity:    quantity,
+		Price:       price,
+		Fee:         fee,
+		Note:        req.Note,
+	}
+	if req.ExecutedAt != nil {
+		tx.ExecutedAt = req.ExecutedAt.UTC()
+	}
+
+	if _, err := h.coinsService.RecordTransaction(c.Request.Context(), userID, tx); err != nil {
+		h.writeTransactionError(c, err, "portfolio not found", "could not record transaction")
+		return
+	}
+
+	c.JSON(http.StatusCreated, tx)
+}
+
+func (h *Handler) editTransaction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
+		return
+	}
+
+	var req transactionPatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	patch := service.TransactionPatch{
+		Note: req.Note,
+	}
+	if req.Side != nil {
+		side := models.Side(*req.Side)
+		patch.Side = &side
+	}
+
+	if req.Quantity != nil {
+		quantity, err := decimal.NewFromString(*req.Quantity)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid quantity format"})
+			return
+		}
+		patch.Quantity = &quantity
+	}
+	if req.Price != nil {
+		price, err := decimal.NewFromString(*req.Price)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid price format"})
+			return
+		}
+		patch.Price = &price
+	}
+	if req.Fee != nil {
+		fee, err := decimal.NewFromString(*req.Fee)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid fee format"})
+			return
+		}
+		patch.Fee = &fee
+	}
+	if req.ExecutedAt != nil {
+		executedAt := req.ExecutedAt.UTC()
+		patch.ExecutedAt = &executedAt
+	}
+
+	tx, err := h.coinsService.EditTransaction(c.Request.Context(), userID, uint(id), patch)
+	if err != nil {
+		h.writeTransactionError(c, err, "transaction not found", "could not edit transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, tx)
+}
+
+func (h *Handler) voidTransaction(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid transaction id"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	if err := h.coinsService.VoidTransaction(c.Request.Context(), userID, uint(id)); err != nil {
+		h.writeTransactionError(c, err, "transaction not found", "could not void transaction")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "transaction successfully voided"})
+}
+
+type costMethodRequest struct {
+	Method string `json:"method" binding:"required"`
+}
+
+func (h *Handler) setCostMethod(c *gin.Context) {
+	var req costMethodRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body, 'method' is required"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	if err := h.coinsService.SetCostMethod(c.Request.Context(), userID, models.CostMethod(req.Method)); err != nil {
+		h.writeTransactionError(c, err, "user profile not found", "could not set cost method")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "cost method updated", "method": req.Method})
+}
+
+type portfolioRequest struct {
+	Name string `json:"name" binding:"required"`
+}
+
+func (h *Handler) listPortfolios(c *gin.Context) {
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	portfolios, err := h.portfoliosService.ListPortfolios(c.Request.Context(), userID)
+	if err != nil {
+		h.log.Error("failed to list portfolios", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not list portfolios"})
+		return
+	}
+
+	c.JSON(http.StatusOK, portfolios)
+}
+
+func (h *Handler) createPortfolio(c *gin.Context) {
+	var req portfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body, 'name' is required"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	portfolio, err := h.portfoliosService.CreatePortfolio(c.Request.Context(), userID, req.Name)
+	if err != nil {
+		h.writePortfolioError(c, err, "could not create portfolio")
+		return
+	}
+
+	c.JSON(http.StatusCreated, portfolio)
+}
+
+func (h *Handler) getPortfolio(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid portfolio id"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	portfolio, err := h.portfoliosService.GetPortfolio(c.Request.Context(), userID, uint(id))
+	if err != nil {
+		h.writePortfolioError(c, err, "could not get portfolio")
+		return
+	}
+
+	c.JSON(http.StatusOK, portfolio)
+}
+
+func (h *Handler) renamePortfolio(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid portfolio id"})
+		return
+	}
+
+	var req portfolioRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body, 'name' is required"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	if err := h.portfoliosService.RenamePortfolio(c.Request.Context(), userID, uint(id), req.Name); err != nil {
+		h.writePortfolioError(c, err, "could not rename portfolio")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "portfolio successfully renamed"})
+}
+
+func (h *Handler) deletePortfolio(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid portfolio id"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	if err := h.portfoliosService.DeletePortfolio(c.Request.Context(), userID, uint(id)); err != nil {
+		h.writePortfolioError(c, err, "could not delete portfolio")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "portfolio successfully deleted"})
+}
+
+func (h *Handler) writePortfolioError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidPortfolio):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "portfolio not found"})
+	case errors.Is(err, errs.ErrAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "portfolio with this name already exists"})
+	case errors.Is(err, errs.ErrPortfolioNotEmpty):
+		c.JSON(http.StatusConflict, gin.H{"error": "portfolio still holds coins"})
+	default:
+		h.log.Error(fallback, slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+type alertRequest struct {
+	Kind        string `json:"kind" binding:"required"`
+	Symbol      string `json:"symbol"`
+	PortfolioID uint   `json:"portfolioId"`
+	Threshold   string `json:"threshold" binding:"required"`
+	Window      string `json:"window"`
+	Recurring   bool   `json:"recurring"`
+	Cooldown    string `json:"cooldown"`
+	WebhookURL  string `json:"webhookUrl"`
+}
+
+type alertPatchRequest struct {
+	Threshold  *string `json:"threshold"`
+	Window     *string `json:"window"`
+	Recurring  *bool   `json:"recurring"`
+	Cooldown   *string `json:"cooldown"`
+	WebhookURL *string `json:"webhookUrl"`
+	Active     *bool   `json:"active"`
+}
+
+func (h *Handler) listAlerts(c *gin.Context) {
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	alerts, err := h.alertsService.ListAlerts(c.Request.Context(), userID)
+	if err != nil {
+		h.log.Error("failed to list alerts", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not list alerts"})
+		return
+	}
+
+	c.JSON(http.StatusOK, alerts)
+}
+
+func (h *Handler) createAlert(c *gin.Context) {
+	var req alertRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	threshold, err := decimal.NewFromString(req.Threshold)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid threshold format"})
+		return
+	}
+
+	window, err := parseOptionalSeconds(req.Window)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid window format"})
+		return
+	}
+
+	cooldown, err := parseOptionalSeconds(req.Cooldown)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cooldown format"})
+		return
+	}
+
+	alert := &models.Alert{
+		Kind:        models.AlertKind(req.Kind),
+		Symbol:      req.Symbol,
+		PortfolioID: req.PortfolioID,
+		Threshold:   threshold,
+		WindowSec:   window,
+		Recurring:   req.Recurring,
+		CooldownSec: cooldown,
+		WebhookURL:  req.WebhookURL,
+	}
+
+	if err := h.alertsService.CreateAlert(c.Request.Context(), userID, alert); err != nil {
+		h.writeAlertError(c, err, "could not create alert")
+		return
+	}
+
+	c.JSON(http.StatusCreated, alert)
+}
+
+func (h *Handler) getAlert(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert id"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	alert, err := h.alertsService.GetAlert(c.Request.Context(), userID, uint(id))
+	if err != nil {
+		h.writeAlertError(c, err, "could not get alert")
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+func (h *Handler) updateAlert(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert id"})
+		return
+	}
+
+	var req alertPatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body", "details": err.Error()})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	patch := service.AlertPatch{
+		Recurring:  req.Recurring,
+		WebhookURL: req.WebhookURL,
+		Active:     req.Active,
+	}
+
+	if req.Threshold != nil {
+		threshold, err := decimal.NewFromString(*req.Threshold)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid threshold format"})
+			return
+		}
+		patch.Threshold = &threshold
+	}
+	if req.Window != nil {
+		window, err := parseOptionalSeconds(*req.Window)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid window format"})
+			return
+		}
+		patch.WindowSec = &window
+	}
+	if req.Cooldown != nil {
+		cooldown, err := parseOptionalSeconds(*req.Cooldown)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid cooldown format"})
+			return
+		}
+		patch.CooldownSec = &cooldown
+	}
+
+	alert, err := h.alertsService.UpdateAlert(c.Request.Context(), userID, uint(id), patch)
+	if err != nil {
+		h.writeAlertError(c, err, "could not update alert")
+		return
+	}
+
+	c.JSON(http.StatusOK, alert)
+}
+
+func (h *Handler) deleteAlert(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid alert id"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	if err := h.alertsService.DeleteAlert(c.Request.Context(), userID, uint(id)); err != nil {
+		h.writeAlertError(c, err, "could not delete alert")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "alert successfully deleted"})
+}
+
+func (h *Handler) writeAlertError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidAlert):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrSymbolsUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "alert or portfolio not found"})
+	default:
+		h.log.Error(fallback, slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+type watchlistRequest struct {
+	Name    string   `json:"name" binding:"required"`
+	Symbols []string `json:"symbols"`
+}
+
+type watchlistPatchRequest struct {
+	Name    *string   `json:"name"`
+	Symbols *[]string `json:"symbols"`
+}
+
+func (h *Handler) listWatchlists(c *gin.Context) {
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	watchlists, err := h.watchlistsService.ListWatchlists(c.Request.Context(), userID)
+	if err != nil {
+		h.log.Error("failed to list watchlists", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not list watchlists"})
+		return
+	}
+
+	c.JSON(http.StatusOK, watchlists)
+}
+
+func (h *Handler) createWatchlist(c *gin.Context) {
+	var req watchlistRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body, 'name' is required"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	watchlist, err := h.watchlistsService.CreateWatchlist(c.Request.Context(), userID, req.Name, req.Symbols)
+	if err != nil {
+		h.writeWatchlistError(c, err, "could not create watchlist")
+		return
+	}
+
+	c.JSON(http.StatusCreated, watchlist)
+}
+
+func (h *Handler) getWatchlist(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid watchlist id"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	watchlist, err := h.watchlistsService.GetWatchlist(c.Request.Context(), userID, uint(id))
+	if err != nil {
+		h.writeWatchlistError(c, err, "could not get watchlist")
+		return
+	}
+
+	c.JSON(http.StatusOK, watchlist)
+}
+
+func (h *Handler) updateWatchlist(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid watchlist id"})
+		return
+	}
+
+	var req watchlistPatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	watchlist, err := h.watchlistsService.UpdateWatchlist(c.Request.Context(), userID, uint(id), service.WatchlistPatch{
+		Name:    req.Name,
+		Symbols: req.Symbols,
+	})
+	if err != nil {
+		h.writeWatchlistError(c, err, "could not update watchlist")
+		return
+	}
+
+	c.JSON(http.StatusOK, watchlist)
+}
+
+func (h *Handler) deleteWatchlist(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid watchlist id"})
+		return
+	}
+
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	if err := h.watchlistsService.DeleteWatchlist(c.Request.Context(), userID, uint(id)); err != nil {
+		h.writeWatchlistError(c, err, "could not delete watchlist")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "watchlist successfully deleted"})
+}
+
+func (h *Handler) writeWatchlistError(c *gin.Context, err error, fallback string) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidWatchlist):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrSymbolsUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "watchlist not found"})
+	case errors.Is(err, errs.ErrAlreadyExists):
+		c.JSON(http.StatusConflict, gin.H{"error": "watchlist with this name already exists"})
+	default:
+		h.log.Error(fallback, slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func (h *Handler) getHistory(c *gin.Context) {
+	userIDRaw, _ := c.Get(userCtx)
+	userID, _ := uuid.Parse(userIDRaw.(string))
+
+	rangeName := c.DefaultQuery("range", "24h")
+
+	points, err := h.historyService.GetHistory(c.Request.Context(), userID, rangeName)
+	if err != nil {
+		if errors.Is(err, errs.ErrInvalidRange) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		h.log.Error("failed to get portfolio history", slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "could not get portfolio history"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"range": rangeName, "points": points})
+}
+
+// searchSymbols lists the symbols of the registry matching the search
+// query, best matches first.
+func (h *Handler) searchSymbols(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 100"})
+		return
+	}
+
+	found, err := h.symbols.Search(c.Request.Context(), c.Query("search"), limit)
+	if err != nil {
+		h.log.Error("failed to search symbols", slog.Any("error", err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "symbol registry is unavailable"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"symbols": found})
+}
+
+// parseOptionalSeconds reads a Go duration such as "1h" or "90s" and returns
+// it in whole seconds.
+func parseOptionalSeconds(raw string) (uint, error) {
+	if raw == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration %q", raw)
+	}
+	return uint(d / time.Second), nil
+}
+
+func ownsPortfolio(user *models.User, id uint) bool {
+	for _, portfolio := range user.Portfolios {
+		if portfolio.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) writeTransactionError(c *gin.Context, err error, notFound, fallback string) {
+	switch {
+	case errors.Is(err, errs.ErrInvalidTransaction):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrSymbolsUnavailable):
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": err.Error()})
+	case errors.Is(err, errs.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": notFound})
+	case errors.Is(err, errs.ErrInsufficientFunds):
+		c.JSON(http.StatusConflict, gin.H{"error": "insufficient funds"})
+	case errors.Is(err, errs.ErrTransactionVoided):
+		c.JSON(http.StatusConflict, gin.H{"error": "transaction already voided"})
+	default:
+		h.log.Error(fallback, slog.Any("error", err))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fallback})
+	}
+}
+
+func parseOptionalDecimal(raw string) (decimal.Decimal, error) {
+	if raw == "" {
+		return decimal.Zero, nil
+	}
+	return decimal.NewFromString(raw)
+}
+
 func grpcCodeToHTTP(code codes.Code) int {
 	switch code {
 	case codes.OK:
@@ -308,3 +1110,46 @@ func grpcCodeToHTTP(code codes.Code) int {
 		return http.StatusInternalServerError
 	}
 }
+
+// maxPriceSymbols caps the symbols of one GET /prices request.
+const maxPriceSymbols = 100
+
+// getPrices returns the last known price of every symbol of the
+// comma-separated ?symbols= list. Symbols without a cached price are left
+// out.
+func (h *Handler) getPrices(c *gin.Context) {
+	var symbols []string
+	seen := make(map[string]struct{})
+	for _, symbol := range strings.Split(c.Query("symbols"), ",") {
+		symbol = models.NormalizeSymbol(strings.TrimSpace(symbol))
+		if symbol == "" {
+			continue
+		}
+		if _, ok := seen[symbol]; ok {
+			continue
+		}
+		seen[symbol] = struct{}{}
+		symbols = append(symbols, symbol)
+	}
+	if len(symbols) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "symbols are required"})
+		return
+	}
+	if len(symbols) > maxPriceSymbols {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("at most %d symbols can be requested", maxPriceSymbols)})
+		return
+	}
+
+	prices, err := h.wsManager.LastPrices(c.Request.Context(), symbols)
+	if err != nil {
+		h.log.Error("failed to read cached prices", slog.Any("error", err))
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "price cache is unavailable"})
+		return
+	}
+
+	views := make([]models.LastPriceView, len(prices))
+	for i, price := range prices {
+		views[i] = price.View()
+	}
+	c.JSON(http.StatusOK, gin.H{"prices": views})
+}
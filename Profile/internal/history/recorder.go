@@ -0,0 +1,235 @@
+package history
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// FollowerID identifies the recorder among the subscribers of a symbol, so
+// every held coin is priced whether or not its owner is connected.
+var FollowerID = uuid.NewSHA1(uuid.NameSpaceURL, []byte("profile-service/history"))
+
+// Feed opens and closes the price streams the recorder listens to.
+type Feed interface {
+	Follow(followerID uuid.UUID, symbol string)
+	Unfollow(followerID uuid.UUID, symbol string)
+}
+
+// Rates converts the prices of symbols quoted in other assets into USDT.
+type Rates interface {
+	// USDTRate returns the USDT price of one unit of the quote of symbol.
+	USDTRate(symbol string) (decimal.Decimal, bool)
+}
+
+type Config struct {
+	SnapshotInterval time.Duration
+	MinuteRetention  time.Duration
+	HourRetention    time.Duration
+}
+
+// Recorder snapshots the value of every user's holdings on a schedule and
+// downsamples old snapshots: minute snapshots older than MinuteRetention
+// become hourly ones, hourly ones older than HourRetention become daily.
+type Recorder struct {
+	log           *slog.Logger
+	coinsRepo     repository.CoinsRepository
+	snapshotsRepo repository.SnapshotsRepository
+	feed          Feed
+	rates         Rates
+	cfg           Config
+
+	mu       sync.Mutex
+	prices   map[string]decimal.Decimal
+	followed map[string]struct{}
+}
+
+func NewRecorder(
+	log *slog.Logger,
+	coinsRepo repository.CoinsRepository,
+	snapshotsRepo repository.SnapshotsRepository,
+	feed Feed,
+	rates Rates,
+	cfg Config,
+) *Recorder {
+	return &Recorder{
+		log:           log,
+		coinsRepo:     coinsRepo,
+		snapshotsRepo: snapshotsRepo,
+		feed:          feed,
+		rates:         rates,
+		cfg:           cfg,
+		prices:        make(map[string]decimal.Decimal),
+		followed:      make(map[string]struct{}),
+	}
+}
+
+func (r *Recorder) Run(ctx context.Context) {
+	// The first run only opens the price streams; users are skipped until
+	// their prices arrive.
+	if err := r.Snapshot(r.slot(time.Now())); err != nil {
+		r.log.Error("history: failed to take snapshots", "error", err)
+	}
+
+	snapshotTicker := time.NewTicker(r.cfg.SnapshotInterval)
+	defer snapshotTicker.Stop()
+
+	compactTicker := time.NewTicker(time.Hour)
+	defer compactTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			r.log.Info("history recorder stopping...")
+			return
+		case now := <-snapshotTicker.C:
+			if err := r.Snapshot(r.slot(now)); err != nil {
+				r.log.Error("history: failed to take snapshots", "error", err)
+			}
+		case now := <-compactTicker.C:
+			if err := r.Compact(now.UTC()); err != nil {
+				r.log.Error("history: failed to compact snapshots", "error", err)
+			}
+		}
+	}
+}
+
+// ObservePrice implements websocket.PriceObserver.
+func (r *Recorder) ObservePrice(symbol string, price decimal.Decimal, _ time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.prices[symbol] = price
+}
+
+// Snapshot stores the value of every user holding coins. A user is skipped
+// while one of their symbols has no price yet, so a missing quote never
+// shows up as a drop on the curve.
+func (r *Recorder) Snapshot(now time.Time) error {
+	coins, err := r.coinsRepo.GetAllCoins()
+	if err != nil {
+		return err
+	}
+
+	symbols := make(map[string]struct{})
+	for _, coin := range coins {
+		symbols[coin.Symbol] = struct{}{}
+	}
+	r.follow(symbols)
+
+	r.mu.Lock()
+	var snapshots []models.PortfolioSnapshot
+	for start := 0; start < len(coins); {
+		end := start
+		for end < len(coins) && coins[end].UserID == coins[start].UserID {
+			end++
+		}
+
+		if snapshot, ok := r.value(coins[start:end], now); ok {
+			snapshots = append(snapshots, snapshot)
+		}
+		start = end
+	}
+	r.mu.Unlock()
+
+	return r.snapshotsRepo.AddSnapshots(snapshots)
+}
+
+// slot is the scheduled time of the snapshot taken at now. Every replica
+// stores its snapshot at the same slot, so only one of them is kept.
+func (r *Recorder) slot(now time.Time) time.Time {
+	return now.UTC().Truncate(r.cfg.SnapshotInterval)
+}
+
+// Compact downsamples snapshots that have outlived their resolution.
+func (r *Recorder) Compact(now time.Time) error {
+	minutes, err := r.snapshotsRepo.Compact(models.ResolutionMinute, models.ResolutionHour, time.Hour, now.Add(-r.cfg.MinuteRetention))
+	if err != nil {
+		return err
+	}
+
+	hours, err := r.snapshotsRepo.Compact(models.ResolutionHour, models.ResolutionDay, 24*time.Hour, now.Add(-r.cfg.HourRetention))
+	if err != nil {
+		return err
+	}
+
+	if minutes+hours > 0 {
+		r.log.Info("history: snapshots compacted", "minute", minutes, "hour", hours)
+	}
+	return nil
+}
+
+// value merges the coins of one user, held in any portfolio, into a snapshot.
+// The caller must hold r.mu.
+func (r *Recorder) value(coins []models.Coin, now time.Time) (models.PortfolioSnapshot, bool) {
+	snapshot := models.PortfolioSnapshot{
+		UserID:     coins[0].UserID,
+		Resolution: models.ResolutionMinute,
+		TakenAt:    now,
+		TotalValue: decimal.Zero,
+	}
+
+	index := make(map[string]int)
+	for _, coin := range coins {
+		price, ok := r.prices[coin.Symbol]
+		if !ok {
+			return models.PortfolioSnapshot{}, false
+		}
+		// Pairs are quoted in different assets; values are summed in USDT.
+		rate, ok := r.rates.USDTRate(coin.Symbol)
+		if !ok {
+			return models.PortfolioSnapshot{}, false
+		}
+
+		i, ok := index[coin.Symbol]
+		if !ok {
+			i = len(snapshot.Coins)
+			index[coin.Symbol] = i
+			snapshot.Coins = append(snapshot.Coins, models.SnapshotCoin{
+				Asset:    coin.Asset,
+				Symbol:   coin.Symbol,
+				Quantity: decimal.Zero,
+				Price:    price,
+				Value:    decimal.Zero,
+			})
+		}
+
+		value := coin.Quantity.Mul(price).Mul(rate)
+		snapshot.Coins[i].Quantity = snapshot.Coins[i].Quantity.Add(coin.Quantity)
+		snapshot.Coins[i].Value = snapshot.Coins[i].Value.Add(value)
+		snapshot.TotalValue = snapshot.TotalValue.Add(value)
+	}
+
+	return snapshot, true
+}
+
+func (r *Recorder) follow(symbols map[string]struct{}) {
+	r.mu.Lock()
+	var follow, unfollow []string
+	for symbol := range symbols {
+		if _, ok := r.followed[symbol]; !ok {
+			follow = append(follow, symbol)
+		}
+	}
+	for symbol := range r.followed {
+		if _, ok := symbols[symbol]; !ok {
+			unfollow = append(unfollow, symbol)
+			delete(r.prices, symbol)
+		}
+	}
+	r.followed = symbols
+	r.mu.Unlock()
+
+	for _, symbol := range follow {
+		r.feed.Follow(FollowerID, symbol)
+	}
+	for _, symbol := range unfollow {
+		r.feed.Unfollow(FollowerID, symbol)
+	}
+}
@@ -0,0 +1,181 @@
+package history_test
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/history"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/service"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type nopFeed struct{}
+
+func (nopFeed) Follow(uuid.UUID, string)   {}
+func (nopFeed) Unfollow(uuid.UUID, string) {}
+
+// quoteRates prices the quote of USDT pairs at 1 and of any other symbol at
+// the listed rate.
+type quoteRates map[string]decimal.Decimal
+
+func (r quoteRates) USDTRate(symbol string) (decimal.Decimal, bool) {
+	if strings.HasSuffix(symbol, "usdt") {
+		return decimal.NewFromInt(1), true
+	}
+	rate, ok := r[symbol]
+	return rate, ok
+}
+
+func setupRecorder(t *testing.T, rates quoteRates, coins ...models.Coin) (*history.Recorder, repository.SnapshotsRepository, uuid.UUID) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(&models.User{}, &models.Portfolio{}, &models.Coin{}, &models.PortfolioSnapshot{}, &models.SnapshotCoin{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	user := &models.User{ID: uuid.New(), Name: "history_user"}
+	if err := repository.NewUsersRepository(db).CreateUserProfile(user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	coinsRepo := repository.NewCoinsRepository(db)
+	for _, coin := range coins {
+		coin.UserID = user.ID
+		if err := coinsRepo.AddCoin(&coin); err != nil {
+			t.Fatalf("failed to add coin: %v", err)
+		}
+	}
+
+	snapshotsRepo := repository.NewSnapshotsRepository(db)
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	recorder := history.NewRecorder(log, coinsRepo, snapshotsRepo, nopFeed{}, rates, history.Config{
+		SnapshotInterval: time.Minute,
+		MinuteRetention:  24 * time.Hour,
+		HourRetention:    30 * 24 * time.Hour,
+	})
+
+	return recorder, snapshotsRepo, user.ID
+}
+
+func TestRecorder(t *testing.T) {
+	recorder, snapshotsRepo, userID := setupRecorder(t, nil,
+		models.Coin{PortfolioID: 1, Symbol: "btcusdt", Quantity: decimal.NewFromInt(1)},
+		models.Coin{PortfolioID: 2, Symbol: "btcusdt", Quantity: decimal.NewFromInt(2)},
+		models.Coin{PortfolioID: 2, Symbol: "ethusdt", Quantity: decimal.NewFromInt(10)},
+	)
+	now := time.Now().UTC()
+	past := now.Add(-48 * time.Hour).Truncate(time.Hour)
+
+	t.Run("user_without_prices_is_skipped", func(t *testing.T) {
+		recorder.ObservePrice("btcusdt", decimal.NewFromInt(100), now)
+		if err := recorder.Snapshot(past); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		snapshots, _ := snapshotsRepo.ListSnapshots(userID, time.Time{})
+		if len(snapshots) != 0 {
+			t.Errorf("Expected no snapshots without an ethusdt price, got %d", len(snapshots))
+		}
+	})
+
+	t.Run("snapshot_merges_portfolios", func(t *testing.T) {
+		recorder.ObservePrice("ethusdt", decimal.NewFromInt(10), now)
+		for i := 0; i < 3; i++ {
+			if err := recorder.Snapshot(past.Add(time.Duration(i) * time.Minute)); err != nil {
+				t.Fatalf("Snapshot failed: %v", err)
+			}
+		}
+		if err := recorder.Snapshot(now); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		snapshots, _ := snapshotsRepo.ListSnapshots(userID, now)
+		if len(snapshots) != 1 {
+			t.Fatalf("Expected 1 snapshot, got %d", len(snapshots))
+		}
+		if !snapshots[0].TotalValue.Equal(decimal.NewFromInt(400)) || len(snapshots[0].Coins) != 2 {
+			t.Errorf("Expected total 400 over 2 coins, got %s over %d", snapshots[0].TotalValue, len(snapshots[0].Coins))
+		}
+	})
+
+	t.Run("same_slot_is_stored_once", func(t *testing.T) {
+		if err := recorder.Snapshot(now); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		snapshots, _ := snapshotsRepo.ListSnapshots(userID, now)
+		if len(snapshots) != 1 || len(snapshots[0].Coins) != 2 {
+			t.Errorf("Expected the repeated snapshot to be skipped, got %d snapshots", len(snapshots))
+		}
+	})
+
+	t.Run("compact_keeps_last_of_hour", func(t *testing.T) {
+		if err := recorder.Compact(now); err != nil {
+			t.Fatalf("Compact failed: %v", err)
+		}
+
+		points, err := service.NewHistoryService(snapshotsRepo).GetHistory(context.Background(), userID, "all")
+		if err != nil {
+			t.Fatalf("GetHistory failed: %v", err)
+		}
+		if len(points) != 2 {
+			t.Errorf("Expected 2 points after compaction, got %d", len(points))
+		}
+	})
+}
+
+func TestRecorderMixedQuotes(t *testing.T) {
+	rates := quoteRates{}
+	recorder, snapshotsRepo, userID := setupRecorder(t, rates,
+		models.Coin{PortfolioID: 1, Symbol: "btcusdt", Quantity: decimal.NewFromInt(1)},
+		models.Coin{PortfolioID: 1, Symbol: "ethbtc", Quantity: decimal.NewFromInt(10)},
+	)
+	now := time.Now().UTC()
+
+	recorder.ObservePrice("btcusdt", decimal.NewFromInt(60000), now)
+	recorder.ObservePrice("ethbtc", decimal.NewFromFloat(0.05), now)
+
+	t.Run("missing_rate_skips_user", func(t *testing.T) {
+		if err := recorder.Snapshot(now.Add(-time.Minute)); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		snapshots, _ := snapshotsRepo.ListSnapshots(userID, time.Time{})
+		if len(snapshots) != 0 {
+			t.Errorf("Expected no snapshots without a btc rate, got %d", len(snapshots))
+		}
+	})
+
+	t.Run("value_is_summed_in_usdt", func(t *testing.T) {
+		rates["ethbtc"] = decimal.NewFromInt(60000)
+		if err := recorder.Snapshot(now); err != nil {
+			t.Fatalf("Snapshot failed: %v", err)
+		}
+
+		snapshots, _ := snapshotsRepo.ListSnapshots(userID, time.Time{})
+		if len(snapshots) != 1 {
+			t.Fatalf("Expected 1 snapshot, got %d", len(snapshots))
+		}
+		// 1 × 60000 + 10 × 0.05 × 60000 = 90000 USDT, not 60000.5.
+		if !snapshots[0].TotalValue.Equal(decimal.NewFromInt(90000)) {
+			t.Errorf("Expected total 90000, got %s", snapshots[0].TotalValue)
+		}
+	})
+}
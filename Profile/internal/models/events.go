@@ -1,22 +1,219 @@
 package models
 
-import "github.com/shopspring/decimal"
+import (
+	"time"
 
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+// PriceUpdate is published by the Aggregator once per second. Prices are in
+// the Quote asset of the symbol and Time is the Unix time in milliseconds
+// of the trade Price comes from. Open, High and Low are the rolling 24h
+// prices, 0 while the Aggregator has not seen them yet. Bid and Ask are the
+// top of the order book, 0 while it is not in sync.
 type PriceUpdate struct {
 	Symbol string  `json:"s"`
+	Quote  string  `json:"q"`
 	Price  float64 `json:"p"`
+	Time   int64   `json:"t"`
+	Open   float64 `json:"o"`
+	High   float64 `json:"h"`
+	Low    float64 `json:"l"`
+	Bid    float64 `json:"b"`
+	Ask    float64 `json:"a"`
+}
+
+// LastPriceView is the last price of a symbol as the Aggregator cached it,
+// in the quote of the symbol. UpdatedAt is the time of the trade the price
+// comes from. The 24h and book fields are null while they are unknown.
+type LastPriceView struct {
+	Symbol    string           `json:"symbol"`
+	Quote     string           `json:"quote"`
+	Price     decimal.Decimal  `json:"price"`
+	UpdatedAt *time.Time       `json:"updatedAt"`
+	Open24h   *decimal.Decimal `json:"open24h"`
+	High24h   *decimal.Decimal `json:"high24h"`
+	Low24h    *decimal.Decimal `json:"low24h"`
+	Bid       *decimal.Decimal `json:"bid"`
+	Ask       *decimal.Decimal `json:"ask"`
+}
+
+func (p PriceUpdate) View() LastPriceView {
+	view := LastPriceView{
+		Symbol:  p.Symbol,
+		Quote:   p.Quote,
+		Price:   decimal.NewFromFloat(p.Price),
+		Open24h: positiveDecimal(p.Open),
+		High24h: positiveDecimal(p.High),
+		Low24h:  positiveDecimal(p.Low),
+		Bid:     positiveDecimal(p.Bid),
+		Ask:     positiveDecimal(p.Ask),
+	}
+	if p.Time > 0 {
+		at := time.UnixMilli(p.Time).UTC()
+		view.UpdatedAt = &at
+	}
+	return view
+}
+
+func positiveDecimal(f float64) *decimal.Decimal {
+	if f <= 0 {
+		return nil
+	}
+	d := decimal.NewFromFloat(f)
+	return &d
+}
+
+// Prices come over Redis Pub/Sub on a channel per symbol, or with
+// PriceTransportStreams as entries of one Redis stream, each holding the
+// symbol and the PriceUpdate as JSON in its "symbol" and "data" fields.
+const (
+	PriceTransportPubSub  = "pubsub"
+	PriceTransportStreams = "streams"
+)
+
+// PriceKeyPrefix plus a symbol names the Redis key the Aggregator caches
+// the last PriceUpdate of that symbol under.
+const PriceKeyPrefix = "price:"
+
+// Every replica keeps the symbols its clients follow in a Redis set under
+// SubscriptionsKeyPrefix plus its instance id, and publishes that key on
+// SubscriptionsChannel whenever the set changes.
+const (
+	SubscriptionsKeyPrefix = "subscriptions:"
+	SubscriptionsChannel   = "subscriptions"
+)
+
+// RatesChannel is the Redis channel the Aggregator publishes its rate table
+// on.
+const RatesChannel = "rates"
+
+// Rates holds the price of one unit of every asset in Base, USDT, as the
+// Aggregator derives it from the ticker feed.
+type Rates struct {
+	Base  string             `json:"base"`
+	Time  int64              `json:"t"`
+	Rates map[string]float64 `json:"r"`
 }
 
+// BookTop is the best bid and ask of a symbol, in its quote asset.
+type BookTop struct {
+	Bid decimal.Decimal
+	Ask decimal.Decimal
+}
+
+// CoinView is one holding of the streamed portfolio. Symbol is the pair the
+// asset is valued with.
 type CoinView struct {
+	Asset                string          `json:"asset"`
+	Symbol               string          `json:"symbol"`
+	Quantity             decimal.Decimal `json:"quantity"`
+	Price                decimal.Decimal `json:"price"`
+	Total                decimal.Decimal `json:"total"`
+	AvgCost              decimal.Decimal `json:"avgCost"`
+	CostBasis            decimal.Decimal `json:"costBasis"`
+	UnrealizedPnL        decimal.Decimal `json:"unrealizedPnl"`
+	UnrealizedPnLPercent decimal.Decimal `json:"unrealizedPnlPercent"`
+	RealizedPnL          decimal.Decimal `json:"realizedPnl"`
+}
+
+type PortfolioView struct {
+	Type               string          `json:"type"`
+	UserID             string          `json:"userID"`
+	UserName           string          `json:"userName"`
+	PortfolioID        uint            `json:"portfolioId,omitempty"`
+	PortfolioName      string          `json:"portfolioName,omitempty"`
+	CostMethod         CostMethod      `json:"costMethod"`
+	Quote              string          `json:"quote"`
+	TotalValue         decimal.Decimal `json:"totalValue"`
+	TotalCost          decimal.Decimal `json:"totalCost"`
+	TotalUnrealizedPnL decimal.Decimal `json:"totalUnrealizedPnl"`
+	TotalRealizedPnL   decimal.Decimal `json:"totalRealizedPnl"`
+	Coins              []CoinView      `json:"coins"`
+}
+
+// ProfileEventsChannel is the Redis channel profile changes are fanned out
+// on, so that every replica can refresh the websockets it holds.
+const ProfileEventsChannel = "profile-events"
+
+type ProfileEventKind string
+
+const (
+	ProfileEventCoins      ProfileEventKind = "coins"
+	ProfileEventCostMethod ProfileEventKind = "cost_method"
+	ProfileEventPortfolios ProfileEventKind = "portfolios"
+	ProfileEventWatchlists ProfileEventKind = "watchlists"
+)
+
+// ProfileEvent tells that the profile of UserID changed after a committed
+// write.
+type ProfileEvent struct {
+	Kind   ProfileEventKind `json:"kind"`
+	UserID uuid.UUID        `json:"userId"`
+	At     time.Time        `json:"at"`
+}
+
+// Types of the frames pushed over the websocket.
+const (
+	FramePortfolio = "portfolio"
+	FramePrice     = "price"
+	FrameAlert     = "alert"
+	FrameReply     = "reply"
+)
+
+// PriceView is pushed for symbols the client watches, either over the
+// connection or through a watchlist. The 24h change is null until the
+// Aggregator knows the open price, the book fields while its order book
+// is not in sync.
+type PriceView struct {
+	Type             string           `json:"type"`
+	Symbol           string           `json:"symbol"`
+	Price            decimal.Decimal  `json:"price"`
+	Quote            string           `json:"quote"`
+	Change24h        *decimal.Decimal `json:"change24h"`
+	ChangePercent24h *decimal.Decimal `json:"changePercent24h"`
+	Bid              *decimal.Decimal `json:"bid"`
+	Ask              *decimal.Decimal `json:"ask"`
+	Mid              *decimal.Decimal `json:"mid"`
+	Spread           *decimal.Decimal `json:"spread"`
+}
+
+// AlertEvent is pushed to the websocket of the owner and to the webhook of
+// the alert when a rule fires.
+type AlertEvent struct {
+	Type      string          `json:"type"`
+	AlertID   uint            `json:"alertId"`
+	Kind      AlertKind       `json:"kind"`
+	Symbol    string          `json:"symbol,omitempty"`
+	Threshold decimal.Decimal `json:"threshold"`
+	Value     decimal.Decimal `json:"value"`
+	FiredAt   time.Time       `json:"firedAt"`
+}
+
+// AlertsChannel is the Redis channel firings are fanned out on, so that the
+// replica holding the websocket of the user pushes the alert frame.
+const AlertsChannel = "alert-events"
+
+// AlertDelivery carries a firing to every replica.
+type AlertDelivery struct {
+	UserID uuid.UUID  `json:"userId"`
+	Event  AlertEvent `json:"event"`
+}
+
+// HistoryCoin is a holding within a history point. Asset is empty for points
+// recorded while holdings were kept by pair.
+type HistoryCoin struct {
+	Asset    string          `json:"asset,omitempty"`
 	Symbol   string          `json:"symbol"`
 	Quantity decimal.Decimal `json:"quantity"`
 	Price    decimal.Decimal `json:"price"`
-	Total    decimal.Decimal `json:"total"`
+	Value    decimal.Decimal `json:"value"`
 }
 
-type PortfolioView struct {
-	UserID     string     `json:"userID"`
-	UserName   string     `json:"userName"`
+// HistoryPoint is one point of the equity curve returned by the history API.
+type HistoryPoint struct {
+	Time       time.Time       `json:"time"`
 	TotalValue decimal.Decimal `json:"totalValue"`
-	Coins      []CoinView `json:"coins"`
-}
\ No newline at end of file
+	Coins      []HistoryCoin   `json:"coins"`
+}
@@ -1,21 +1,211 @@
 package models
 
 import (
+	"time"
+
 	"github.com/google/uuid"
 	"github.com/shopspring/decimal"
 	"gorm.io/gorm"
 )
 
+type Side string
+
+const (
+	SideBuy  Side = "buy"
+	SideSell Side = "sell"
+)
+
+func (s Side) Valid() bool {
+	return s == SideBuy || s == SideSell
+}
+
+// CostMethod selects how sells are matched against earlier buys when the
+// cost basis and realized P&L of a coin are computed.
+type CostMethod string
+
+const (
+	CostMethodAverage CostMethod = "average"
+	CostMethodFIFO    CostMethod = "fifo"
+	CostMethodLIFO    CostMethod = "lifo"
+)
+
+func (m CostMethod) Valid() bool {
+	return m == CostMethodAverage || m == CostMethodFIFO || m == CostMethodLIFO
+}
+
 type User struct {
-	ID    uuid.UUID `gorm:"type:uuid;primaryKey;"`
-	Name  string    `gorm:"unique;not null"`
-	Coins []Coin    `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	ID         uuid.UUID   `gorm:"type:uuid;primaryKey;"`
+	Name       string      `gorm:"unique;not null"`
+	CostMethod CostMethod  `gorm:"type:varchar(8);not null;default:average"`
+	Portfolios []Portfolio `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Coins      []Coin      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+	Watchlists []Watchlist `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;"`
+}
+
+const DefaultPortfolioName = "main"
+
+// Portfolio groups the coins of a user. Every user has exactly one default
+// portfolio that receives coin updates which do not name a portfolio.
+type Portfolio struct {
+	gorm.Model
+
+	UserID    uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_portfolios_user_name"`
+	Name      string    `gorm:"not null;uniqueIndex:idx_portfolios_user_name"`
+	IsDefault bool      `gorm:"not null;default:false"`
+	Coins     []Coin    `gorm:"foreignKey:PortfolioID;constraint:OnDelete:CASCADE;"`
 }
 
+// Coin is the holding of one asset within a portfolio. Symbol is the pair the
+// asset is valued with; prices, cost basis and realized P&L are in its quote.
 type Coin struct {
 	gorm.Model
 
-	Symbol   string          `gorm:"not null"`
-	Quantity decimal.Decimal `gorm:"type:decimal(20,8);not null"`
-	UserID   uuid.UUID       `gorm:"not null"`
+	PortfolioID uint            `gorm:"index"`
+	Asset       string          `gorm:"not null;default:''"`
+	Symbol      string          `gorm:"not null"`
+	Quantity    decimal.Decimal `gorm:"type:decimal(20,8);not null"`
+	CostBasis   decimal.Decimal `gorm:"type:decimal(28,8);not null;default:0"`
+	RealizedPnL decimal.Decimal `gorm:"type:decimal(28,8);not null;default:0"`
+	UserID      uuid.UUID       `gorm:"not null"`
+}
+
+// MaxWatchlistSymbols bounds the size of one watchlist.
+const MaxWatchlistSymbols = 100
+
+// Watchlist is a named set of symbols a user follows without holding them.
+// Their prices are streamed over the websocket next to the portfolio.
+type Watchlist struct {
+	gorm.Model
+
+	UserID uuid.UUID       `gorm:"type:uuid;not null;uniqueIndex:idx_watchlists_user_name"`
+	Name   string          `gorm:"not null;uniqueIndex:idx_watchlists_user_name"`
+	Items  []WatchlistItem `gorm:"foreignKey:WatchlistID;constraint:OnDelete:CASCADE;"`
+}
+
+type WatchlistItem struct {
+	ID          uint   `gorm:"primaryKey"`
+	WatchlistID uint   `gorm:"not null;uniqueIndex:idx_watchlist_items_symbol"`
+	Symbol      string `gorm:"not null;uniqueIndex:idx_watchlist_items_symbol"`
+}
+
+// Transaction is a single immutable ledger entry. Coin balances are always
+// rebuilt from the non-voided transactions of a portfolio and asset; an
+// edit voids the original row and points it at its replacement. Price and
+// fee are in the quote of Symbol, the pair the asset is valued with.
+type Transaction struct {
+	gorm.Model
+
+	UserID      uuid.UUID       `gorm:"type:uuid;not null;index:idx_transactions_user_symbol;index:idx_transactions_user_asset"`
+	PortfolioID uint            `gorm:"index"`
+	Asset       string          `gorm:"not null;default:'';index:idx_transactions_user_asset"`
+	Symbol      string          `gorm:"not null;index:idx_transactions_user_symbol"`
+	Side        Side            `gorm:"type:varchar(4);not null"`
+	Quantity    decimal.Decimal `gorm:"type:decimal(20,8);not null"`
+	Price       decimal.Decimal `gorm:"type:decimal(20,8);not null"`
+	Fee         decimal.Decimal `gorm:"type:decimal(20,8);not null"`
+	ExecutedAt  time.Time       `gorm:"not null"`
+	Note        string
+	VoidedAt    *time.Time
+	ReplacedBy  *uint
+	User        User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type AlertKind string
+
+const (
+	AlertPriceAbove     AlertKind = "price_above"
+	AlertPriceBelow     AlertKind = "price_below"
+	AlertChangeUp       AlertKind = "change_up"
+	AlertChangeDown     AlertKind = "change_down"
+	AlertPortfolioAbove AlertKind = "portfolio_above"
+	AlertPortfolioBelow AlertKind = "portfolio_below"
+)
+
+func (k AlertKind) Valid() bool {
+	switch k {
+	case AlertPriceAbove, AlertPriceBelow, AlertChangeUp, AlertChangeDown, AlertPortfolioAbove, AlertPortfolioBelow:
+		return true
+	}
+	return false
+}
+
+// IsPortfolio reports whether the rule watches the value of a portfolio
+// rather than the price of a single symbol.
+func (k AlertKind) IsPortfolio() bool {
+	return k == AlertPortfolioAbove || k == AlertPortfolioBelow
+}
+
+// MaxAlertWindow bounds change rules to the price history the alerts engine
+// keeps in memory.
+const MaxAlertWindow = 24 * time.Hour
+
+// Alert is a user rule evaluated against live prices. Price rules compare the
+// last price of Symbol with Threshold, change rules compare the percentage
+// move over the last WindowSec seconds, and portfolio rules compare the value of PortfolioID (or
+// of all portfolios when it is zero). A one-shot alert is deactivated after
+// it fires; a recurring one fires again once CooldownSec seconds have passed.
+// A firing stays pending until the owner acknowledges it over the websocket,
+// so it is replayed when they reconnect.
+type Alert struct {
+	gorm.Model
+
+	UserID      uuid.UUID       `gorm:"type:uuid;not null;index"`
+	Kind        AlertKind       `gorm:"type:varchar(16);not null"`
+	Symbol      string          `gorm:"not null;default:''"`
+	PortfolioID uint            `gorm:"not null;default:0"`
+	Threshold   decimal.Decimal `gorm:"type:decimal(28,8);not null"`
+	WindowSec   uint            `gorm:"not null;default:0"`
+	Recurring   bool            `gorm:"not null;default:false"`
+	CooldownSec uint            `gorm:"not null;default:0"`
+	WebhookURL  string          `gorm:"not null;default:''"`
+	Active      bool            `gorm:"not null;default:true"`
+	LastFiredAt *time.Time
+	LastValue   decimal.Decimal `gorm:"type:decimal(28,8);not null;default:0"`
+	AckedAt     *time.Time
+	User        User `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+func (a *Alert) Window() time.Duration {
+	return time.Duration(a.WindowSec) * time.Second
+}
+
+func (a *Alert) Cooldown() time.Duration {
+	return time.Duration(a.CooldownSec) * time.Second
+}
+
+// Pending reports whether the last firing has not been acknowledged yet.
+func (a *Alert) Pending() bool {
+	return a.LastFiredAt != nil && (a.AckedAt == nil || a.AckedAt.Before(*a.LastFiredAt))
+}
+
+// Resolution tells how much history a portfolio snapshot stands for. Minute
+// snapshots are compacted into hourly ones and those into daily ones as
+// they age.
+type Resolution string
+
+const (
+	ResolutionMinute Resolution = "minute"
+	ResolutionHour   Resolution = "hour"
+	ResolutionDay    Resolution = "day"
+)
+
+// PortfolioSnapshot is the value of all portfolios of a user at TakenAt.
+type PortfolioSnapshot struct {
+	ID         uint            `gorm:"primaryKey"`
+	UserID     uuid.UUID       `gorm:"type:uuid;not null;index:idx_snapshots_user_time;uniqueIndex:idx_snapshots_taken"`
+	Resolution Resolution      `gorm:"type:varchar(8);not null;index;uniqueIndex:idx_snapshots_taken"`
+	TakenAt    time.Time       `gorm:"not null;index:idx_snapshots_user_time;uniqueIndex:idx_snapshots_taken"`
+	TotalValue decimal.Decimal `gorm:"type:decimal(28,8);not null"`
+	Coins      []SnapshotCoin  `gorm:"foreignKey:SnapshotID;constraint:OnDelete:CASCADE;"`
+	User       User            `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE;" json:"-"`
+}
+
+type SnapshotCoin struct {
+	ID         uint            `gorm:"primaryKey"`
+	SnapshotID uint            `gorm:"not null;index"`
+	Asset      string          `gorm:"not null;default:''"`
+	Symbol     string          `gorm:"not null"`
+	Quantity   decimal.Decimal `gorm:"type:decimal(20,8);not null"`
+	Price      decimal.Decimal `gorm:"type:decimal(20,8);not null"`
+	Value      decimal.Decimal `gorm:"type:decimal(28,8);not null"`
 }
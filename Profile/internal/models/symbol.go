@@ -0,0 +1,43 @@
+package models
+
+import "strings"
+
+// SymbolStatusTrading is the status of a symbol open for trading.
+const SymbolStatusTrading = "TRADING"
+
+// Symbol is the exchange metadata of a symbol listed in the registry of the
+// Socket service. Filters are decimal strings, empty when Binance sets none.
+type Symbol struct {
+	Symbol      string `json:"symbol"`
+	BaseAsset   string `json:"baseAsset"`
+	QuoteAsset  string `json:"quoteAsset"`
+	Status      string `json:"status"`
+	TickSize    string `json:"tickSize,omitempty"`
+	StepSize    string `json:"stepSize,omitempty"`
+	MinQty      string `json:"minQty,omitempty"`
+	MaxQty      string `json:"maxQty,omitempty"`
+	MinNotional string `json:"minNotional,omitempty"`
+}
+
+// NormalizeSymbol lowercases symbol as the Aggregator publishes it and drops
+// the separators people put between the assets, so "BTC/USDT" and
+// "btc-usdt" both become "btcusdt".
+func NormalizeSymbol(symbol string) string {
+	return strings.ToLower(strings.NewReplacer("/", "", "-", "", "_", "", " ", "").Replace(symbol))
+}
+
+// SplitSymbol splits symbol into its base and quote asset at the longest of
+// quotes it ends with, so "ethbtc" becomes "eth" and "btc". It reports
+// false when symbol ends with none of them.
+func SplitSymbol(symbol string, quotes []string) (string, string, bool) {
+	var quote string
+	for _, q := range quotes {
+		if len(q) > len(quote) && len(q) < len(symbol) && strings.HasSuffix(symbol, q) {
+			quote = q
+		}
+	}
+	if quote == "" {
+		return "", "", false
+	}
+	return strings.TrimSuffix(symbol, quote), quote, true
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/gorm"
+)
+
+type AlertsRepository interface {
+	CreateAlert(alert *models.Alert) error
+	GetAlert(userID uuid.UUID, id uint) (*models.Alert, error)
+	ListAlerts(userID uuid.UUID) ([]models.Alert, error)
+	ListActiveAlerts() ([]models.Alert, error)
+	UpdateAlert(alert *models.Alert, columns []string) error
+	MarkFired(id uint, firedAt, cutoff time.Time, value decimal.Decimal, active bool) (bool, error)
+	AckAlert(userID uuid.UUID, id uint, ackedAt time.Time) error
+	DeleteAlert(userID uuid.UUID, id uint) error
+	DeletePortfolioAlerts(userID uuid.UUID, portfolioID uint) error
+}
+
+type alertsRepository struct {
+	db *gorm.DB
+}
+
+func NewAlertsRepository(db *gorm.DB) AlertsRepository {
+	return &alertsRepository{db: db}
+}
+
+func (db *alertsRepository) CreateAlert(alert *models.Alert) error {
+	return db.db.Create(alert).Error
+}
+
+func (db *alertsRepository) GetAlert(userID uuid.UUID, id uint) (*models.Alert, error) {
+	var alert models.Alert
+
+	if err := db.db.Where("user_id = ? AND id = ?", userID, id).First(&alert).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &alert, nil
+}
+
+func (db *alertsRepository) ListAlerts(userID uuid.UUID) ([]models.Alert, error) {
+	var alerts []models.Alert
+
+	if err := db.db.Where("user_id = ?", userID).Order("id ASC").Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+func (db *alertsRepository) ListActiveAlerts() ([]models.Alert, error) {
+	var alerts []models.Alert
+
+	if err := db.db.Where("active = ?", true).Find(&alerts).Error; err != nil {
+		return nil, err
+	}
+
+	return alerts, nil
+}
+
+// UpdateAlert writes only the given columns of alert, so an edit does not
+// overwrite the firing state the engine stores concurrently.
+func (db *alertsRepository) UpdateAlert(alert *models.Alert, columns []string) error {
+	return db.db.Model(alert).Select(columns).Updates(alert).Error
+}
+
+// MarkFired stores a firing unless the alert is inactive or already fired at
+// or after cutoff, and reports whether it did. Replicas evaluate the same
+// prices, so only the one whose update lands delivers the alert.
+func (db *alertsRepository) MarkFired(id uint, firedAt, cutoff time.Time, value decimal.Decimal, active bool) (bool, error) {
+	result := db.db.Model(&models.Alert{}).
+		Where("id = ? AND active = ? AND (last_fired_at IS NULL OR last_fired_at < ?)", id, true, cutoff.UTC()).
+		Updates(map[string]interface{}{"last_fired_at": firedAt.UTC(), "last_value": value, "active": active})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (db *alertsRepository) AckAlert(userID uuid.UUID, id uint, ackedAt time.Time) error {
+	result := db.db.Model(&models.Alert{}).
+		Where("user_id = ? AND id = ?", userID, id).
+		Update("acked_at", ackedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+	return nil
+}
+
+// DeletePortfolioAlerts removes the rules watching the value of one
+// portfolio, which could never fire again once it is gone.
+func (db *alertsRepository) DeletePortfolioAlerts(userID uuid.UUID, portfolioID uint) error {
+	return db.db.Where("user_id = ? AND portfolio_id = ?", userID, portfolioID).Delete(&models.Alert{}).Error
+}
+
+func (db *alertsRepository) DeleteAlert(userID uuid.UUID, id uint) error {
+	result := db.db.Where("user_id = ? AND id = ?", userID, id).Delete(&models.Alert{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return nil
+}
@@ -12,9 +12,11 @@ import (
 
 type CoinsRepository interface {
 	AddCoin(coin *models.Coin) error
-	GetCoin(userID uuid.UUID, symbol string) (*models.Coin, error)
+	GetCoin(portfolioID uint, asset string) (*models.Coin, error)
+	GetCoins(userID uuid.UUID) ([]models.Coin, error)
+	GetAllCoins() ([]models.Coin, error)
 	UpdateCoin(coin *models.Coin) error
-	DeleteCoin(userID uuid.UUID, symbol string) error
+	DeleteCoin(portfolioID uint, asset string) error
 }
 
 type coinsRepository struct {
@@ -38,10 +40,10 @@ func (db *coinsRepository) AddCoin(coin *models.Coin) error {
 	return nil
 }
 
-func (db *coinsRepository) GetCoin(userID uuid.UUID, symbol string) (*models.Coin, error) {
+func (db *coinsRepository) GetCoin(portfolioID uint, asset string) (*models.Coin, error) {
 	var coin models.Coin
 
-	if err := db.db.Where("user_id = ? AND symbol = ?", userID, symbol).First(&coin).Error; err != nil {
+	if err := db.db.Where("portfolio_id = ? AND asset = ?", portfolioID, asset).First(&coin).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errs.ErrNotFound
 		}
@@ -51,6 +53,16 @@ func (db *coinsRepository) GetCoin(userID uuid.UUID, symbol string) (*models.Coi
 	return &coin, nil
 }
 
+func (db *coinsRepository) GetCoins(userID uuid.UUID) ([]models.Coin, error) {
+	var coins []models.Coin
+
+	if err := db.db.Where("user_id = ?", userID).Find(&coins).Error; err != nil {
+		return nil, err
+	}
+
+	return coins, nil
+}
+
 func (db *coinsRepository) UpdateCoin(coin *models.Coin) error {
 	if err := db.db.Save(coin).Error; err != nil {
 		return err
@@ -58,8 +70,18 @@ func (db *coinsRepository) UpdateCoin(coin *models.Coin) error {
 	return nil
 }
 
-func (db *coinsRepository) DeleteCoin(userID uuid.UUID, symbol string) error {
-	result := db.db.Where("user_id = ? AND symbol = ?", userID, symbol).Delete(&models.Coin{})
+func (db *coinsRepository) GetAllCoins() ([]models.Coin, error) {
+	var coins []models.Coin
+
+	if err := db.db.Where("quantity > 0").Order("user_id, asset").Find(&coins).Error; err != nil {
+		return nil, err
+	}
+
+	return coins, nil
+}
+
+func (db *coinsRepository) DeleteCoin(portfolioID uint, asset string) error {
+	result := db.db.Where("portfolio_id = ? AND asset = ?", portfolioID, asset).Delete(&models.Coin{})
 
 	if result.Error != nil {
 		return result.Error
@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PortfoliosRepository interface {
+	CreatePortfolio(portfolio *models.Portfolio) error
+	GetPortfolio(userID uuid.UUID, id uint) (*models.Portfolio, error)
+	GetDefaultPortfolio(userID uuid.UUID) (*models.Portfolio, error)
+	LockPortfolio(userID uuid.UUID, id uint) error
+	ListPortfolios(userID uuid.UUID) ([]models.Portfolio, error)
+	RenamePortfolio(userID uuid.UUID, id uint, name string) error
+	DeletePortfolio(userID uuid.UUID, id uint) error
+}
+
+type portfoliosRepository struct {
+	db *gorm.DB
+}
+
+func NewPortfoliosRepository(db *gorm.DB) PortfoliosRepository {
+	return &portfoliosRepository{db: db}
+}
+
+func (db *portfoliosRepository) CreatePortfolio(portfolio *models.Portfolio) error {
+	if err := db.db.Create(portfolio).Error; err != nil {
+		if isUniqueViolation(err) {
+			return errs.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *portfoliosRepository) GetPortfolio(userID uuid.UUID, id uint) (*models.Portfolio, error) {
+	var portfolio models.Portfolio
+
+	if err := db.db.Preload("Coins").Where("user_id = ? AND id = ?", userID, id).First(&portfolio).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &portfolio, nil
+}
+
+func (db *portfoliosRepository) GetDefaultPortfolio(userID uuid.UUID) (*models.Portfolio, error) {
+	var portfolio models.Portfolio
+
+	if err := db.db.Where("user_id = ? AND is_default = ?", userID, true).First(&portfolio).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &portfolio, nil
+}
+
+// LockPortfolio locks the row of a portfolio until the surrounding database
+// transaction ends, so changes to its ledger are applied one at a time.
+func (db *portfoliosRepository) LockPortfolio(userID uuid.UUID, id uint) error {
+	var portfolio models.Portfolio
+
+	err := db.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("user_id = ? AND id = ?", userID, id).
+		First(&portfolio).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errs.ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (db *portfoliosRepository) ListPortfolios(userID uuid.UUID) ([]models.Portfolio, error) {
+	var portfolios []models.Portfolio
+
+	if err := db.db.Preload("Coins").Where("user_id = ?", userID).Order("id ASC").Find(&portfolios).Error; err != nil {
+		return nil, err
+	}
+
+	return portfolios, nil
+}
+
+func (db *portfoliosRepository) RenamePortfolio(userID uuid.UUID, id uint, name string) error {
+	result := db.db.Model(&models.Portfolio{}).Where("user_id = ? AND id = ?", userID, id).Update("name", name)
+
+	if result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return errs.ErrAlreadyExists
+		}
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return nil
+}
+
+func (db *portfoliosRepository) DeletePortfolio(userID uuid.UUID, id uint) error {
+	result := db.db.Unscoped().Where("user_id = ? AND id = ?", userID, id).Delete(&models.Portfolio{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return nil
+}
+
+func isUniqueViolation(err error) bool {
+	errorString := err.Error()
+	return strings.Contains(errorString, "UNIQUE") || strings.Contains(errorString, "duplicate")
+}
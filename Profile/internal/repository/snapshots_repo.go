@@ -0,0 +1,141 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SnapshotsRepository interface {
+	AddSnapshots(snapshots []models.PortfolioSnapshot) error
+	ListSnapshots(userID uuid.UUID, since time.Time) ([]models.PortfolioSnapshot, error)
+	Compact(from, to models.Resolution, step time.Duration, before time.Time) (int, error)
+}
+
+type snapshotsRepository struct {
+	db *gorm.DB
+}
+
+func NewSnapshotsRepository(db *gorm.DB) SnapshotsRepository {
+	return &snapshotsRepository{db: db}
+}
+
+// AddSnapshots stores the snapshots and their coins. A snapshot of a user,
+// resolution and time that is already stored, by another replica taking the
+// same scheduled snapshot, is skipped.
+func (db *snapshotsRepository) AddSnapshots(snapshots []models.PortfolioSnapshot) error {
+	if len(snapshots) == 0 {
+		return nil
+	}
+
+	return db.db.Transaction(func(tx *gorm.DB) error {
+		for i := range snapshots {
+			snapshot := &snapshots[i]
+			result := tx.Omit("Coins").Clauses(clause.OnConflict{DoNothing: true}).Create(snapshot)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 || len(snapshot.Coins) == 0 {
+				continue
+			}
+
+			for j := range snapshot.Coins {
+				snapshot.Coins[j].SnapshotID = snapshot.ID
+			}
+			if err := tx.CreateInBatches(&snapshot.Coins, 500).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListSnapshots returns the snapshots of every resolution taken at or after
+// since, oldest first.
+func (db *snapshotsRepository) ListSnapshots(userID uuid.UUID, since time.Time) ([]models.PortfolioSnapshot, error) {
+	var snapshots []models.PortfolioSnapshot
+
+	err := db.db.Preload("Coins").
+		Where("user_id = ? AND taken_at >= ?", userID, since).
+		Order("taken_at ASC").
+		Find(&snapshots).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return snapshots, nil
+}
+
+// Compact downsamples the snapshots of resolution from taken before the
+// given time: the last snapshot of every user and step-sized bucket is kept
+// with resolution to, the rest are deleted. It returns the number of deleted
+// snapshots.
+func (db *snapshotsRepository) Compact(from, to models.Resolution, step time.Duration, before time.Time) (int, error) {
+	before = before.Truncate(step)
+	deleted := 0
+
+	err := db.db.Transaction(func(tx *gorm.DB) error {
+		var snapshots []models.PortfolioSnapshot
+		err := tx.Select("id", "user_id", "taken_at").
+			Where("resolution = ? AND taken_at < ?", from, before).
+			Order("user_id, taken_at ASC").
+			Find(&snapshots).Error
+		if err != nil {
+			return err
+		}
+
+		var keep, drop []uint
+		for i, snapshot := range snapshots {
+			last := i == len(snapshots)-1 ||
+				snapshots[i+1].UserID != snapshot.UserID ||
+				!snapshots[i+1].TakenAt.Truncate(step).Equal(snapshot.TakenAt.Truncate(step))
+			if last {
+				keep = append(keep, snapshot.ID)
+			} else {
+				drop = append(drop, snapshot.ID)
+			}
+		}
+
+		for _, ids := range chunkIDs(drop) {
+			if err := tx.Where("snapshot_id IN ?", ids).Delete(&models.SnapshotCoin{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Where("id IN ?", ids).Delete(&models.PortfolioSnapshot{}).Error; err != nil {
+				return err
+			}
+		}
+
+		for _, ids := range chunkIDs(keep) {
+			err := tx.Model(&models.PortfolioSnapshot{}).
+				Where("id IN ?", ids).
+				Update("resolution", to).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		deleted = len(drop)
+		return nil
+	})
+
+	return deleted, err
+}
+
+// chunkIDs splits ids so that no statement exceeds the bind parameter limit
+// of the driver.
+func chunkIDs(ids []uint) [][]uint {
+	const size = 1000
+
+	var chunks [][]uint
+	for len(ids) > size {
+		chunks = append(chunks, ids[:size])
+		ids = ids[size:]
+	}
+	if len(ids) > 0 {
+		chunks = append(chunks, ids)
+	}
+	return chunks
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type TransactionsRepository interface {
+	AddTransaction(tx *models.Transaction) error
+	GetTransaction(userID uuid.UUID, id uint) (*models.Transaction, error)
+	ListTransactions(userID uuid.UUID, portfolioID uint, asset string, includeVoided bool) ([]models.Transaction, error)
+	VoidTransaction(id uint, voidedAt time.Time, replacedBy *uint) error
+}
+
+type transactionsRepository struct {
+	db *gorm.DB
+}
+
+func NewTransactionsRepository(db *gorm.DB) TransactionsRepository {
+	return &transactionsRepository{db: db}
+}
+
+func (db *transactionsRepository) AddTransaction(tx *models.Transaction) error {
+	return db.db.Create(tx).Error
+}
+
+func (db *transactionsRepository) GetTransaction(userID uuid.UUID, id uint) (*models.Transaction, error) {
+	var tx models.Transaction
+
+	if err := db.db.Where("user_id = ? AND id = ?", userID, id).First(&tx).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &tx, nil
+}
+
+// ListTransactions returns the ledger in execution order. A zero portfolio
+// and an empty asset list every portfolio and every asset of the user.
+func (db *transactionsRepository) ListTransactions(userID uuid.UUID, portfolioID uint, asset string, includeVoided bool) ([]models.Transaction, error) {
+	var txs []models.Transaction
+
+	query := db.db.Where("user_id = ?", userID)
+	if portfolioID != 0 {
+		query = query.Where("portfolio_id = ?", portfolioID)
+	}
+	if asset != "" {
+		query = query.Where("asset = ?", asset)
+	}
+	if !includeVoided {
+		query = query.Where("voided_at IS NULL")
+	}
+
+	if err := query.Order("executed_at ASC, id ASC").Find(&txs).Error; err != nil {
+		return nil, err
+	}
+
+	return txs, nil
+}
+
+func (db *transactionsRepository) VoidTransaction(id uint, voidedAt time.Time, replacedBy *uint) error {
+	result := db.db.Model(&models.Transaction{}).
+		Where("id = ? AND voided_at IS NULL", id).
+		Updates(map[string]interface{}{"voided_at": voidedAt, "replaced_by": replacedBy})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrTransactionVoided
+	}
+
+	return nil
+}
@@ -14,6 +14,8 @@ type UsersRepository interface {
 	CreateUserProfile(user *models.User) error
 	GetUserByID(userID uuid.UUID) (*models.User, error)
 	DeleteUserByID(userID uuid.UUID) error
+	GetCostMethod(userID uuid.UUID) (models.CostMethod, error)
+	UpdateCostMethod(userID uuid.UUID, method models.CostMethod) error
 }
 
 type usersRepository struct {
@@ -39,7 +41,7 @@ func (db *usersRepository) CreateUserProfile(user *models.User) error {
 
 func (db *usersRepository) GetUserByID(userID uuid.UUID) (*models.User, error) {
 	var user models.User
-	if err := db.db.Preload("Coins").First(&user, userID).Error; err != nil {
+	if err := db.db.Preload("Portfolios").Preload("Coins").Preload("Watchlists.Items").First(&user, userID).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, errs.ErrNotFound
 		}
@@ -63,3 +65,29 @@ func (db *usersRepository) DeleteUserByID(userID uuid.UUID) error {
 
 	return nil
 }
+
+func (db *usersRepository) GetCostMethod(userID uuid.UUID) (models.CostMethod, error) {
+	var user models.User
+	if err := db.db.Select("cost_method").First(&user, userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", errs.ErrNotFound
+		}
+
+		return "", err
+	}
+	return user.CostMethod, nil
+}
+
+func (db *usersRepository) UpdateCostMethod(userID uuid.UUID, method models.CostMethod) error {
+	result := db.db.Model(&models.User{}).Where("id = ?", userID).Update("cost_method", method)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return nil
+}
@@ -18,7 +18,7 @@ func setupTestDB(t *testing.T) *gorm.DB {
 		t.Fatalf("failed to connect database: %v", err)
 	}
 
-	if err := db.AutoMigrate(&models.User{}, &models.Coin{}); err != nil {
+	if err := db.AutoMigrate(&models.User{}, &models.Portfolio{}, &models.Coin{}, &models.Watchlist{}, &models.WatchlistItem{}); err != nil {
 		t.Fatalf("failed to migrate database: %v", err)
 	}
 
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type WatchlistsRepository interface {
+	CreateWatchlist(watchlist *models.Watchlist) error
+	GetWatchlist(userID uuid.UUID, id uint) (*models.Watchlist, error)
+	ListWatchlists(userID uuid.UUID) ([]models.Watchlist, error)
+	RenameWatchlist(userID uuid.UUID, id uint, name string) error
+	ReplaceSymbols(watchlistID uint, symbols []string) error
+	DeleteWatchlist(userID uuid.UUID, id uint) error
+}
+
+type watchlistsRepository struct {
+	db *gorm.DB
+}
+
+func NewWatchlistsRepository(db *gorm.DB) WatchlistsRepository {
+	return &watchlistsRepository{db: db}
+}
+
+func (db *watchlistsRepository) CreateWatchlist(watchlist *models.Watchlist) error {
+	if err := db.db.Create(watchlist).Error; err != nil {
+		if isUniqueViolation(err) {
+			return errs.ErrAlreadyExists
+		}
+		return err
+	}
+	return nil
+}
+
+func (db *watchlistsRepository) GetWatchlist(userID uuid.UUID, id uint) (*models.Watchlist, error) {
+	var watchlist models.Watchlist
+
+	if err := db.db.Preload("Items").Where("user_id = ? AND id = ?", userID, id).First(&watchlist).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errs.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &watchlist, nil
+}
+
+func (db *watchlistsRepository) ListWatchlists(userID uuid.UUID) ([]models.Watchlist, error) {
+	var watchlists []models.Watchlist
+
+	if err := db.db.Preload("Items").Where("user_id = ?", userID).Order("id ASC").Find(&watchlists).Error; err != nil {
+		return nil, err
+	}
+
+	return watchlists, nil
+}
+
+func (db *watchlistsRepository) RenameWatchlist(userID uuid.UUID, id uint, name string) error {
+	result := db.db.Model(&models.Watchlist{}).Where("user_id = ? AND id = ?", userID, id).Update("name", name)
+
+	if result.Error != nil {
+		if isUniqueViolation(result.Error) {
+			return errs.ErrAlreadyExists
+		}
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return nil
+}
+
+// ReplaceSymbols swaps the items of a watchlist for symbols. Ownership must
+// be checked by the caller.
+func (db *watchlistsRepository) ReplaceSymbols(watchlistID uint, symbols []string) error {
+	if err := db.db.Where("watchlist_id = ?", watchlistID).Delete(&models.WatchlistItem{}).Error; err != nil {
+		return err
+	}
+
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	items := make([]models.WatchlistItem, 0, len(symbols))
+	for _, symbol := range symbols {
+		items = append(items, models.WatchlistItem{WatchlistID: watchlistID, Symbol: symbol})
+	}
+	return db.db.Create(&items).Error
+}
+
+func (db *watchlistsRepository) DeleteWatchlist(userID uuid.UUID, id uint) error {
+	result := db.db.Unscoped().Where("user_id = ? AND id = ?", userID, id).Delete(&models.Watchlist{})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return errs.ErrNotFound
+	}
+
+	return nil
+}
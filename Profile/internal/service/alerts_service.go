@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/safehttp"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+)
+
+const defaultAlertCooldown = 5 * time.Minute
+
+type AlertsService interface {
+	CreateAlert(ctx context.Context, userID uuid.UUID, alert *models.Alert) error
+	GetAlert(ctx context.Context, userID uuid.UUID, id uint) (*models.Alert, error)
+	ListAlerts(ctx context.Context, userID uuid.UUID) ([]models.Alert, error)
+	UpdateAlert(ctx context.Context, userID uuid.UUID, id uint, patch AlertPatch) (*models.Alert, error)
+	DeleteAlert(ctx context.Context, userID uuid.UUID, id uint) error
+	AckAlert(ctx context.Context, userID uuid.UUID, id uint) error
+	PendingAlerts(ctx context.Context, userID uuid.UUID) ([]models.AlertEvent, error)
+}
+
+// AlertPatch holds the fields of an alert update; nil fields are kept.
+type AlertPatch struct {
+	Threshold   *decimal.Decimal
+	WindowSec   *uint
+	Recurring   *bool
+	CooldownSec *uint
+	WebhookURL  *string
+	Active      *bool
+}
+
+type alertsService struct {
+	repo           repository.AlertsRepository
+	portfoliosRepo repository.PortfoliosRepository
+	symbols        SymbolRegistry
+}
+
+func NewAlertsService(repo repository.AlertsRepository, portfoliosRepo repository.PortfoliosRepository, symbols SymbolRegistry) AlertsService {
+	return &alertsService{
+		repo:           repo,
+		portfoliosRepo: portfoliosRepo,
+		symbols:        symbols,
+	}
+}
+
+func (s *alertsService) CreateAlert(_ context.Context, userID uuid.UUID, alert *models.Alert) error {
+	alert.UserID = userID
+	alert.Active = true
+	alert.LastFiredAt = nil
+	alert.AckedAt = nil
+
+	if err := s.validateAlert(alert); err != nil {
+		return err
+	}
+
+	return s.repo.CreateAlert(alert)
+}
+
+func (s *alertsService) GetAlert(_ context.Context, userID uuid.UUID, id uint) (*models.Alert, error) {
+	return s.repo.GetAlert(userID, id)
+}
+
+func (s *alertsService) ListAlerts(_ context.Context, userID uuid.UUID) ([]models.Alert, error) {
+	return s.repo.ListAlerts(userID)
+}
+
+func (s *alertsService) UpdateAlert(_ context.Context, userID uuid.UUID, id uint, patch AlertPatch) (*models.Alert, error) {
+	alert, err := s.repo.GetAlert(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	patch.apply(alert)
+
+	if err := s.validateAlert(alert); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.UpdateAlert(alert, patch.columns()); err != nil {
+		return nil, fmt.Errorf("failed to update alert: %w", err)
+	}
+
+	return alert, nil
+}
+
+func (s *alertsService) DeleteAlert(_ context.Context, userID uuid.UUID, id uint) error {
+	return s.repo.DeleteAlert(userID, id)
+}
+
+func (s *alertsService) AckAlert(_ context.Context, userID uuid.UUID, id uint) error {
+	return s.repo.AckAlert(userID, id, time.Now().UTC())
+}
+
+// PendingAlerts returns the last firing of every alert the user has not
+// acknowledged yet, oldest first.
+func (s *alertsService) PendingAlerts(_ context.Context, userID uuid.UUID) ([]models.AlertEvent, error) {
+	alerts, err := s.repo.ListAlerts(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []models.AlertEvent
+	for _, alert := range alerts {
+		if !alert.Pending() {
+			continue
+		}
+		events = append(events, models.AlertEvent{
+			Type:      models.FrameAlert,
+			AlertID:   alert.ID,
+			Kind:      alert.Kind,
+			Symbol:    alert.Symbol,
+			Threshold: alert.Threshold,
+			Value:     alert.LastValue,
+			FiredAt:   *alert.LastFiredAt,
+		})
+	}
+
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].FiredAt.Before(events[j].FiredAt)
+	})
+	return events, nil
+}
+
+// validateAlert checks a rule and normalizes it: symbols must be listed in
+// the registry and are normalized as the Aggregator publishes them, and
+// recurring rules get a default cooldown.
+func (s *alertsService) validateAlert(alert *models.Alert) error {
+	if !alert.Kind.Valid() {
+		return fmt.Errorf("%w: unknown kind %q", errs.ErrInvalidAlert, alert.Kind)
+	}
+
+	if !alert.Threshold.IsPositive() {
+		return fmt.Errorf("%w: threshold must be positive", errs.ErrInvalidAlert)
+	}
+
+	if alert.Kind.IsPortfolio() {
+		alert.Symbol = ""
+		if alert.PortfolioID != 0 {
+			if _, err := s.portfoliosRepo.GetPortfolio(alert.UserID, alert.PortfolioID); err != nil {
+				return err
+			}
+		}
+	} else {
+		alert.PortfolioID = 0
+		if strings.TrimSpace(alert.Symbol) == "" {
+			return fmt.Errorf("%w: symbol is required", errs.ErrInvalidAlert)
+		}
+		symbol, err := resolveSymbol(s.symbols, alert.Symbol, errs.ErrInvalidAlert)
+		if err != nil {
+			return err
+		}
+		alert.Symbol = symbol
+	}
+
+	if alert.Kind == models.AlertChangeUp || alert.Kind == models.AlertChangeDown {
+		if alert.WindowSec == 0 || alert.Window() > models.MaxAlertWindow {
+			return fmt.Errorf("%w: window must be between 1s and %s", errs.ErrInvalidAlert, models.MaxAlertWindow)
+		}
+	} else {
+		alert.WindowSec = 0
+	}
+
+	if alert.Recurring && alert.CooldownSec == 0 {
+		alert.CooldownSec = uint(defaultAlertCooldown / time.Second)
+	}
+
+	if alert.WebhookURL != "" {
+		if err := safehttp.CheckURL(alert.WebhookURL); err != nil {
+			return fmt.Errorf("%w: webhook %v", errs.ErrInvalidAlert, err)
+		}
+	}
+
+	return nil
+}
+
+// columns lists what an update writes: the settings, which validateAlert may
+// normalize, and active only when the patch sets it, so a rule deactivated by
+// a firing in the meantime is not switched back on by an unrelated edit.
+func (p AlertPatch) columns() []string {
+	columns := []string{"threshold", "window_sec", "recurring", "cooldown_sec", "webhook_url"}
+	if p.Active != nil {
+		columns = append(columns, "active")
+	}
+	return columns
+}
+
+func (p AlertPatch) apply(alert *models.Alert) {
+	if p.Threshold != nil {
+		alert.Threshold = *p.Threshold
+	}
+	if p.WindowSec != nil {
+		alert.WindowSec = *p.WindowSec
+	}
+	if p.Recurring != nil {
+		alert.Recurring = *p.Recurring
+	}
+	if p.CooldownSec != nil {
+		alert.CooldownSec = *p.CooldownSec
+	}
+	if p.WebhookURL != nil {
+		alert.WebhookURL = *p.WebhookURL
+	}
+	if p.Active != nil {
+		alert.Active = *p.Active
+	}
+}
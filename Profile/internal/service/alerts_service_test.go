@@ -0,0 +1,72 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/service"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/shopspring/decimal"
+)
+
+func TestUpdateAlert(t *testing.T) {
+	_, db, userID := setupCoinsService(t)
+	if err := db.AutoMigrate(&models.Alert{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	repo := repository.NewAlertsRepository(db)
+	svc := service.NewAlertsService(repo, repository.NewPortfoliosRepository(db), nil)
+	ctx := context.Background()
+
+	alert := &models.Alert{Kind: models.AlertPriceAbove, Symbol: "btcusdt", Threshold: decimal.NewFromInt(70000)}
+	if err := svc.CreateAlert(ctx, userID, alert); err != nil {
+		t.Fatalf("CreateAlert failed: %v", err)
+	}
+
+	t.Run("edit_keeps_firing_state", func(t *testing.T) {
+		firedAt := time.Now()
+		if _, err := repo.MarkFired(alert.ID, firedAt, firedAt, decimal.NewFromInt(71000), false); err != nil {
+			t.Fatalf("MarkFired failed: %v", err)
+		}
+
+		threshold := decimal.NewFromInt(72000)
+		if _, err := svc.UpdateAlert(ctx, userID, alert.ID, service.AlertPatch{Threshold: &threshold}); err != nil {
+			t.Fatalf("UpdateAlert failed: %v", err)
+		}
+
+		stored, err := repo.GetAlert(userID, alert.ID)
+		if err != nil {
+			t.Fatalf("GetAlert failed: %v", err)
+		}
+		if !stored.Threshold.Equal(threshold) || stored.Active || stored.LastFiredAt == nil {
+			t.Errorf("Expected new threshold with the firing kept, got threshold %s, active %v, fired %v", stored.Threshold, stored.Active, stored.LastFiredAt)
+		}
+	})
+
+	t.Run("edit_reactivates_when_asked", func(t *testing.T) {
+		active := true
+		if _, err := svc.UpdateAlert(ctx, userID, alert.ID, service.AlertPatch{Active: &active}); err != nil {
+			t.Fatalf("UpdateAlert failed: %v", err)
+		}
+
+		stored, err := repo.GetAlert(userID, alert.ID)
+		if err != nil {
+			t.Fatalf("GetAlert failed: %v", err)
+		}
+		if !stored.Active {
+			t.Errorf("Expected alert to be active again")
+		}
+	})
+
+	t.Run("internal_webhook_is_rejected", func(t *testing.T) {
+		webhook := "http://aggregator-service:8088/streams"
+		_, err := svc.UpdateAlert(ctx, userID, alert.ID, service.AlertPatch{WebhookURL: &webhook})
+		if !errors.Is(err, errs.ErrInvalidAlert) {
+			t.Errorf("Expected ErrInvalidAlert, but got %v", err)
+		}
+	})
+}
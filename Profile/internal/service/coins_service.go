@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
@@ -13,73 +16,446 @@ import (
 )
 
 type CoinsService interface {
-	UpdateCoinQuantity(ctx context.Context, userID uuid.UUID, symbol string, quantityChange decimal.Decimal) (*models.Coin, error)
-	DeleteCoin(ctx context.Context, userID uuid.UUID, symbol string) error
+	RecordTransaction(ctx context.Context, userID uuid.UUID, tx *models.Transaction) (*models.Coin, error)
+	DeleteCoin(ctx context.Context, userID uuid.UUID, portfolioID uint, asset string) error
+	ListTransactions(ctx context.Context, userID uuid.UUID, portfolioID uint, asset string, includeVoided bool) ([]models.Transaction, error)
+	EditTransaction(ctx context.Context, userID uuid.UUID, id uint, patch TransactionPatch) (*models.Transaction, error)
+	VoidTransaction(ctx context.Context, userID uuid.UUID, id uint) error
+	SetCostMethod(ctx context.Context, userID uuid.UUID, method models.CostMethod) error
+}
+
+// TransactionPatch holds the fields of an edit; nil fields keep the value of
+// the original transaction.
+type TransactionPatch struct {
+	Side       *models.Side
+	Quantity   *decimal.Decimal
+	Price      *decimal.Decimal
+	Fee        *decimal.Decimal
+	ExecutedAt *time.Time
+	Note       *string
 }
 
 type coinsService struct {
-	coinsRepo repository.CoinsRepository
-	db *gorm.DB
+	coinsRepo        repository.CoinsRepository
+	transactionsRepo repository.TransactionsRepository
+	db               *gorm.DB
+	events           EventPublisher
+	symbols          SymbolRegistry
 }
 
-func NewCoinsService(coinsRepo repository.CoinsRepository, db *gorm.DB) CoinsService {
+func NewCoinsService(coinsRepo repository.CoinsRepository, transactionsRepo repository.TransactionsRepository, db *gorm.DB, events EventPublisher, symbols SymbolRegistry) CoinsService {
 	return &coinsService{
-		coinsRepo: coinsRepo,
-		db: db,
+		coinsRepo:        coinsRepo,
+		transactionsRepo: transactionsRepo,
+		db:               db,
+		events:           events,
+		symbols:          symbols,
 	}
 }
 
-func (s *coinsService) UpdateCoinQuantity(ctx context.Context, userID uuid.UUID, symbol string, quantityChange decimal.Decimal) (*models.Coin, error) {
+// RecordTransaction appends tx to the ledger of its portfolio; a zero
+// PortfolioID books it into the default portfolio of the user. The holding
+// is named by Asset, by Symbol, a listed pair of it, or by both. The pair
+// priced in is the one the holding is valued with; the first transaction of
+// an asset picks it, from Symbol or else from the quote preference of the
+// registry.
+func (s *coinsService) RecordTransaction(ctx context.Context, userID uuid.UUID, tx *models.Transaction) (*models.Coin, error) {
+	tx.UserID = userID
+	if tx.ExecutedAt.IsZero() {
+		tx.ExecutedAt = time.Now().UTC()
+	}
+
+	if err := validateTransaction(tx); err != nil {
+		return nil, err
+	}
+
+	tx.Asset = models.NormalizeSymbol(tx.Asset)
+	if tx.Symbol != "" {
+		symbol, base, err := resolvePair(s.symbols, tx.Symbol, errs.ErrInvalidTransaction)
+		if err != nil {
+			return nil, err
+		}
+		if tx.Asset != "" && tx.Asset != base {
+			return nil, fmt.Errorf("%w: %s is not a pair of %s", errs.ErrInvalidTransaction, symbol, tx.Asset)
+		}
+		tx.Symbol = symbol
+		tx.Asset = base
+	}
+
 	var resultingCoin *models.Coin
 
-	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		txRepo := repository.NewCoinsRepository(tx)
+	err := s.db.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+		txRepo := repository.NewTransactionsRepository(dbTx)
 
-		existingCoin, err := txRepo.GetCoin(userID, symbol)
+		portfolioID, err := resolvePortfolio(dbTx, userID, tx.PortfolioID)
+		if err != nil {
+			return err
+		}
+		tx.PortfolioID = portfolioID
+
+		if err := s.pickSymbol(dbTx, tx); err != nil {
+			return err
+		}
 
+		if err := txRepo.AddTransaction(tx); err != nil {
+			return err
+		}
+
+		coin, err := rebuildCoin(dbTx, userID, portfolioID, tx.Asset, tx.Symbol)
 		if err != nil {
-			if quantityChange.IsNegative() {
-				return errs.ErrInsufficientFunds
-			}
+			return err
+		}
+
+		resultingCoin = coin
+		return nil
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to record transaction: %w", err)
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventCoins)
+	return resultingCoin, nil
+}
+
+// DeleteCoin voids every open transaction of the asset, so the holding
+// disappears while its history stays available for audit. A listed pair
+// stands for its base asset.
+func (s *coinsService) DeleteCoin(ctx context.Context, userID uuid.UUID, portfolioID uint, asset string) error {
+	asset = assetOf(s.symbols, asset)
+
+	err := s.db.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+		txRepo := repository.NewTransactionsRepository(dbTx)
+		coinsRepo := repository.NewCoinsRepository(dbTx)
+
+		portfolioID, err := resolvePortfolio(dbTx, userID, portfolioID)
+		if err != nil {
+			return err
+		}
+
+		txs, err := txRepo.ListTransactions(userID, portfolioID, asset, false)
+		if err != nil {
+			return err
+		}
 
-			if err == errs.ErrNotFound {
-				newCoin := &models.Coin{
-					Symbol: symbol,
-					Quantity: quantityChange,
-					UserID: userID,
-				}
-				if err := txRepo.AddCoin(newCoin); err != nil {
-					return err
-				}
-				resultingCoin = newCoin
-				return nil
+		now := time.Now().UTC()
+		for _, tx := range txs {
+			if err := txRepo.VoidTransaction(tx.ID, now, nil); err != nil {
+				return err
 			}
+		}
+
+		return coinsRepo.DeleteCoin(portfolioID, asset)
+	})
+	if err != nil {
+		return err
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventCoins)
+	return nil
+}
+
+// ListTransactions lists the ledger of one portfolio, or of all portfolios of
+// the user when portfolioID is zero. A listed pair stands for its base asset.
+func (s *coinsService) ListTransactions(_ context.Context, userID uuid.UUID, portfolioID uint, asset string, includeVoided bool) ([]models.Transaction, error) {
+	if asset != "" {
+		asset = assetOf(s.symbols, asset)
+	}
+	return s.transactionsRepo.ListTransactions(userID, portfolioID, asset, includeVoided)
+}
 
+// EditTransaction never rewrites a ledger row: the original is voided and a
+// corrected copy is appended in its place.
+func (s *coinsService) EditTransaction(ctx context.Context, userID uuid.UUID, id uint, patch TransactionPatch) (*models.Transaction, error) {
+	var replacement *models.Transaction
+
+	err := s.db.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+		txRepo := repository.NewTransactionsRepository(dbTx)
+
+		original, err := lockTransaction(dbTx, userID, id)
+		if err != nil {
 			return err
 		}
-		newQuantity := existingCoin.Quantity.Add(quantityChange)
+		if original.VoidedAt != nil {
+			return errs.ErrTransactionVoided
+		}
+
+		edited := &models.Transaction{
+			UserID:      userID,
+			PortfolioID: original.PortfolioID,
+			Asset:       original.Asset,
+			Symbol:      original.Symbol,
+			Side:        original.Side,
+			Quantity:    original.Quantity,
+			Price:       original.Price,
+			Fee:         original.Fee,
+			ExecutedAt:  original.ExecutedAt,
+			Note:        original.Note,
+		}
+		patch.apply(edited)
 
-		if newQuantity.IsNegative() {
-			return errs.ErrInsufficientFunds
+		if err := validateTransaction(edited); err != nil {
+			return err
 		}
 
-		existingCoin.Quantity = newQuantity
+		if err := txRepo.AddTransaction(edited); err != nil {
+			return err
+		}
 
-		if err := txRepo.UpdateCoin(existingCoin); err != nil {
+		if err := txRepo.VoidTransaction(original.ID, time.Now().UTC(), &edited.ID); err != nil {
 			return err
 		}
-		
-		resultingCoin = existingCoin
+
+		if _, err := rebuildCoin(dbTx, userID, original.PortfolioID, original.Asset, original.Symbol); err != nil {
+			return err
+		}
+
+		replacement = edited
 		return nil
 	})
 
 	if err != nil {
-		return nil, fmt.Errorf("failed to upsert coin: %w", err)
+		return nil, fmt.Errorf("failed to edit transaction: %w", err)
 	}
 
-	return resultingCoin, nil
+	publishEvent(ctx, s.events, userID, models.ProfileEventCoins)
+	return replacement, nil
+}
+
+func (s *coinsService) VoidTransaction(ctx context.Context, userID uuid.UUID, id uint) error {
+	err := s.db.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+		txRepo := repository.NewTransactionsRepository(dbTx)
+
+		tx, err := lockTransaction(dbTx, userID, id)
+		if err != nil {
+			return err
+		}
+		if tx.VoidedAt != nil {
+			return errs.ErrTransactionVoided
+		}
+
+		if err := txRepo.VoidTransaction(tx.ID, time.Now().UTC(), nil); err != nil {
+			return err
+		}
+
+		_, err = rebuildCoin(dbTx, userID, tx.PortfolioID, tx.Asset, tx.Symbol)
+		return err
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to void transaction: %w", err)
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventCoins)
+	return nil
 }
 
-func (s *coinsService) DeleteCoin(_ context.Context, userID uuid.UUID, symbol string) error {
-	return s.coinsRepo.DeleteCoin(userID, symbol)
+// SetCostMethod switches the lot matching method of a profile and recomputes
+// every coin with it.
+func (s *coinsService) SetCostMethod(ctx context.Context, userID uuid.UUID, method models.CostMethod) error {
+	if !method.Valid() {
+		return fmt.Errorf("%w: unknown cost method %q", errs.ErrInvalidTransaction, method)
+	}
+
+	err := s.db.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+		if err := repository.NewUsersRepository(dbTx).UpdateCostMethod(userID, method); err != nil {
+			return err
+		}
+
+		coins, err := repository.NewCoinsRepository(dbTx).GetCoins(userID)
+		if err != nil {
+			return err
+		}
+
+		portfoliosRepo := repository.NewPortfoliosRepository(dbTx)
+		for _, coin := range coins {
+			if err := portfoliosRepo.LockPortfolio(userID, coin.PortfolioID); err != nil {
+				return err
+			}
+			if _, err := rebuildCoin(dbTx, userID, coin.PortfolioID, coin.Asset, coin.Symbol); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to set cost method: %w", err)
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventCostMethod)
+	return nil
+}
+
+// resolvePortfolio checks that the portfolio belongs to the user and locks it
+// until dbTx ends, so concurrent changes cannot both pass the balance check of
+// rebuildCoin. A zero id selects the default portfolio, which is created on
+// first use.
+func resolvePortfolio(dbTx *gorm.DB, userID uuid.UUID, portfolioID uint) (uint, error) {
+	portfoliosRepo := repository.NewPortfoliosRepository(dbTx)
+
+	if portfolioID != 0 {
+		if err := portfoliosRepo.LockPortfolio(userID, portfolioID); err != nil {
+			return 0, err
+		}
+		return portfolioID, nil
+	}
+
+	portfolio, err := portfoliosRepo.GetDefaultPortfolio(userID)
+	if err == nil {
+		if err := portfoliosRepo.LockPortfolio(userID, portfolio.ID); err != nil {
+			return 0, err
+		}
+		return portfolio.ID, nil
+	}
+	if !errors.Is(err, errs.ErrNotFound) {
+		return 0, err
+	}
+
+	portfolio = &models.Portfolio{UserID: userID, Name: models.DefaultPortfolioName, IsDefault: true}
+	if err := portfoliosRepo.CreatePortfolio(portfolio); err != nil {
+		return 0, err
+	}
+	return portfolio.ID, nil
+}
+
+// lockTransaction locks the portfolio of a ledger entry and returns the
+// entry as it is once the lock is held, as a concurrent edit may have voided
+// it in the meantime.
+func lockTransaction(dbTx *gorm.DB, userID uuid.UUID, id uint) (*models.Transaction, error) {
+	txRepo := repository.NewTransactionsRepository(dbTx)
+
+	tx, err := txRepo.GetTransaction(userID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repository.NewPortfoliosRepository(dbTx).LockPortfolio(userID, tx.PortfolioID); err != nil {
+		return nil, err
+	}
+
+	return txRepo.GetTransaction(userID, id)
+}
+
+// pickSymbol sets the pair tx is priced in: the one its holding is valued
+// with, or the preferred pair of the asset for a new holding. A transaction
+// priced in another pair of a held asset is rejected, as the ledger of a
+// holding is kept in a single quote.
+func (s *coinsService) pickSymbol(dbTx *gorm.DB, tx *models.Transaction) error {
+	coin, err := repository.NewCoinsRepository(dbTx).GetCoin(tx.PortfolioID, tx.Asset)
+	if err != nil && !errors.Is(err, errs.ErrNotFound) {
+		return err
+	}
+
+	switch {
+	case coin != nil && tx.Symbol == "":
+		tx.Symbol = coin.Symbol
+	case coin != nil && tx.Symbol != coin.Symbol:
+		return fmt.Errorf("%w: %s is held against %s", errs.ErrInvalidTransaction, tx.Asset, coin.Symbol)
+	case tx.Symbol == "":
+		symbol, err := pricingSymbol(s.symbols, tx.Asset, errs.ErrInvalidTransaction)
+		if err != nil {
+			return err
+		}
+		tx.Symbol = symbol
+	}
+	return nil
+}
+
+// rebuildCoin replays the open ledger of an asset within a portfolio and
+// stores the result as its coin, valued with symbol when it is new. The
+// coin row is removed once no open transactions remain.
+func rebuildCoin(dbTx *gorm.DB, userID uuid.UUID, portfolioID uint, asset, symbol string) (*models.Coin, error) {
+	txRepo := repository.NewTransactionsRepository(dbTx)
+	coinsRepo := repository.NewCoinsRepository(dbTx)
+
+	txs, err := txRepo.ListTransactions(userID, portfolioID, asset, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(txs) == 0 {
+		if err := coinsRepo.DeleteCoin(portfolioID, asset); err != nil && !errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	method, err := repository.NewUsersRepository(dbTx).GetCostMethod(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	pos, err := replayLedger(txs, method)
+	if err != nil {
+		return nil, err
+	}
+
+	coin, err := coinsRepo.GetCoin(portfolioID, asset)
+	if err != nil {
+		if !errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+
+		coin = &models.Coin{
+			PortfolioID: portfolioID,
+			Asset:       asset,
+			Symbol:      symbol,
+			Quantity:    pos.Quantity,
+			CostBasis:   pos.CostBasis,
+			RealizedPnL: pos.RealizedPnL,
+			UserID:      userID,
+		}
+		if err := coinsRepo.AddCoin(coin); err != nil {
+			return nil, err
+		}
+		return coin, nil
+	}
+
+	coin.Quantity = pos.Quantity
+	coin.CostBasis = pos.CostBasis
+	coin.RealizedPnL = pos.RealizedPnL
+
+	if err := coinsRepo.UpdateCoin(coin); err != nil {
+		return nil, err
+	}
+
+	return coin, nil
+}
+
+func validateTransaction(tx *models.Transaction) error {
+	switch {
+	case strings.TrimSpace(tx.Asset) == "" && strings.TrimSpace(tx.Symbol) == "":
+		return fmt.Errorf("%w: asset or symbol is required", errs.ErrInvalidTransaction)
+	case !tx.Side.Valid():
+		return fmt.Errorf("%w: side must be %q or %q", errs.ErrInvalidTransaction, models.SideBuy, models.SideSell)
+	case !tx.Quantity.IsPositive():
+		return fmt.Errorf("%w: quantity must be positive", errs.ErrInvalidTransaction)
+	case tx.Price.IsNegative():
+		return fmt.Errorf("%w: price cannot be negative", errs.ErrInvalidTransaction)
+	case tx.Fee.IsNegative():
+		return fmt.Errorf("%w: fee cannot be negative", errs.ErrInvalidTransaction)
+	}
+	return nil
+}
+
+func (p TransactionPatch) apply(tx *models.Transaction) {
+	if p.Side != nil {
+		tx.Side = *p.Side
+	}
+	if p.Quantity != nil {
+		tx.Quantity = *p.Quantity
+	}
+	if p.Price != nil {
+		tx.Price = *p.Price
+	}
+	if p.Fee != nil {
+		tx.Fee = *p.Fee
+	}
+	if p.ExecutedAt != nil {
+		tx.ExecutedAt = *p.ExecutedAt
+	}
+	if p.Note != nil {
+		tx.Note = *p.Note
+	}
 }
@@ -0,0 +1,371 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/service"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/google/uuid"
+	"github.com/shopspring/decimal"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupCoinsService(t *testing.T) (service.CoinsService, *gorm.DB, uuid.UUID) {
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{})
+	if err != nil {
+		t.Fatalf("failed to connect database: %v", err)
+	}
+
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get sql database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	if err := db.AutoMigrate(&models.User{}, &models.Portfolio{}, &models.Coin{}, &models.Transaction{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+
+	user := &models.User{ID: uuid.New(), Name: "ledger_user"}
+	if err := repository.NewUsersRepository(db).CreateUserProfile(user); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	svc := service.NewCoinsService(
+		repository.NewCoinsRepository(db),
+		repository.NewTransactionsRepository(db),
+		db,
+		nil,
+		nil,
+	)
+	return svc, db, user.ID
+}
+
+func record(t *testing.T, svc service.CoinsService, userID uuid.UUID, side models.Side, qty, price string, at time.Time) *models.Coin {
+	t.Helper()
+
+	coin, err := svc.RecordTransaction(context.Background(), userID, &models.Transaction{
+		Symbol:     "btcusdt",
+		Side:       side,
+		Quantity:   decimal.RequireFromString(qty),
+		Price:      decimal.RequireFromString(price),
+		ExecutedAt: at,
+	})
+	if err != nil {
+		t.Fatalf("RecordTransaction failed: %v", err)
+	}
+	return coin
+}
+
+func TestRecordTransaction(t *testing.T) {
+	svc, _, userID := setupCoinsService(t)
+	start := time.Now().Add(-time.Hour)
+
+	t.Run("cost_basis_follows_average_price", func(t *testing.T) {
+		record(t, svc, userID, models.SideBuy, "1", "100", start)
+		record(t, svc, userID, models.SideBuy, "1", "200", start.Add(time.Minute))
+		coin := record(t, svc, userID, models.SideSell, "1", "300", start.Add(2*time.Minute))
+
+		if !coin.Quantity.Equal(decimal.NewFromInt(1)) {
+			t.Errorf("Expected quantity 1, got %s", coin.Quantity)
+		}
+		if !coin.CostBasis.Equal(decimal.NewFromInt(150)) {
+			t.Errorf("Expected cost basis 150, got %s", coin.CostBasis)
+		}
+	})
+
+	t.Run("oversell_is_rejected", func(t *testing.T) {
+		_, err := svc.RecordTransaction(context.Background(), userID, &models.Transaction{
+			Symbol:   "btcusdt",
+			Side:     models.SideSell,
+			Quantity: decimal.NewFromInt(5),
+		})
+		if !errors.Is(err, errs.ErrInsufficientFunds) {
+			t.Errorf("Expected ErrInsufficientFunds, but got %v", err)
+		}
+	})
+}
+
+func TestEditAndVoidTransaction(t *testing.T) {
+	svc, _, userID := setupCoinsService(t)
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour)
+
+	record(t, svc, userID, models.SideBuy, "2", "100", start)
+	record(t, svc, userID, models.SideSell, "1", "150", start.Add(time.Minute))
+
+	txs, err := svc.ListTransactions(ctx, userID, 0, "btcusdt", false)
+	if err != nil || len(txs) != 2 {
+		t.Fatalf("Expected 2 open transactions, got %d (err: %v)", len(txs), err)
+	}
+	buy, sell := txs[0], txs[1]
+
+	t.Run("edit_replaces_original", func(t *testing.T) {
+		price := decimal.NewFromInt(50)
+		edited, err := svc.EditTransaction(ctx, userID, buy.ID, service.TransactionPatch{Price: &price})
+		if err != nil {
+			t.Fatalf("EditTransaction failed: %v", err)
+		}
+
+		all, _ := svc.ListTransactions(ctx, userID, 0, "btcusdt", true)
+		for _, tx := range all {
+			if tx.ID == buy.ID && (tx.VoidedAt == nil || tx.ReplacedBy == nil || *tx.ReplacedBy != edited.ID) {
+				t.Errorf("Expected original transaction to be voided and replaced by %d", edited.ID)
+			}
+		}
+		buy = *edited
+	})
+
+	t.Run("void_breaking_history_is_rejected", func(t *testing.T) {
+		err := svc.VoidTransaction(ctx, userID, buy.ID)
+		if !errors.Is(err, errs.ErrInsufficientFunds) {
+			t.Errorf("Expected ErrInsufficientFunds, but got %v", err)
+		}
+	})
+
+	t.Run("void_twice_is_rejected", func(t *testing.T) {
+		if err := svc.VoidTransaction(ctx, userID, sell.ID); err != nil {
+			t.Fatalf("VoidTransaction failed: %v", err)
+		}
+		err := svc.VoidTransaction(ctx, userID, sell.ID)
+		if !errors.Is(err, errs.ErrTransactionVoided) {
+			t.Errorf("Expected ErrTransactionVoided, but got %v", err)
+		}
+	})
+}
+
+func TestSetCostMethod(t *testing.T) {
+	svc, db, userID := setupCoinsService(t)
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour)
+
+	record(t, svc, userID, models.SideBuy, "1", "100", start)
+	record(t, svc, userID, models.SideBuy, "1", "300", start.Add(time.Minute))
+	record(t, svc, userID, models.SideSell, "1", "400", start.Add(2*time.Minute))
+
+	tests := []struct {
+		method      models.CostMethod
+		costBasis   int64
+		realizedPnL int64
+	}{
+		{models.CostMethodAverage, 200, 200},
+		{models.CostMethodFIFO, 300, 300},
+		{models.CostMethodLIFO, 100, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.method), func(t *testing.T) {
+			if err := svc.SetCostMethod(ctx, userID, tt.method); err != nil {
+				t.Fatalf("SetCostMethod failed: %v", err)
+			}
+
+			portfolio, err := repository.NewPortfoliosRepository(db).GetDefaultPortfolio(userID)
+			if err != nil {
+				t.Fatalf("GetDefaultPortfolio failed: %v", err)
+			}
+
+			coin, err := repository.NewCoinsRepository(db).GetCoin(portfolio.ID, "btc")
+			if err != nil {
+				t.Fatalf("GetCoin failed: %v", err)
+			}
+
+			if !coin.CostBasis.Equal(decimal.NewFromInt(tt.costBasis)) {
+				t.Errorf("Expected cost basis %d, got %s", tt.costBasis, coin.CostBasis)
+			}
+			if !coin.RealizedPnL.Equal(decimal.NewFromInt(tt.realizedPnL)) {
+				t.Errorf("Expected realized P&L %d, got %s", tt.realizedPnL, coin.RealizedPnL)
+			}
+		})
+	}
+
+	t.Run("unknown_method_is_rejected", func(t *testing.T) {
+		err := svc.SetCostMethod(ctx, userID, models.CostMethod("hifo"))
+		if !errors.Is(err, errs.ErrInvalidTransaction) {
+			t.Errorf("Expected ErrInvalidTransaction, but got %v", err)
+		}
+	})
+}
+
+type eventRecorder struct {
+	events []models.ProfileEvent
+}
+
+func (r *eventRecorder) PublishProfileEvent(_ context.Context, event models.ProfileEvent) {
+	r.events = append(r.events, event)
+}
+
+func TestProfileEvents(t *testing.T) {
+	_, db, userID := setupCoinsService(t)
+	events := &eventRecorder{}
+	svc := service.NewCoinsService(repository.NewCoinsRepository(db), repository.NewTransactionsRepository(db), db, events, nil)
+	ctx := context.Background()
+
+	t.Run("committed_change_is_published", func(t *testing.T) {
+		record(t, svc, userID, models.SideBuy, "1", "100", time.Now())
+		if len(events.events) != 1 || events.events[0].Kind != models.ProfileEventCoins || events.events[0].UserID != userID {
+			t.Errorf("Expected one coins event for the user, got %+v", events.events)
+		}
+	})
+
+	t.Run("failed_change_is_not_published", func(t *testing.T) {
+		_, err := svc.RecordTransaction(ctx, userID, &models.Transaction{
+			Symbol:   "btcusdt",
+			Side:     models.SideSell,
+			Quantity: decimal.NewFromInt(5),
+		})
+		if !errors.Is(err, errs.ErrInsufficientFunds) {
+			t.Fatalf("Expected ErrInsufficientFunds, but got %v", err)
+		}
+		if len(events.events) != 1 {
+			t.Errorf("Expected no event for a rolled back change, got %d events", len(events.events))
+		}
+	})
+}
+
+type fakeRegistry map[string]models.Symbol
+
+func (r fakeRegistry) Resolve(symbol string) (models.Symbol, error) {
+	if len(r) == 0 {
+		return models.Symbol{}, errs.ErrSymbolsUnavailable
+	}
+	info, ok := r[models.NormalizeSymbol(symbol)]
+	if !ok || info.Status != models.SymbolStatusTrading {
+		return models.Symbol{}, errs.ErrInvalidSymbol
+	}
+	return info, nil
+}
+
+func (r fakeRegistry) PricingSymbol(asset string) (models.Symbol, error) {
+	for _, quote := range []string{"usdt", "btc"} {
+		if info, err := r.Resolve(asset + quote); err == nil {
+			return info, nil
+		}
+	}
+	return models.Symbol{}, errs.ErrInvalidSymbol
+}
+
+func TestSymbolValidation(t *testing.T) {
+	_, db, userID := setupCoinsService(t)
+	registry := fakeRegistry{
+		"btcusdt":  {Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Status: models.SymbolStatusTrading},
+		"lunausdt": {Symbol: "LUNAUSDT", BaseAsset: "LUNA", QuoteAsset: "USDT", Status: "BREAK"},
+	}
+	svc := service.NewCoinsService(repository.NewCoinsRepository(db), repository.NewTransactionsRepository(db), db, nil, registry)
+	ctx := context.Background()
+
+	buy := func(symbol string) (*models.Coin, error) {
+		return svc.RecordTransaction(ctx, userID, &models.Transaction{
+			Symbol:   symbol,
+			Side:     models.SideBuy,
+			Quantity: decimal.NewFromInt(1),
+		})
+	}
+
+	t.Run("symbol_is_normalized", func(t *testing.T) {
+		coin, err := buy("BTC/USDT")
+		if err != nil {
+			t.Fatalf("RecordTransaction failed: %v", err)
+		}
+		if coin.Asset != "btc" || coin.Symbol != "btcusdt" {
+			t.Errorf("Expected btc valued with btcusdt, got %s with %s", coin.Asset, coin.Symbol)
+		}
+	})
+
+	t.Run("unknown_symbol_is_rejected", func(t *testing.T) {
+		_, err := buy("BTCUSD")
+		if !errors.Is(err, errs.ErrInvalidTransaction) || !errors.Is(err, errs.ErrInvalidSymbol) {
+			t.Errorf("Expected ErrInvalidTransaction, but got %v", err)
+		}
+	})
+
+	t.Run("halted_symbol_is_rejected", func(t *testing.T) {
+		if _, err := buy("lunausdt"); !errors.Is(err, errs.ErrInvalidTransaction) {
+			t.Errorf("Expected ErrInvalidTransaction, but got %v", err)
+		}
+	})
+
+	t.Run("empty_registry_is_unavailable", func(t *testing.T) {
+		svc := service.NewCoinsService(repository.NewCoinsRepository(db), repository.NewTransactionsRepository(db), db, nil, fakeRegistry{})
+		_, err := svc.RecordTransaction(ctx, userID, &models.Transaction{Symbol: "btcusdt", Side: models.SideBuy, Quantity: decimal.NewFromInt(1)})
+		if !errors.Is(err, errs.ErrSymbolsUnavailable) || errors.Is(err, errs.ErrInvalidTransaction) {
+			t.Errorf("Expected ErrSymbolsUnavailable, but got %v", err)
+		}
+	})
+}
+
+func TestAssetHoldings(t *testing.T) {
+	_, db, userID := setupCoinsService(t)
+	registry := fakeRegistry{
+		"btcusdt": {Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT", Status: models.SymbolStatusTrading},
+		"ethbtc":  {Symbol: "ETHBTC", BaseAsset: "ETH", QuoteAsset: "BTC", Status: models.SymbolStatusTrading},
+		"ethusdt": {Symbol: "ETHUSDT", BaseAsset: "ETH", QuoteAsset: "USDT", Status: models.SymbolStatusTrading},
+		"solbtc":  {Symbol: "SOLBTC", BaseAsset: "SOL", QuoteAsset: "BTC", Status: models.SymbolStatusTrading},
+	}
+	svc := service.NewCoinsService(repository.NewCoinsRepository(db), repository.NewTransactionsRepository(db), db, nil, registry)
+	ctx := context.Background()
+
+	buy := func(asset, symbol string) (*models.Coin, error) {
+		return svc.RecordTransaction(ctx, userID, &models.Transaction{
+			Asset:    asset,
+			Symbol:   symbol,
+			Side:     models.SideBuy,
+			Quantity: decimal.NewFromInt(1),
+		})
+	}
+
+	t.Run("asset_picks_preferred_pair", func(t *testing.T) {
+		coin, err := buy("ETH", "")
+		if err != nil {
+			t.Fatalf("RecordTransaction failed: %v", err)
+		}
+		if coin.Asset != "eth" || coin.Symbol != "ethusdt" {
+			t.Errorf("Expected eth valued with ethusdt, got %s with %s", coin.Asset, coin.Symbol)
+		}
+
+		coin, err = buy("sol", "")
+		if err != nil {
+			t.Fatalf("RecordTransaction failed: %v", err)
+		}
+		if coin.Symbol != "solbtc" {
+			t.Errorf("Expected sol valued with solbtc, got %s", coin.Symbol)
+		}
+	})
+
+	t.Run("pair_adds_to_asset", func(t *testing.T) {
+		coin, err := buy("", "ETHUSDT")
+		if err != nil {
+			t.Fatalf("RecordTransaction failed: %v", err)
+		}
+		if coin.Asset != "eth" || !coin.Quantity.Equal(decimal.NewFromInt(2)) {
+			t.Errorf("Expected 2 eth, got %s %s", coin.Quantity, coin.Asset)
+		}
+	})
+
+	t.Run("other_pair_of_held_asset_is_rejected", func(t *testing.T) {
+		if _, err := buy("eth", "ethbtc"); !errors.Is(err, errs.ErrInvalidTransaction) {
+			t.Errorf("Expected ErrInvalidTransaction, but got %v", err)
+		}
+	})
+
+	t.Run("pair_of_other_asset_is_rejected", func(t *testing.T) {
+		if _, err := buy("btc", "ethusdt"); !errors.Is(err, errs.ErrInvalidTransaction) {
+			t.Errorf("Expected ErrInvalidTransaction, but got %v", err)
+		}
+	})
+
+	t.Run("pair_deletes_its_asset", func(t *testing.T) {
+		if err := svc.DeleteCoin(ctx, userID, 0, "ETHUSDT"); err != nil {
+			t.Fatalf("DeleteCoin failed: %v", err)
+		}
+		txs, err := svc.ListTransactions(ctx, userID, 0, "eth", false)
+		if err != nil || len(txs) != 0 {
+			t.Errorf("Expected no open eth transactions, got %d, %v", len(txs), err)
+		}
+	})
+}
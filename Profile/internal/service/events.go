@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/google/uuid"
+)
+
+// EventPublisher announces committed profile changes. Delivery is best
+// effort: a lost event only delays a websocket refresh until reconnect, so
+// implementations log failures instead of returning them.
+type EventPublisher interface {
+	PublishProfileEvent(ctx context.Context, event models.ProfileEvent)
+}
+
+// publishEvent is a no-op when the service was built without a publisher.
+func publishEvent(ctx context.Context, events EventPublisher, userID uuid.UUID, kind models.ProfileEventKind) {
+	if events == nil {
+		return
+	}
+
+	events.PublishProfileEvent(ctx, models.ProfileEvent{
+		Kind:   kind,
+		UserID: userID,
+		At:     time.Now().UTC(),
+	})
+}
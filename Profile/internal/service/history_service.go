@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/google/uuid"
+)
+
+type HistoryService interface {
+	GetHistory(ctx context.Context, userID uuid.UUID, rangeName string) ([]models.HistoryPoint, error)
+}
+
+type historyRange struct {
+	span time.Duration
+	step time.Duration
+}
+
+// historyRanges maps the supported ranges to their length and the spacing of
+// the returned points. A zero span means the whole history.
+var historyRanges = map[string]historyRange{
+	"24h": {span: 24 * time.Hour, step: time.Minute},
+	"7d":  {span: 7 * 24 * time.Hour, step: time.Hour},
+	"30d": {span: 30 * 24 * time.Hour, step: time.Hour},
+	"all": {span: 0, step: 24 * time.Hour},
+}
+
+type historyService struct {
+	repo repository.SnapshotsRepository
+}
+
+func NewHistoryService(repo repository.SnapshotsRepository) HistoryService {
+	return &historyService{repo: repo}
+}
+
+// GetHistory returns the equity curve of the user over the range. Snapshots
+// of every resolution are merged and the last one of each step is kept, so
+// the recent, not yet compacted part of the range has the same spacing as
+// the rest.
+func (s *historyService) GetHistory(_ context.Context, userID uuid.UUID, rangeName string) ([]models.HistoryPoint, error) {
+	r, ok := historyRanges[rangeName]
+	if !ok {
+		return nil, fmt.Errorf("%w: range must be one of 24h, 7d, 30d, all", errs.ErrInvalidRange)
+	}
+
+	var since time.Time
+	if r.span > 0 {
+		since = time.Now().UTC().Add(-r.span)
+	}
+
+	snapshots, err := s.repo.ListSnapshots(userID, since)
+	if err != nil {
+		return nil, err
+	}
+
+	points := make([]models.HistoryPoint, 0, len(snapshots))
+	for i, snapshot := range snapshots {
+		if i+1 < len(snapshots) && snapshots[i+1].TakenAt.Truncate(r.step).Equal(snapshot.TakenAt.Truncate(r.step)) {
+			continue
+		}
+
+		coins := make([]models.HistoryCoin, 0, len(snapshot.Coins))
+		for _, coin := range snapshot.Coins {
+			coins = append(coins, models.HistoryCoin{
+				Asset:    coin.Asset,
+				Symbol:   coin.Symbol,
+				Quantity: coin.Quantity,
+				Price:    coin.Price,
+				Value:    coin.Value,
+			})
+		}
+
+		points = append(points, models.HistoryPoint{
+			Time:       snapshot.TakenAt,
+			TotalValue: snapshot.TotalValue,
+			Coins:      coins,
+		})
+	}
+
+	return points, nil
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/shopspring/decimal"
+)
+
+type position struct {
+	Quantity    decimal.Decimal
+	CostBasis   decimal.Decimal
+	RealizedPnL decimal.Decimal
+}
+
+type lot struct {
+	quantity decimal.Decimal
+	unitCost decimal.Decimal
+}
+
+// replayLedger folds transactions, already sorted by execution time, into the
+// resulting position. Buys open a lot whose cost includes the fee; sells
+// release cost according to the lot matching method and book the difference
+// to the sale proceeds, net of the sell fee, as realized P&L. A sell larger
+// than the holding at that point of the history fails with
+// ErrInsufficientFunds.
+func replayLedger(txs []models.Transaction, method models.CostMethod) (position, error) {
+	pos := position{
+		Quantity:    decimal.Zero,
+		CostBasis:   decimal.Zero,
+		RealizedPnL: decimal.Zero,
+	}
+	var lots []lot
+
+	for _, tx := range txs {
+		switch tx.Side {
+		case models.SideBuy:
+			cost := tx.Quantity.Mul(tx.Price).Add(tx.Fee)
+
+			pos.Quantity = pos.Quantity.Add(tx.Quantity)
+			pos.CostBasis = pos.CostBasis.Add(cost)
+			lots = append(lots, lot{quantity: tx.Quantity, unitCost: cost.Div(tx.Quantity)})
+		case models.SideSell:
+			if tx.Quantity.GreaterThan(pos.Quantity) {
+				return position{}, errs.ErrInsufficientFunds
+			}
+
+			var released decimal.Decimal
+			switch method {
+			case models.CostMethodFIFO:
+				lots, released = consumeLots(lots, tx.Quantity, false)
+			case models.CostMethodLIFO:
+				lots, released = consumeLots(lots, tx.Quantity, true)
+			default:
+				released = pos.CostBasis.Mul(tx.Quantity).Div(pos.Quantity)
+			}
+
+			proceeds := tx.Quantity.Mul(tx.Price).Sub(tx.Fee)
+			pos.RealizedPnL = pos.RealizedPnL.Add(proceeds.Sub(released))
+			pos.Quantity = pos.Quantity.Sub(tx.Quantity)
+			pos.CostBasis = pos.CostBasis.Sub(released)
+
+			if pos.Quantity.IsZero() {
+				pos.CostBasis = decimal.Zero
+				lots = nil
+			}
+		default:
+			return position{}, errs.ErrInvalidTransaction
+		}
+	}
+
+	return pos, nil
+}
+
+// consumeLots removes quantity from the oldest lots, or from the newest ones
+// when fromNewest is set, and returns the remaining lots with the released
+// cost.
+func consumeLots(lots []lot, quantity decimal.Decimal, fromNewest bool) ([]lot, decimal.Decimal) {
+	released := decimal.Zero
+
+	for quantity.IsPositive() && len(lots) > 0 {
+		idx := 0
+		if fromNewest {
+			idx = len(lots) - 1
+		}
+
+		taken := decimal.Min(lots[idx].quantity, quantity)
+		released = released.Add(taken.Mul(lots[idx].unitCost))
+		quantity = quantity.Sub(taken)
+		lots[idx].quantity = lots[idx].quantity.Sub(taken)
+
+		if lots[idx].quantity.IsZero() {
+			if fromNewest {
+				lots = lots[:idx]
+			} else {
+				lots = lots[1:]
+			}
+		}
+	}
+
+	return lots, released
+}
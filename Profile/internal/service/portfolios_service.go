@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type PortfoliosService interface {
+	CreatePortfolio(ctx context.Context, userID uuid.UUID, name string) (*models.Portfolio, error)
+	GetPortfolio(ctx context.Context, userID uuid.UUID, id uint) (*models.Portfolio, error)
+	ListPortfolios(ctx context.Context, userID uuid.UUID) ([]models.Portfolio, error)
+	RenamePortfolio(ctx context.Context, userID uuid.UUID, id uint, name string) error
+	DeletePortfolio(ctx context.Context, userID uuid.UUID, id uint) error
+}
+
+type portfoliosService struct {
+	repo   repository.PortfoliosRepository
+	db     *gorm.DB
+	events EventPublisher
+}
+
+func NewPortfoliosService(repo repository.PortfoliosRepository, db *gorm.DB, events EventPublisher) PortfoliosService {
+	return &portfoliosService{
+		repo:   repo,
+		db:     db,
+		events: events,
+	}
+}
+
+func (s *portfoliosService) CreatePortfolio(ctx context.Context, userID uuid.UUID, name string) (*models.Portfolio, error) {
+	name, err := validatePortfolioName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio := models.Portfolio{
+		UserID: userID,
+		Name:   name,
+	}
+
+	if err := s.repo.CreatePortfolio(&portfolio); err != nil {
+		return nil, err
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventPortfolios)
+	return &portfolio, nil
+}
+
+func (s *portfoliosService) GetPortfolio(_ context.Context, userID uuid.UUID, id uint) (*models.Portfolio, error) {
+	return s.repo.GetPortfolio(userID, id)
+}
+
+func (s *portfoliosService) ListPortfolios(_ context.Context, userID uuid.UUID) ([]models.Portfolio, error) {
+	return s.repo.ListPortfolios(userID)
+}
+
+func (s *portfoliosService) RenamePortfolio(ctx context.Context, userID uuid.UUID, id uint, name string) error {
+	name, err := validatePortfolioName(name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.RenamePortfolio(userID, id, name); err != nil {
+		return err
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventPortfolios)
+	return nil
+}
+
+// DeletePortfolio removes an empty, non-default portfolio and the alerts on
+// its value. Portfolios that still have open transactions must be emptied
+// first so that no holding is dropped silently.
+func (s *portfoliosService) DeletePortfolio(ctx context.Context, userID uuid.UUID, id uint) error {
+	err := s.db.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+		portfoliosRepo := repository.NewPortfoliosRepository(dbTx)
+
+		portfolio, err := portfoliosRepo.GetPortfolio(userID, id)
+		if err != nil {
+			return err
+		}
+		if portfolio.IsDefault {
+			return fmt.Errorf("%w: the default portfolio cannot be deleted", errs.ErrInvalidPortfolio)
+		}
+
+		// A transaction recorded concurrently would survive as an orphan.
+		if err := portfoliosRepo.LockPortfolio(userID, id); err != nil {
+			return err
+		}
+
+		txs, err := repository.NewTransactionsRepository(dbTx).ListTransactions(userID, id, "", false)
+		if err != nil {
+			return err
+		}
+		if len(txs) > 0 {
+			return errs.ErrPortfolioNotEmpty
+		}
+
+		if err := repository.NewAlertsRepository(dbTx).DeletePortfolioAlerts(userID, id); err != nil {
+			return err
+		}
+
+		return portfoliosRepo.DeletePortfolio(userID, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventPortfolios)
+	return nil
+}
+
+func validatePortfolioName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", errs.ErrInvalidPortfolio)
+	}
+	return name, nil
+}
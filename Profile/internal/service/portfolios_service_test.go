@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/service"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/shopspring/decimal"
+)
+
+func TestPortfolios(t *testing.T) {
+	coinsSvc, db, userID := setupCoinsService(t)
+	if err := db.AutoMigrate(&models.Alert{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	svc := service.NewPortfoliosService(repository.NewPortfoliosRepository(db), db, nil)
+	ctx := context.Background()
+
+	trading, err := svc.CreatePortfolio(ctx, userID, "trading")
+	if err != nil {
+		t.Fatalf("CreatePortfolio failed: %v", err)
+	}
+
+	t.Run("duplicate_name_is_rejected", func(t *testing.T) {
+		_, err := svc.CreatePortfolio(ctx, userID, "trading")
+		if !errors.Is(err, errs.ErrAlreadyExists) {
+			t.Errorf("Expected ErrAlreadyExists, but got %v", err)
+		}
+	})
+
+	t.Run("holdings_are_kept_per_portfolio", func(t *testing.T) {
+		if _, err := coinsSvc.RecordTransaction(ctx, userID, &models.Transaction{
+			Symbol:   "btcusdt",
+			Side:     models.SideBuy,
+			Quantity: decimal.NewFromInt(1),
+		}); err != nil {
+			t.Fatalf("RecordTransaction failed: %v", err)
+		}
+		coin, err := coinsSvc.RecordTransaction(ctx, userID, &models.Transaction{
+			PortfolioID: trading.ID,
+			Symbol:      "btcusdt",
+			Side:        models.SideBuy,
+			Quantity:    decimal.NewFromInt(2),
+		})
+		if err != nil {
+			t.Fatalf("RecordTransaction failed: %v", err)
+		}
+
+		if coin.PortfolioID != trading.ID || !coin.Quantity.Equal(decimal.NewFromInt(2)) {
+			t.Errorf("Expected 2 btcusdt in portfolio %d, got %s in portfolio %d", trading.ID, coin.Quantity, coin.PortfolioID)
+		}
+	})
+
+	t.Run("non_empty_portfolio_cannot_be_deleted", func(t *testing.T) {
+		err := svc.DeletePortfolio(ctx, userID, trading.ID)
+		if !errors.Is(err, errs.ErrPortfolioNotEmpty) {
+			t.Errorf("Expected ErrPortfolioNotEmpty, but got %v", err)
+		}
+	})
+
+	t.Run("default_portfolio_cannot_be_deleted", func(t *testing.T) {
+		portfolio, err := repository.NewPortfoliosRepository(db).GetDefaultPortfolio(userID)
+		if err != nil {
+			t.Fatalf("GetDefaultPortfolio failed: %v", err)
+		}
+
+		err = svc.DeletePortfolio(ctx, userID, portfolio.ID)
+		if !errors.Is(err, errs.ErrInvalidPortfolio) {
+			t.Errorf("Expected ErrInvalidPortfolio, but got %v", err)
+		}
+	})
+
+	t.Run("emptied_portfolio_can_be_deleted", func(t *testing.T) {
+		alertsRepo := repository.NewAlertsRepository(db)
+		if err := alertsRepo.CreateAlert(&models.Alert{
+			UserID:      userID,
+			Kind:        models.AlertPortfolioBelow,
+			PortfolioID: trading.ID,
+			Threshold:   decimal.NewFromInt(1000),
+			Active:      true,
+		}); err != nil {
+			t.Fatalf("CreateAlert failed: %v", err)
+		}
+
+		if err := coinsSvc.DeleteCoin(ctx, userID, trading.ID, "btcusdt"); err != nil {
+			t.Fatalf("DeleteCoin failed: %v", err)
+		}
+		if err := svc.DeletePortfolio(ctx, userID, trading.ID); err != nil {
+			t.Errorf("Expected portfolio to be deleted, but got %v", err)
+		}
+
+		alerts, err := alertsRepo.ListAlerts(userID)
+		if err != nil || len(alerts) != 0 {
+			t.Errorf("Expected the alerts of the portfolio to be deleted, got %d (err: %v)", len(alerts), err)
+		}
+	})
+}
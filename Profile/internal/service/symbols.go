@@ -0,0 +1,95 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+)
+
+// SymbolRegistry resolves the symbols users type to listed ones. Resolve
+// fails with errs.ErrInvalidSymbol for unknown or halted symbols and with
+// errs.ErrSymbolsUnavailable while the registry cannot be reached.
+// PricingSymbol picks the pair an asset is valued with, following the quote
+// preference, and fails the same way.
+type SymbolRegistry interface {
+	Resolve(symbol string) (models.Symbol, error)
+	PricingSymbol(asset string) (models.Symbol, error)
+}
+
+// fallbackQuote is the quote assets are valued in when there is no registry.
+const fallbackQuote = "usdt"
+
+// resolveSymbol normalizes symbol and checks it against the registry. An
+// invalid symbol is reported as invalid too, so handlers map it like the
+// other validation errors of the request. Without a registry symbols are
+// only normalized.
+func resolveSymbol(registry SymbolRegistry, symbol string, invalid error) (string, error) {
+	if registry == nil {
+		return models.NormalizeSymbol(symbol), nil
+	}
+
+	info, err := registry.Resolve(symbol)
+	if err != nil {
+		return "", invalidSymbol(err, invalid)
+	}
+	return models.NormalizeSymbol(info.Symbol), nil
+}
+
+// resolvePair checks a trading pair like resolveSymbol does and also returns
+// its base asset. Without a registry only USDT pairs can be split.
+func resolvePair(registry SymbolRegistry, symbol string, invalid error) (string, string, error) {
+	if registry == nil {
+		symbol = models.NormalizeSymbol(symbol)
+		base, _, ok := models.SplitSymbol(symbol, []string{fallbackQuote})
+		if !ok {
+			return "", "", fmt.Errorf("%w: cannot tell the asset of %q", invalid, symbol)
+		}
+		return symbol, base, nil
+	}
+
+	info, err := registry.Resolve(symbol)
+	if err != nil {
+		return "", "", invalidSymbol(err, invalid)
+	}
+	return models.NormalizeSymbol(info.Symbol), models.NormalizeSymbol(info.BaseAsset), nil
+}
+
+// pricingSymbol returns the pair asset is valued with.
+func pricingSymbol(registry SymbolRegistry, asset string, invalid error) (string, error) {
+	if registry == nil {
+		return asset + fallbackQuote, nil
+	}
+
+	info, err := registry.PricingSymbol(asset)
+	if err != nil {
+		return "", invalidSymbol(err, invalid)
+	}
+	return models.NormalizeSymbol(info.Symbol), nil
+}
+
+// assetOf returns the asset name stands for: the base asset when name is a
+// listed pair, name itself otherwise. It lets clients that still address
+// holdings by pair keep working.
+func assetOf(registry SymbolRegistry, name string) string {
+	name = models.NormalizeSymbol(name)
+	if registry == nil {
+		if base, _, ok := models.SplitSymbol(name, []string{fallbackQuote}); ok {
+			return base
+		}
+		return name
+	}
+
+	if info, err := registry.Resolve(name); err == nil {
+		return models.NormalizeSymbol(info.BaseAsset)
+	}
+	return name
+}
+
+func invalidSymbol(err, invalid error) error {
+	if errors.Is(err, errs.ErrInvalidSymbol) {
+		return fmt.Errorf("%w: %w", invalid, err)
+	}
+	return err
+}
@@ -27,8 +27,12 @@ func NewUsersService(repo repository.UsersRepository) UsersService {
 
 func (s *usersService) CreateUserProfile(_ context.Context, userID uuid.UUID, name string) (*models.User, error) {
 	user := models.User{
-		ID: userID,
-		Name: name,
+		ID:         userID,
+		Name:       name,
+		CostMethod: models.CostMethodAverage,
+		Portfolios: []models.Portfolio{
+			{Name: models.DefaultPortfolioName, IsDefault: true},
+		},
 	}
 
 	if err := s.repo.CreateUserProfile(&user); err != nil {
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+var symbolPattern = regexp.MustCompile(`^[a-z0-9]{2,20}$`)
+
+type WatchlistsService interface {
+	CreateWatchlist(ctx context.Context, userID uuid.UUID, name string, symbols []string) (*models.Watchlist, error)
+	GetWatchlist(ctx context.Context, userID uuid.UUID, id uint) (*models.Watchlist, error)
+	ListWatchlists(ctx context.Context, userID uuid.UUID) ([]models.Watchlist, error)
+	UpdateWatchlist(ctx context.Context, userID uuid.UUID, id uint, patch WatchlistPatch) (*models.Watchlist, error)
+	DeleteWatchlist(ctx context.Context, userID uuid.UUID, id uint) error
+}
+
+// WatchlistPatch holds the fields of a watchlist update; nil fields are kept.
+// Symbols replaces the whole list.
+type WatchlistPatch struct {
+	Name    *string
+	Symbols *[]string
+}
+
+type watchlistsService struct {
+	repo    repository.WatchlistsRepository
+	db      *gorm.DB
+	events  EventPublisher
+	symbols SymbolRegistry
+}
+
+func NewWatchlistsService(repo repository.WatchlistsRepository, db *gorm.DB, events EventPublisher, symbols SymbolRegistry) WatchlistsService {
+	return &watchlistsService{
+		repo:    repo,
+		db:      db,
+		events:  events,
+		symbols: symbols,
+	}
+}
+
+func (s *watchlistsService) CreateWatchlist(ctx context.Context, userID uuid.UUID, name string, symbols []string) (*models.Watchlist, error) {
+	name, err := validateWatchlistName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err = normalizeWatchlistSymbols(s.symbols, symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	watchlist := models.Watchlist{
+		UserID: userID,
+		Name:   name,
+	}
+	for _, symbol := range symbols {
+		watchlist.Items = append(watchlist.Items, models.WatchlistItem{Symbol: symbol})
+	}
+
+	if err := s.repo.CreateWatchlist(&watchlist); err != nil {
+		return nil, err
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventWatchlists)
+	return &watchlist, nil
+}
+
+func (s *watchlistsService) GetWatchlist(_ context.Context, userID uuid.UUID, id uint) (*models.Watchlist, error) {
+	return s.repo.GetWatchlist(userID, id)
+}
+
+func (s *watchlistsService) ListWatchlists(_ context.Context, userID uuid.UUID) ([]models.Watchlist, error) {
+	return s.repo.ListWatchlists(userID)
+}
+
+func (s *watchlistsService) UpdateWatchlist(ctx context.Context, userID uuid.UUID, id uint, patch WatchlistPatch) (*models.Watchlist, error) {
+	var updated *models.Watchlist
+
+	err := s.db.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+		watchlistsRepo := repository.NewWatchlistsRepository(dbTx)
+
+		if _, err := watchlistsRepo.GetWatchlist(userID, id); err != nil {
+			return err
+		}
+
+		if patch.Name != nil {
+			name, err := validateWatchlistName(*patch.Name)
+			if err != nil {
+				return err
+			}
+			if err := watchlistsRepo.RenameWatchlist(userID, id, name); err != nil {
+				return err
+			}
+		}
+
+		if patch.Symbols != nil {
+			symbols, err := normalizeWatchlistSymbols(s.symbols, *patch.Symbols)
+			if err != nil {
+				return err
+			}
+			if err := watchlistsRepo.ReplaceSymbols(id, symbols); err != nil {
+				return fmt.Errorf("failed to replace watchlist symbols: %w", err)
+			}
+		}
+
+		watchlist, err := watchlistsRepo.GetWatchlist(userID, id)
+		if err != nil {
+			return err
+		}
+		updated = watchlist
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventWatchlists)
+	return updated, nil
+}
+
+func (s *watchlistsService) DeleteWatchlist(ctx context.Context, userID uuid.UUID, id uint) error {
+	err := s.db.WithContext(ctx).Transaction(func(dbTx *gorm.DB) error {
+		watchlistsRepo := repository.NewWatchlistsRepository(dbTx)
+
+		if _, err := watchlistsRepo.GetWatchlist(userID, id); err != nil {
+			return err
+		}
+		if err := watchlistsRepo.ReplaceSymbols(id, nil); err != nil {
+			return err
+		}
+
+		return watchlistsRepo.DeleteWatchlist(userID, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	publishEvent(ctx, s.events, userID, models.ProfileEventWatchlists)
+	return nil
+}
+
+func validateWatchlistName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", fmt.Errorf("%w: name is required", errs.ErrInvalidWatchlist)
+	}
+	return name, nil
+}
+
+// normalizeWatchlistSymbols lowercases symbols as the Aggregator publishes
+// them, checks them against the registry and drops duplicates, keeping the
+// order the user gave.
+func normalizeWatchlistSymbols(registry SymbolRegistry, symbols []string) ([]string, error) {
+	seen := make(map[string]struct{}, len(symbols))
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToLower(strings.TrimSpace(symbol))
+		if !symbolPattern.MatchString(symbol) {
+			return nil, fmt.Errorf("%w: invalid symbol %q", errs.ErrInvalidWatchlist, symbol)
+		}
+		symbol, err := resolveSymbol(registry, symbol, errs.ErrInvalidWatchlist)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[symbol]; ok {
+			continue
+		}
+		seen[symbol] = struct{}{}
+		normalized = append(normalized, symbol)
+	}
+
+	if len(normalized) > models.MaxWatchlistSymbols {
+		return nil, fmt.Errorf("%w: at most %d symbols per watchlist", errs.ErrInvalidWatchlist, models.MaxWatchlistSymbols)
+	}
+	return normalized, nil
+}
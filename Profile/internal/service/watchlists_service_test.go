@@ -0,0 +1,59 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/repository"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/service"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+)
+
+func TestWatchlists(t *testing.T) {
+	_, db, userID := setupCoinsService(t)
+	if err := db.AutoMigrate(&models.Watchlist{}, &models.WatchlistItem{}); err != nil {
+		t.Fatalf("failed to migrate database: %v", err)
+	}
+	svc := service.NewWatchlistsService(repository.NewWatchlistsRepository(db), db, nil, nil)
+	ctx := context.Background()
+
+	watchlist, err := svc.CreateWatchlist(ctx, userID, " alts ", []string{"SOLUSDT", "dogeusdt", "solusdt"})
+	if err != nil {
+		t.Fatalf("CreateWatchlist failed: %v", err)
+	}
+
+	t.Run("symbols_are_normalized", func(t *testing.T) {
+		if watchlist.Name != "alts" || len(watchlist.Items) != 2 || watchlist.Items[0].Symbol != "solusdt" {
+			t.Errorf("Expected 'alts' with solusdt and dogeusdt, got %q with %+v", watchlist.Name, watchlist.Items)
+		}
+	})
+
+	t.Run("invalid_symbol_is_rejected", func(t *testing.T) {
+		_, err := svc.CreateWatchlist(ctx, userID, "bad", []string{"btc/usdt"})
+		if !errors.Is(err, errs.ErrInvalidWatchlist) {
+			t.Errorf("Expected ErrInvalidWatchlist, but got %v", err)
+		}
+	})
+
+	t.Run("update_replaces_symbols", func(t *testing.T) {
+		symbols := []string{"adausdt"}
+		updated, err := svc.UpdateWatchlist(ctx, userID, watchlist.ID, service.WatchlistPatch{Symbols: &symbols})
+		if err != nil {
+			t.Fatalf("UpdateWatchlist failed: %v", err)
+		}
+		if len(updated.Items) != 1 || updated.Items[0].Symbol != "adausdt" {
+			t.Errorf("Expected only adausdt, got %+v", updated.Items)
+		}
+	})
+
+	t.Run("delete_removes_watchlist", func(t *testing.T) {
+		if err := svc.DeleteWatchlist(ctx, userID, watchlist.ID); err != nil {
+			t.Fatalf("DeleteWatchlist failed: %v", err)
+		}
+		if _, err := svc.GetWatchlist(ctx, userID, watchlist.ID); !errors.Is(err, errs.ErrNotFound) {
+			t.Errorf("Expected ErrNotFound after delete, but got %v", err)
+		}
+	})
+}
@@ -0,0 +1,149 @@
+// Package symbols keeps a copy of the symbol registry of the Socket
+// service, so symbols can be validated without a call per request.
+package symbols
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+)
+
+const maxRetryDelay = time.Minute
+
+type Registry struct {
+	log     *slog.Logger
+	client  socket.SocketServiceClient
+	refresh time.Duration
+	quotes  []string
+
+	mu      sync.RWMutex
+	symbols map[string]models.Symbol
+}
+
+// NewRegistry creates a registry that values assets in the first of quotes
+// they have a trading pair with.
+func NewRegistry(log *slog.Logger, client socket.SocketServiceClient, refresh time.Duration, quotes []string) *Registry {
+	return &Registry{
+		log:     log,
+		client:  client,
+		refresh: refresh,
+		quotes:  quotes,
+		symbols: make(map[string]models.Symbol),
+	}
+}
+
+// Run loads the registry and reloads it every refresh interval. A failed
+// load keeps the symbols already known and is retried with backoff.
+func (r *Registry) Run(ctx context.Context) {
+	delay := time.Second
+	for {
+		wait := r.refresh
+		if err := r.load(ctx); err != nil {
+			r.log.Warn("symbols: could not load registry", "error", err, "retry_in", delay)
+			wait = delay
+			delay = min(delay*2, maxRetryDelay)
+		} else {
+			delay = time.Second
+		}
+
+		select {
+		case <-ctx.Done():
+			r.log.Info("symbol registry stopping...")
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (r *Registry) load(ctx context.Context) error {
+	resp, err := r.client.ListSymbols(ctx, &socket.ListSymbolsRequest{})
+	if err != nil {
+		return err
+	}
+
+	symbols := make(map[string]models.Symbol, len(resp.GetSymbols()))
+	for _, info := range resp.GetSymbols() {
+		symbol := symbolFromProto(info)
+		symbols[models.NormalizeSymbol(symbol.Symbol)] = symbol
+	}
+
+	r.mu.Lock()
+	r.symbols = symbols
+	r.mu.Unlock()
+
+	r.log.Info("symbols: registry loaded", "symbols", len(symbols))
+	return nil
+}
+
+// Resolve implements service.SymbolRegistry.
+func (r *Registry) Resolve(symbol string) (models.Symbol, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.symbols) == 0 {
+		return models.Symbol{}, errs.ErrSymbolsUnavailable
+	}
+
+	info, ok := r.symbols[models.NormalizeSymbol(symbol)]
+	if !ok {
+		return models.Symbol{}, fmt.Errorf("%w: unknown symbol %q", errs.ErrInvalidSymbol, symbol)
+	}
+	if info.Status != models.SymbolStatusTrading {
+		return models.Symbol{}, fmt.Errorf("%w: %s is %s", errs.ErrInvalidSymbol, info.Symbol, info.Status)
+	}
+	return info, nil
+}
+
+// PricingSymbol implements service.SymbolRegistry.
+func (r *Registry) PricingSymbol(asset string) (models.Symbol, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.symbols) == 0 {
+		return models.Symbol{}, errs.ErrSymbolsUnavailable
+	}
+
+	asset = models.NormalizeSymbol(asset)
+	for _, quote := range r.quotes {
+		if info, ok := r.symbols[asset+quote]; ok && info.Status == models.SymbolStatusTrading {
+			return info, nil
+		}
+	}
+	return models.Symbol{}, fmt.Errorf("%w: no trading pair of %s in %s", errs.ErrInvalidSymbol, asset, strings.Join(r.quotes, ", "))
+}
+
+// Search asks the Socket service for the symbols matching query, best
+// matches first.
+func (r *Registry) Search(ctx context.Context, query string, limit int) ([]models.Symbol, error) {
+	resp, err := r.client.ListSymbols(ctx, &socket.ListSymbolsRequest{Search: query, Limit: int32(limit)})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errs.ErrSymbolsUnavailable, err)
+	}
+
+	symbols := make([]models.Symbol, 0, len(resp.GetSymbols()))
+	for _, info := range resp.GetSymbols() {
+		symbols = append(symbols, symbolFromProto(info))
+	}
+	return symbols, nil
+}
+
+func symbolFromProto(s *socket.SymbolInfo) models.Symbol {
+	return models.Symbol{
+		Symbol:      s.GetSymbol(),
+		BaseAsset:   s.GetBaseAsset(),
+		QuoteAsset:  s.GetQuoteAsset(),
+		Status:      s.GetStatus(),
+		TickSize:    s.GetTickSize(),
+		StepSize:    s.GetStepSize(),
+		MinQty:      s.GetMinQty(),
+		MaxQty:      s.GetMaxQty(),
+		MinNotional: s.GetMinNotional(),
+	}
+}
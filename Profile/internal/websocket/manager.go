@@ -3,10 +3,7 @@ package websocket
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log/slog"
-	"net/http"
-	"net/url"
 	"sync"
 	"time"
 
@@ -18,14 +15,34 @@ import (
 	"github.com/shopspring/decimal"
 )
 
+// Client streams the view of one portfolio, or of all portfolios of the user
+// combined when PortfolioID is zero. Watch holds the symbols subscribed over
+// the connection on top of the holdings and the watchlists of the profile,
+// and Quote the asset the views are valued in.
 type Client struct {
-	Manager *Manager
-	Conn    *websocket.Conn
-	UserID  uuid.UUID
-	Profile *models.User
-	Send    chan []byte
-	Prices  map[string]decimal.Decimal
-	mu      sync.RWMutex
+	Manager     *Manager
+	Conn        *websocket.Conn
+	UserID      uuid.UUID
+	PortfolioID uint
+	Profile     *models.User
+	Send        chan []byte
+	Prices      map[string]decimal.Decimal
+	Opens       map[string]decimal.Decimal
+	Books       map[string]models.BookTop
+	Watch       map[string]struct{}
+	Quote       string
+	mu          sync.RWMutex
+
+	// quotePair is the pair the price of Quote is followed by, if any.
+	quotePair string
+
+	// closed is set under Manager.mu once Send has been closed.
+	closed bool
+}
+
+// PriceObserver receives every price update the manager reads from Redis.
+type PriceObserver interface {
+	ObservePrice(symbol string, price decimal.Decimal, at time.Time)
 }
 
 type Manager struct {
@@ -35,28 +52,56 @@ type Manager struct {
 	unregister      chan *Client
 	log             *slog.Logger
 	subscriber      *redis.Subscriber
+	intents         *redis.Intents
+	prices          *redis.Prices
+	symbols         service.SymbolRegistry
+	usersService    service.UsersService
 	coinsService    service.CoinsService
+	alertsService   service.AlertsService
 	activeRedisSub  map[string]struct{}
 	coinSubscribers map[string]map[uuid.UUID]bool
-	httpClient      *http.Client
+	observers       []PriceObserver
+	maxPriceAge     time.Duration
+	refresh         chan uuid.UUID
+	rates           *rateTable
 }
 
-func NewManager(log *slog.Logger, subscriber *redis.Subscriber, coinsService service.CoinsService) *Manager {
+func NewManager(log *slog.Logger, subscriber *redis.Subscriber, intents *redis.Intents, prices *redis.Prices, symbols service.SymbolRegistry, usersService service.UsersService, coinsService service.CoinsService, alertsService service.AlertsService, maxPriceAge time.Duration) *Manager {
 	return &Manager{
 		clients:         make(map[uuid.UUID]*Client),
 		register:        make(chan *Client),
 		unregister:      make(chan *Client),
 		log:             log,
 		subscriber:      subscriber,
+		intents:         intents,
+		prices:          prices,
+		symbols:         symbols,
+		usersService:    usersService,
 		coinsService:    coinsService,
+		alertsService:   alertsService,
 		activeRedisSub:  make(map[string]struct{}),
 		coinSubscribers: make(map[string]map[uuid.UUID]bool),
-		httpClient:      &http.Client{Timeout: 10 * time.Second},
+		maxPriceAge:     maxPriceAge,
+		refresh:         make(chan uuid.UUID, 256),
+		rates:           newRateTable(),
 	}
 }
 
 func (m *Manager) Run(ctx context.Context) {
+	if err := m.subscriber.Subscribe(ctx, models.ProfileEventsChannel); err != nil {
+		m.log.Error("manager: could not subscribe to profile events, websockets will not refresh", "error", err)
+	}
+	if err := m.subscriber.Subscribe(ctx, models.AlertsChannel); err != nil {
+		m.log.Error("manager: could not subscribe to alert events, alerts will not reach websockets", "error", err)
+	}
+	if err := m.subscriber.Subscribe(ctx, models.RatesChannel); err != nil {
+		m.log.Error("manager: could not subscribe to rates, holdings not quoted in USDT will not be valued", "error", err)
+	}
+
+	go m.subscriber.Run(ctx)
 	go m.listenToRedis(ctx)
+	go m.intents.Run(ctx, m.followedSymbols)
+	go m.refreshClients(ctx)
 
 	for {
 		select {
@@ -87,6 +132,52 @@ func (m *Manager) listenToRedis(ctx context.Context) {
 	}
 }
 
+// AddObserver must be called before Run.
+func (m *Manager) AddObserver(observer PriceObserver) {
+	m.observers = append(m.observers, observer)
+}
+
+// Follow keeps the price stream of symbol open on behalf of followerID, which
+// does not need to be a connected client.
+func (m *Manager) Follow(followerID uuid.UUID, symbol string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.followCoin(followerID, symbol)
+}
+
+func (m *Manager) Unfollow(followerID uuid.UUID, symbol string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.unfollowCoin(followerID, symbol)
+}
+
+// USDTRate returns the USDT price of one unit of the asset symbol is quoted
+// in, so observers can sum holdings bought through different quotes.
+func (m *Manager) USDTRate(symbol string) (decimal.Decimal, bool) {
+	return m.rates.usdt(m.rates.quoteOf(symbol))
+}
+
+// SendToUser queues payload for the websocket of the user and reports
+// whether the user is connected.
+func (m *Manager) SendToUser(userID uuid.UUID, payload []byte) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	client, ok := m.clients[userID]
+	if !ok {
+		return false
+	}
+
+	select {
+	case client.Send <- payload:
+	default:
+		m.log.Warn("client send channel is full, dropping message", "userID", userID)
+	}
+	return true
+}
+
 func (m *Manager) Register(client *Client) {
 	m.register <- client
 }
@@ -101,24 +192,41 @@ func (m *Manager) registerClient(client *Client) {
 
 	if oldClient, exists := m.clients[client.UserID]; exists {
 		m.log.Warn("client re-registering, closing old connection", "userID", client.UserID)
+		oldClient.closed = true
 		close(oldClient.Send)
 		oldClient.Conn.Close()
 	}
 
+	client.mu.Lock()
 	client.Prices = make(map[string]decimal.Decimal)
+	client.Opens = make(map[string]decimal.Decimal)
+	client.Books = make(map[string]models.BookTop)
+	client.mu.Unlock()
+
 	m.clients[client.UserID] = client
 	m.log.Info("new client registered", "userID", client.UserID)
 
-	for _, coin := range client.Profile.Coins {
+	var symbols []string
+	for _, coin := range client.coins() {
 		m.followCoin(client.UserID, coin.Symbol)
+		symbols = append(symbols, coin.Symbol)
+	}
+	for _, symbol := range client.watchlisted() {
+		m.followCoin(client.UserID, symbol)
+		symbols = append(symbols, symbol)
 	}
+
+	go m.seedPrices(client, symbols)
+	go m.replayAlerts(client)
 }
 
 func (m *Manager) unregisterClient(client *Client) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	if _, ok := m.clients[client.UserID]; ok {
+	// A reconnect replaces the client of the user; the old connection then
+	// unregisters after the new one was registered and must leave it alone.
+	if current, ok := m.clients[client.UserID]; ok && current == client {
 		delete(m.clients, client.UserID)
 		m.unfollowAllCoins(client.UserID)
 		m.log.Info("client unregistered", "userID", client.UserID)
@@ -132,10 +240,10 @@ func (m *Manager) followCoin(userID uuid.UUID, symbol string) {
 	m.coinSubscribers[symbol][userID] = true
 
 	if _, ok := m.activeRedisSub[symbol]; !ok {
-		m.log.Info("first subscriber for symbol, telling aggregator to start stream", "symbol", symbol, "userID", userID)
-		go m.notifyAggregator(userID.String(), symbol, http.MethodGet)
+		m.log.Info("first subscriber for symbol, asking aggregator to start stream", "symbol", symbol, "userID", userID)
+		m.intents.Changed()
 
-		if err := m.subscriber.Subscribe(context.Background(), symbol); err != nil {
+		if err := m.subscriber.SubscribePrices(context.Background(), symbol); err != nil {
 			m.log.Error("manager: could not subscribe to coin stream", "coin", symbol, "error", err)
 			return
 		}
@@ -144,19 +252,24 @@ func (m *Manager) followCoin(userID uuid.UUID, symbol string) {
 }
 
 func (m *Manager) unfollowAllCoins(userID uuid.UUID) {
-	for symbol, users := range m.coinSubscribers {
+	for symbol := range m.coinSubscribers {
+		m.unfollowCoin(userID, symbol)
+	}
+}
+
+func (m *Manager) unfollowCoin(userID uuid.UUID, symbol string) {
+	if users, ok := m.coinSubscribers[symbol]; ok {
 		if _, ok := users[userID]; ok {
 			delete(users, userID)
 			m.log.Info("user unfollowed coin", "userID", userID, "symbol", symbol)
 		}
 
 		if len(users) == 0 {
-			m.log.Info("no subscribers left, telling aggregator to stop stream", "symbol", symbol)
-			go m.notifyAggregator(userID.String(), symbol, http.MethodDelete)
-
+			m.log.Info("no subscribers left, asking aggregator to stop stream", "symbol", symbol)
 			delete(m.coinSubscribers, symbol)
+			m.intents.Changed()
 			delete(m.activeRedisSub, symbol)
-			if err := m.subscriber.Unsubscribe(context.Background(), symbol); err != nil {
+			if err := m.subscriber.UnsubscribePrices(context.Background(), symbol); err != nil {
 				m.log.Error("manager: failed to unsubscribe from redis", "symbol", symbol, "error", err)
 			}
 		}
@@ -164,6 +277,19 @@ func (m *Manager) unfollowAllCoins(userID uuid.UUID) {
 }
 
 func (m *Manager) processRedisMessage(msg redis.Message) {
+	if msg.Channel == models.ProfileEventsChannel {
+		m.processProfileEvent(msg)
+		return
+	}
+	if msg.Channel == models.RatesChannel {
+		m.processRates(msg)
+		return
+	}
+	if msg.Channel == models.AlertsChannel {
+		m.processAlert(msg)
+		return
+	}
+
 	var priceUpdate models.PriceUpdate
 	if err := json.Unmarshal([]byte(msg.Payload), &priceUpdate); err != nil {
 		m.log.Error("failed to parse price update from redis", "error", err, "payload", msg.Payload)
@@ -171,6 +297,21 @@ func (m *Manager) processRedisMessage(msg redis.Message) {
 	}
 
 	priceDecimal := decimal.NewFromFloat(priceUpdate.Price)
+	m.rates.setQuote(priceUpdate.Symbol, priceUpdate.Quote)
+
+	// Observers get the time of the trade, so prices replayed from the
+	// stream after a restart land where they belong. Entries older than
+	// maxPriceAge only refresh the views: alerts and snapshots must not act
+	// on a backlog.
+	tradedAt := time.Now().UTC()
+	if priceUpdate.Time > 0 {
+		tradedAt = time.UnixMilli(priceUpdate.Time).UTC()
+	}
+	if msg.AddedAt.IsZero() || m.maxPriceAge <= 0 || time.Since(msg.AddedAt) <= m.maxPriceAge {
+		for _, observer := range m.observers {
+			observer.ObservePrice(priceUpdate.Symbol, priceDecimal, tradedAt)
+		}
+	}
 
 	m.mu.RLock()
 	defer m.mu.RUnlock()
@@ -184,46 +325,341 @@ func (m *Manager) processRedisMessage(msg redis.Message) {
 		if client, ok := m.clients[userID]; ok {
 			client.mu.Lock()
 
-			client.Prices[priceUpdate.Symbol] = priceDecimal
+			client.setPrice(priceUpdate)
 
-			portfolio := models.PortfolioView{
-				UserID:     client.UserID.String(),
-				UserName:   client.Profile.Name,
-				TotalValue: decimal.Zero,
-				Coins:      []models.CoinView{},
+			if client.holds(priceUpdate.Symbol) || priceUpdate.Symbol == client.quoteSymbol() {
+				m.push(client, client.portfolioView())
 			}
-
-			for _, coin := range client.Profile.Coins {
-				currentPrice, priceFound := client.Prices[coin.Symbol]
-				if !priceFound {
-					currentPrice = decimal.Zero
-				}
-
-				total := coin.Quantity.Mul(currentPrice)
-				portfolio.Coins = append(portfolio.Coins, models.CoinView{
-					Symbol:   coin.Symbol,
-					Quantity: coin.Quantity,
-					Price:    currentPrice,
-					Total:    total,
-				})
-				portfolio.TotalValue = portfolio.TotalValue.Add(total)
+			if client.watches(priceUpdate.Symbol) {
+				m.push(client, client.priceView(priceUpdate.Symbol))
 			}
+			client.mu.Unlock()
+		}
+	}
+}
+
+// setPrice stores the price, 24h open and book top of update. The caller
+// must hold c.mu.
+func (c *Client) setPrice(update models.PriceUpdate) {
+	c.Prices[update.Symbol] = decimal.NewFromFloat(update.Price)
+	if update.Open > 0 {
+		c.Opens[update.Symbol] = decimal.NewFromFloat(update.Open)
+	}
+	if update.Bid > 0 && update.Ask > 0 {
+		c.Books[update.Symbol] = models.BookTop{
+			Bid: decimal.NewFromFloat(update.Bid),
+			Ask: decimal.NewFromFloat(update.Ask),
+		}
+	} else {
+		delete(c.Books, update.Symbol)
+	}
+}
+
+// LastPrices returns the last prices the Aggregator cached for symbols.
+func (m *Manager) LastPrices(ctx context.Context, symbols []string) ([]models.PriceUpdate, error) {
+	return m.prices.Get(ctx, symbols)
+}
+
+// seedPrices fills in the cached prices of symbols the client has no price
+// for yet, so a new connection is valued before the next tick of every
+// symbol, and pushes the views they changed.
+func (m *Manager) seedPrices(client *Client, symbols []string) {
+	if len(symbols) == 0 {
+		return
+	}
+
+	prices, err := m.prices.Get(context.Background(), symbols)
+	if err != nil {
+		m.log.Warn("ws: failed to load cached prices", "userID", client.UserID, "error", err)
+		return
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
-			jsonData, err := json.Marshal(portfolio)
-			if err != nil {
-				m.log.Error("failed to marshal portfolio view", "error", err, "userID", userID)
-				client.mu.Unlock()
-				continue
+	if client.closed {
+		return
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	var seeded []string
+	for _, price := range prices {
+		m.rates.setQuote(price.Symbol, price.Quote)
+		if _, ok := client.Prices[price.Symbol]; ok {
+			continue
+		}
+		client.setPrice(price)
+		seeded = append(seeded, price.Symbol)
+	}
+	if len(seeded) == 0 {
+		return
+	}
+
+	m.push(client, client.portfolioView())
+	for _, symbol := range seeded {
+		if client.watches(symbol) {
+			m.push(client, client.priceView(symbol))
+		}
+	}
+}
+
+// processRates swaps the rate table. Views pick the new rates up with the
+// next price of their symbols.
+func (m *Manager) processRates(msg redis.Message) {
+	var rates models.Rates
+	if err := json.Unmarshal([]byte(msg.Payload), &rates); err != nil {
+		m.log.Error("failed to parse rates from redis", "error", err)
+		return
+	}
+	if rates.Base != defaultQuote {
+		m.log.Warn("ignoring rates with unexpected base", "base", rates.Base)
+		return
+	}
+
+	m.rates.set(rates.Rates)
+}
+
+// processAlert pushes a firing to the user when they are connected to this
+// replica.
+func (m *Manager) processAlert(msg redis.Message) {
+	var delivery models.AlertDelivery
+	if err := json.Unmarshal([]byte(msg.Payload), &delivery); err != nil {
+		m.log.Error("failed to parse alert event from redis", "error", err, "payload", msg.Payload)
+		return
+	}
+
+	payload, err := json.Marshal(delivery.Event)
+	if err != nil {
+		m.log.Error("failed to marshal alert event", "error", err, "alertID", delivery.Event.AlertID)
+		return
+	}
+	m.SendToUser(delivery.UserID, payload)
+}
+
+// processProfileEvent queues a refresh when the user of the event is
+// connected to this replica. Reloading happens on its own goroutine so that
+// price updates are not held up by the database.
+func (m *Manager) processProfileEvent(msg redis.Message) {
+	var event models.ProfileEvent
+	if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+		m.log.Error("failed to parse profile event from redis", "error", err, "payload", msg.Payload)
+		return
+	}
+
+	m.mu.RLock()
+	_, connected := m.clients[event.UserID]
+	m.mu.RUnlock()
+	if !connected {
+		return
+	}
+
+	select {
+	case m.refresh <- event.UserID:
+	default:
+		m.log.Warn("refresh queue is full, dropping profile event", "userID", event.UserID, "kind", event.Kind)
+	}
+}
+
+// refreshClients reloads profiles one at a time, so two events of the same
+// user can never be applied out of order.
+func (m *Manager) refreshClients(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case userID := <-m.refresh:
+			m.refreshClient(ctx, userID)
+		}
+	}
+}
+
+// refreshClient swaps the profile of a connected client for a fresh copy,
+// follows the symbols it gained, unfollows those it lost and pushes the new
+// view.
+func (m *Manager) refreshClient(ctx context.Context, userID uuid.UUID) {
+	m.mu.RLock()
+	client, ok := m.clients[userID]
+	m.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	profile, err := m.usersService.GetUserProfile(ctx, userID)
+	if err != nil {
+		m.log.Error("manager: failed to reload profile", "userID", userID, "error", err)
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.clients[userID] != client {
+		return
+	}
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+
+	before := client.followed()
+	client.Profile = profile
+	if client.PortfolioID != 0 && !client.hasPortfolio(client.PortfolioID) {
+		m.log.Info("streamed portfolio was deleted, falling back to the combined view", "userID", userID, "portfolioID", client.PortfolioID)
+		client.PortfolioID = 0
+	}
+	after := client.followed()
+
+	for symbol := range after {
+		if _, ok := before[symbol]; !ok {
+			m.followCoin(userID, symbol)
+		}
+	}
+	for symbol := range before {
+		if _, ok := after[symbol]; !ok {
+			m.unfollowCoin(userID, symbol)
+			delete(client.Prices, symbol)
+			delete(client.Opens, symbol)
+			delete(client.Books, symbol)
+		}
+	}
+
+	m.push(client, client.portfolioView())
+	m.log.Info("client profile refreshed", "userID", userID)
+}
+
+// push marshals frame and queues it for client. The caller must hold m.mu
+// and must have checked that client is registered.
+func (m *Manager) push(client *Client, frame any) {
+	jsonData, err := json.Marshal(frame)
+	if err != nil {
+		m.log.Error("failed to marshal websocket frame", "error", err, "userID", client.UserID)
+		return
+	}
+
+	select {
+	case client.Send <- jsonData:
+	default:
+		m.log.Warn("client send channel is full, dropping message", "userID", client.UserID)
+	}
+}
+
+// portfolioView values every coin of the client at its last known price,
+// converted from the quote of its symbol into the quote of the client. Cost
+// basis and realized P&L are taken to be in the quote of the symbol too. A
+// coin stays unpriced while the rate of its quote is unknown. The caller
+// must hold c.mu.
+func (c *Client) portfolioView() models.PortfolioView {
+	hundred := decimal.NewFromInt(100)
+	quote, rate := c.quoteRate()
+
+	portfolio := models.PortfolioView{
+		Type:               models.FramePortfolio,
+		UserID:             c.UserID.String(),
+		UserName:           c.Profile.Name,
+		CostMethod:         c.Profile.CostMethod,
+		Quote:              quote,
+		TotalValue:         decimal.Zero,
+		TotalCost:          decimal.Zero,
+		TotalUnrealizedPnL: decimal.Zero,
+		TotalRealizedPnL:   decimal.Zero,
+		Coins:              []models.CoinView{},
+	}
+
+	for _, p := range c.Profile.Portfolios {
+		if p.ID == c.PortfolioID {
+			portfolio.PortfolioID = p.ID
+			portfolio.PortfolioName = p.Name
+		}
+	}
+
+	for _, coin := range c.coins() {
+		factor, converted := c.conversion(coin.Symbol, rate)
+		if !converted {
+			factor = decimal.NewFromInt(1).Div(rate)
+		}
+
+		currentPrice, priceFound := c.Prices[coin.Symbol]
+		if !priceFound || !converted {
+			priceFound = false
+			currentPrice = decimal.Zero
+		}
+		currentPrice = currentPrice.Mul(factor)
+		costBasis := coin.CostBasis.Mul(factor)
+		realizedPnL := coin.RealizedPnL.Mul(factor)
+
+		total := coin.Quantity.Mul(currentPrice)
+
+		view := models.CoinView{
+			Asset:                coin.Asset,
+			Symbol:               coin.Symbol,
+			Quantity:             coin.Quantity,
+			Price:                currentPrice,
+			Total:                total,
+			AvgCost:              decimal.Zero,
+			CostBasis:            costBasis,
+			UnrealizedPnL:        decimal.Zero,
+			UnrealizedPnLPercent: decimal.Zero,
+			RealizedPnL:          realizedPnL,
+		}
+
+		if coin.Quantity.IsPositive() {
+			view.AvgCost = costBasis.Div(coin.Quantity)
+		}
+
+		if priceFound {
+			view.UnrealizedPnL = total.Sub(costBasis)
+			if costBasis.IsPositive() {
+				view.UnrealizedPnLPercent = view.UnrealizedPnL.Div(costBasis).Mul(hundred)
 			}
+		}
+
+		portfolio.Coins = append(portfolio.Coins, view)
+		portfolio.TotalValue = portfolio.TotalValue.Add(total)
+		portfolio.TotalCost = portfolio.TotalCost.Add(costBasis)
+		portfolio.TotalUnrealizedPnL = portfolio.TotalUnrealizedPnL.Add(view.UnrealizedPnL)
+		portfolio.TotalRealizedPnL = portfolio.TotalRealizedPnL.Add(realizedPnL)
+	}
 
-			select {
-			case client.Send <- jsonData:
-			default:
-				m.log.Warn("client send channel is full, dropping message", "userID", userID)
+	return portfolio
+}
+
+// coins returns the holdings of the selected portfolio. The combined view
+// merges positions of the same asset held in different portfolios, as long
+// as they are valued with the same pair.
+func (c *Client) coins() []models.Coin {
+	if c.PortfolioID != 0 {
+		var coins []models.Coin
+		for _, coin := range c.Profile.Coins {
+			if coin.PortfolioID == c.PortfolioID {
+				coins = append(coins, coin)
 			}
-			client.mu.Unlock()
 		}
+		return coins
 	}
+
+	var coins []models.Coin
+	index := make(map[string]int)
+	for _, coin := range c.Profile.Coins {
+		i, ok := index[coin.Symbol]
+		if !ok {
+			index[coin.Symbol] = len(coins)
+			coins = append(coins, models.Coin{
+				Asset:       coin.Asset,
+				Symbol:      coin.Symbol,
+				Quantity:    coin.Quantity,
+				CostBasis:   coin.CostBasis,
+				RealizedPnL: coin.RealizedPnL,
+				UserID:      coin.UserID,
+			})
+			continue
+		}
+
+		coins[i].Quantity = coins[i].Quantity.Add(coin.Quantity)
+		coins[i].CostBasis = coins[i].CostBasis.Add(coin.CostBasis)
+		coins[i].RealizedPnL = coins[i].RealizedPnL.Add(coin.RealizedPnL)
+	}
+	return coins
 }
 
 func (c *Client) Writer() {
@@ -259,43 +695,33 @@ func (c *Client) Reader() {
 		c.Manager.Unregister(c)
 		c.Conn.Close()
 	}()
-	c.Conn.SetReadLimit(512)
+	c.Conn.SetReadLimit(maxCommandSize)
 	c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
 	c.Conn.SetPongHandler(func(string) error { c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second)); return nil })
 
 	for {
-		if _, _, err := c.Conn.ReadMessage(); err != nil {
+		_, message, err := c.Conn.ReadMessage()
+		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				c.Manager.log.Warn("unexpected close error", "userID", c.UserID, "error", err)
 			}
 			break
 		}
-	}
-}
-
-func (m *Manager) notifyAggregator(userID, symbol, method string) {
-	aggregatorURL := fmt.Sprintf(
-		"http://aggregator-service:8088/coin?symbol=%s&id=%s",
-		url.QueryEscape(symbol),
-		url.QueryEscape(userID),
-	)
+		c.Conn.SetReadDeadline(time.Now().Add(60 * time.Second))
 
-	req, err := http.NewRequest(method, aggregatorURL, nil)
-	if err != nil {
-		m.log.Error("manager: failed to create request for aggregator", "error", err)
-		return
+		c.handleCommand(message)
 	}
+}
 
-	resp, err := m.httpClient.Do(req)
-	if err != nil {
-		m.log.Error("manager: failed to notify aggregator", "error", err)
-		return
-	}
-	defer resp.Body.Close()
+// followedSymbols returns the symbols someone follows, which are the
+// subscription intents of this replica.
+func (m *Manager) followedSymbols() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
 
-	if resp.StatusCode != http.StatusOK {
-		m.log.Warn("manager: aggregator returned non-200 status", "status", resp.Status)
-	} else {
-		m.log.Info("manager: successfully notified aggregator", "method", method, "symbol", symbol)
+	symbols := make([]string, 0, len(m.coinSubscribers))
+	for symbol := range m.coinSubscribers {
+		symbols = append(symbols, symbol)
 	}
+	return symbols
 }
\ No newline at end of file
@@ -0,0 +1,153 @@
+package websocket
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/service"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/storage/redis"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/shopspring/decimal"
+)
+
+type noAlerts struct {
+	service.AlertsService
+}
+
+func (noAlerts) PendingAlerts(context.Context, uuid.UUID) ([]models.AlertEvent, error) {
+	return nil, nil
+}
+
+type listedSymbols map[string]models.Symbol
+
+func (l listedSymbols) Resolve(symbol string) (models.Symbol, error) {
+	if info, ok := l[symbol]; ok {
+		return info, nil
+	}
+	return models.Symbol{}, errs.ErrInvalidSymbol
+}
+
+func (l listedSymbols) PricingSymbol(asset string) (models.Symbol, error) {
+	return l.Resolve(asset + "usdt")
+}
+
+type observedPrices []time.Time
+
+func (o *observedPrices) ObservePrice(_ string, _ decimal.Decimal, at time.Time) {
+	*o = append(*o, at)
+}
+
+func dialClient(t *testing.T, m *Manager, user *models.User) *Client {
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, err := upgrader.Upgrade(w, r, nil); err != nil {
+			t.Errorf("failed to upgrade: %v", err)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &Client{Manager: m, Conn: conn, UserID: user.ID, Profile: user, Send: make(chan []byte, 1)}
+}
+
+func TestUnregisterReplacedClient(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := NewManager(log, nil, nil, nil, nil, nil, nil, noAlerts{}, 0)
+	user := &models.User{ID: uuid.New(), Name: "ws_user"}
+
+	first := dialClient(t, m, user)
+	second := dialClient(t, m, user)
+	m.registerClient(first)
+	m.registerClient(second)
+
+	t.Run("old_connection_keeps_new_one", func(t *testing.T) {
+		m.unregisterClient(first)
+		if m.clients[user.ID] != second {
+			t.Errorf("Expected the second connection to stay registered")
+		}
+	})
+
+	t.Run("current_connection_is_removed", func(t *testing.T) {
+		m.unregisterClient(second)
+		if _, ok := m.clients[user.ID]; ok {
+			t.Errorf("Expected no connection registered for the user")
+		}
+	})
+}
+
+func TestQuotePair(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	registry := listedSymbols{"btcusdt": {Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}}
+	m := NewManager(log, nil, nil, nil, registry, nil, nil, noAlerts{}, 0)
+	m.rates.set(map[string]float64{"eur": 1.08})
+
+	cases := map[string]string{"usdt": "", "usd": "", "btc": "btcusdt", "eur": ""}
+	for quote, want := range cases {
+		pair, err := m.quotePair(quote)
+		if err != nil || pair != want {
+			t.Errorf("Expected quote %s to follow %q, got %q (err: %v)", quote, want, pair, err)
+		}
+	}
+
+	if _, err := m.quotePair("xyz"); err == nil {
+		t.Errorf("Expected an unlisted quote to be rejected")
+	}
+}
+
+func TestReplayedPrices(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := NewManager(log, nil, nil, nil, nil, nil, nil, noAlerts{}, time.Minute)
+	observed := &observedPrices{}
+	m.AddObserver(observed)
+
+	tradedAt := time.Now().Add(-30 * time.Second).Truncate(time.Millisecond).UTC()
+	payload := fmt.Sprintf(`{"s":"btcusdt","q":"usdt","p":65000,"t":%d}`, tradedAt.UnixMilli())
+
+	t.Run("observers_get_trade_time", func(t *testing.T) {
+		m.processRedisMessage(redis.Message{Channel: "btcusdt", Payload: payload, AddedAt: tradedAt})
+		if len(*observed) != 1 || !(*observed)[0].Equal(tradedAt) {
+			t.Errorf("Expected one price observed at %s, got %v", tradedAt, *observed)
+		}
+	})
+
+	t.Run("stale_entry_is_not_observed", func(t *testing.T) {
+		m.processRedisMessage(redis.Message{Channel: "btcusdt", Payload: payload, AddedAt: time.Now().Add(-2 * time.Minute)})
+		if len(*observed) != 1 {
+			t.Errorf("Expected the stale entry to be skipped, got %d observed prices", len(*observed))
+		}
+	})
+}
+
+func TestResolveSymbols(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	registry := listedSymbols{"btcusdt": {Symbol: "BTCUSDT", BaseAsset: "BTC", QuoteAsset: "USDT"}}
+	m := NewManager(log, nil, nil, nil, registry, nil, nil, noAlerts{}, 0)
+
+	t.Run("listed_symbols_are_normalized", func(t *testing.T) {
+		symbols, err := m.resolveSymbols([]string{"BTCUSDT", "btcusdt"})
+		if err != nil || len(symbols) != 1 || symbols[0] != "btcusdt" {
+			t.Errorf("Expected [btcusdt], got %v (err: %v)", symbols, err)
+		}
+	})
+
+	t.Run("unknown_symbol_is_rejected", func(t *testing.T) {
+		if _, err := m.resolveSymbols([]string{"btcusdt", "btcusd"}); err == nil {
+			t.Errorf("Expected btcusd to be rejected")
+		}
+	})
+}
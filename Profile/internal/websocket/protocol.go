@@ -0,0 +1,512 @@
+package websocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/errs"
+	"github.com/shopspring/decimal"
+)
+
+const (
+	maxCommandSize  = 4096
+	maxWatchSymbols = 50
+
+	// defaultQuote is the asset views are valued in unless the client picks
+	// another one, and the base of the rate table.
+	defaultQuote = "usdt"
+	// usdQuote is valued one to one with USDT.
+	usdQuote = "usd"
+)
+
+// Commands a client can send over the websocket.
+const (
+	CommandSubscribe   = "subscribe"
+	CommandUnsubscribe = "unsubscribe"
+	CommandSnapshot    = "snapshot"
+	CommandSetQuote    = "setQuote"
+	CommandAckAlert    = "ackAlert"
+	CommandPing        = "ping"
+)
+
+var (
+	symbolPattern = regexp.MustCompile(`^[a-z0-9]{2,20}$`)
+	quotePattern  = regexp.MustCompile(`^[a-z0-9]{2,10}$`)
+)
+
+// Command is a frame sent by the client. RequestID is opaque to the server
+// and is echoed in the reply.
+type Command struct {
+	Type      string   `json:"type"`
+	RequestID string   `json:"requestId"`
+	Symbols   []string `json:"symbols,omitempty"`
+	Quote     string   `json:"quote,omitempty"`
+	AlertID   uint     `json:"alertId,omitempty"`
+}
+
+// Reply answers every command. Symbols carries the watched symbols after a
+// subscribe or unsubscribe, Quote the quote asset after setQuote.
+type Reply struct {
+	Type      string   `json:"type"`
+	RequestID string   `json:"requestId,omitempty"`
+	Command   string   `json:"command"`
+	OK        bool     `json:"ok"`
+	Error     string   `json:"error,omitempty"`
+	Symbols   []string `json:"symbols,omitempty"`
+	Quote     string   `json:"quote,omitempty"`
+}
+
+func (c *Client) handleCommand(message []byte) {
+	var cmd Command
+	if err := json.Unmarshal(message, &cmd); err != nil {
+		c.Manager.sendFrame(c, Reply{Type: models.FrameReply, Error: "malformed command"})
+		return
+	}
+
+	reply := Reply{
+		Type:      models.FrameReply,
+		RequestID: cmd.RequestID,
+		Command:   cmd.Type,
+	}
+
+	var err error
+	switch cmd.Type {
+	case CommandSubscribe:
+		reply.Symbols, err = c.subscribe(cmd.Symbols)
+	case CommandUnsubscribe:
+		reply.Symbols, err = c.unsubscribe(cmd.Symbols)
+	case CommandSnapshot:
+		c.Manager.sendSnapshot(c)
+	case CommandSetQuote:
+		reply.Quote, err = c.setQuote(cmd.Quote)
+	case CommandAckAlert:
+		err = c.ackAlert(cmd.AlertID)
+	case CommandPing:
+	default:
+		err = fmt.Errorf("unknown command %q", cmd.Type)
+	}
+
+	if err != nil {
+		reply.Error = err.Error()
+	} else {
+		reply.OK = true
+	}
+	c.Manager.sendFrame(c, reply)
+}
+
+// subscribe adds symbols to the watch list of the connection. Watched
+// symbols are pushed as price frames and, unlike watchlists, are dropped on
+// disconnect.
+func (c *Client) subscribe(symbols []string) ([]string, error) {
+	symbols, err := c.Manager.resolveSymbols(symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	if c.Watch == nil {
+		c.Watch = make(map[string]struct{})
+	}
+	var added []string
+	for _, symbol := range symbols {
+		if _, ok := c.Watch[symbol]; !ok {
+			added = append(added, symbol)
+		}
+	}
+	if len(c.Watch)+len(added) > maxWatchSymbols {
+		c.mu.Unlock()
+		return nil, fmt.Errorf("at most %d symbols can be watched", maxWatchSymbols)
+	}
+	for _, symbol := range added {
+		c.Watch[symbol] = struct{}{}
+	}
+	watched := c.watchList()
+	c.mu.Unlock()
+
+	for _, symbol := range added {
+		c.Manager.Follow(c.UserID, symbol)
+	}
+	go c.Manager.seedPrices(c, added)
+	return watched, nil
+}
+
+// unsubscribe removes symbols from the watch list. The price stream stays
+// open while the symbol is still held, in a watchlist or used as the quote.
+func (c *Client) unsubscribe(symbols []string) ([]string, error) {
+	symbols, err := normalizeSymbols(symbols)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	var removed []string
+	for _, symbol := range symbols {
+		if _, ok := c.Watch[symbol]; !ok {
+			continue
+		}
+		delete(c.Watch, symbol)
+		if !c.holds(symbol) && !c.inWatchlist(symbol) && symbol != c.quoteSymbol() {
+			removed = append(removed, symbol)
+		}
+	}
+	watched := c.watchList()
+	c.mu.Unlock()
+
+	for _, symbol := range removed {
+		c.Manager.Unfollow(c.UserID, symbol)
+	}
+	return watched, nil
+}
+
+// setQuote changes the asset the views are valued in, e.g. usd, eur or btc.
+// Values stay in USDT until the quote has a price, either from the
+// <quote>usdt pair or from the rate table of the Aggregator.
+func (c *Client) setQuote(quote string) (string, error) {
+	quote = strings.ToLower(strings.TrimSpace(quote))
+	if !quotePattern.MatchString(quote) {
+		return "", fmt.Errorf("invalid quote %q", quote)
+	}
+
+	pair, err := c.Manager.quotePair(quote)
+	if err != nil {
+		return "", err
+	}
+
+	c.mu.Lock()
+	oldSymbol := c.quoteSymbol()
+	c.Quote = quote
+	c.quotePair = pair
+	newSymbol := c.quoteSymbol()
+
+	release := oldSymbol != "" && oldSymbol != newSymbol && !c.watches(oldSymbol) && !c.holds(oldSymbol)
+	c.mu.Unlock()
+
+	if newSymbol != "" && newSymbol != oldSymbol {
+		c.Manager.Follow(c.UserID, newSymbol)
+	}
+	if release {
+		c.Manager.Unfollow(c.UserID, oldSymbol)
+	}
+	return quote, nil
+}
+
+// quotePair returns the pair the price of quote is followed by: the
+// <quote>usdt pair when the registry lists it, or none for USDT, USD and
+// assets only the rate table prices. Quotes priced by neither are rejected.
+func (m *Manager) quotePair(quote string) (string, error) {
+	if quote == defaultQuote || quote == usdQuote {
+		return "", nil
+	}
+
+	_, priced := m.rates.usdt(quote)
+	if m.symbols == nil {
+		if priced {
+			return "", nil
+		}
+		return quote + defaultQuote, nil
+	}
+
+	info, err := m.symbols.Resolve(quote + defaultQuote)
+	switch {
+	case err == nil && models.NormalizeSymbol(info.QuoteAsset) == defaultQuote:
+		return models.NormalizeSymbol(info.Symbol), nil
+	case priced:
+		return "", nil
+	case err == nil || errors.Is(err, errs.ErrInvalidSymbol):
+		return "", fmt.Errorf("unknown quote %q", quote)
+	default:
+		m.log.Warn("ws: could not resolve quote", "quote", quote, "error", err)
+		return "", errors.New("quotes are unavailable, try again later")
+	}
+}
+
+// resolveSymbols normalizes symbols and checks each against the symbol
+// registry, so a typo is reported instead of opening a stream that never
+// carries a price. Without a registry symbols are only normalized.
+func (m *Manager) resolveSymbols(symbols []string) ([]string, error) {
+	symbols, err := normalizeSymbols(symbols)
+	if err != nil || m.symbols == nil {
+		return symbols, err
+	}
+
+	resolved := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		info, err := m.symbols.Resolve(symbol)
+		switch {
+		case errors.Is(err, errs.ErrInvalidSymbol):
+			return nil, fmt.Errorf("unknown symbol %q", symbol)
+		case err != nil:
+			m.log.Warn("ws: could not resolve symbol", "symbol", symbol, "error", err)
+			return nil, errors.New("symbols are unavailable, try again later")
+		}
+		resolved = append(resolved, models.NormalizeSymbol(info.Symbol))
+	}
+	return resolved, nil
+}
+
+func (c *Client) ackAlert(alertID uint) error {
+	if alertID == 0 {
+		return errors.New("alertId is required")
+	}
+
+	err := c.Manager.alertsService.AckAlert(context.Background(), c.UserID, alertID)
+	if errors.Is(err, errs.ErrNotFound) {
+		return errors.New("alert not found")
+	}
+	if err != nil {
+		c.Manager.log.Error("ws: failed to ack alert", "userID", c.UserID, "alertID", alertID, "error", err)
+		return errors.New("could not ack alert")
+	}
+	return nil
+}
+
+// sendFrame queues frame for client unless its connection was replaced.
+func (m *Manager) sendFrame(client *Client, frame any) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if client.closed {
+		return
+	}
+	m.push(client, frame)
+}
+
+// sendSnapshot pushes the current portfolio view and the last price of every
+// watched symbol.
+func (m *Manager) sendSnapshot(client *Client) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if client.closed {
+		return
+	}
+
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+
+	m.push(client, client.portfolioView())
+	for _, symbol := range client.watched() {
+		if _, ok := client.Prices[symbol]; ok {
+			m.push(client, client.priceView(symbol))
+		}
+	}
+}
+
+// replayAlerts pushes the firings the user has not acknowledged yet, e.g.
+// those that fired while they were offline.
+func (m *Manager) replayAlerts(client *Client) {
+	events, err := m.alertsService.PendingAlerts(context.Background(), client.UserID)
+	if err != nil {
+		m.log.Error("ws: failed to load pending alerts", "userID", client.UserID, "error", err)
+		return
+	}
+
+	for _, event := range events {
+		m.sendFrame(client, event)
+	}
+}
+
+// priceView values the last price of symbol in the quote of the client, or
+// in the quote of the symbol while its rate is unknown. The caller must hold
+// c.mu.
+func (c *Client) priceView(symbol string) models.PriceView {
+	quote, rate := c.quoteRate()
+
+	factor, ok := c.conversion(symbol, rate)
+	if !ok {
+		quote, factor = c.Manager.rates.quoteOf(symbol), decimal.NewFromInt(1)
+	}
+
+	price := c.Prices[symbol].Mul(factor)
+	view := models.PriceView{
+		Type:   models.FramePrice,
+		Symbol: symbol,
+		Price:  price,
+		Quote:  quote,
+	}
+
+	if open, ok := c.Opens[symbol]; ok && open.IsPositive() {
+		open = open.Mul(factor)
+		change := price.Sub(open)
+		percent := change.Div(open).Mul(decimal.NewFromInt(100))
+		view.Change24h = &change
+		view.ChangePercent24h = &percent
+	}
+
+	if book, ok := c.Books[symbol]; ok {
+		bid, ask := book.Bid.Mul(factor), book.Ask.Mul(factor)
+		mid := bid.Add(ask).Div(decimal.NewFromInt(2))
+		spread := ask.Sub(bid)
+		view.Bid, view.Ask, view.Mid, view.Spread = &bid, &ask, &mid, &spread
+	}
+	return view
+}
+
+// quoteRate returns the quote the views are valued in and its price in USDT.
+// The live <quote>usdt pair is preferred over the rate table, which also
+// covers fiat currencies and assets traded only against other quotes. It
+// falls back to USDT while the quote has no price yet. The caller must hold
+// c.mu.
+func (c *Client) quoteRate() (string, decimal.Decimal) {
+	switch c.Quote {
+	case "", defaultQuote:
+		return defaultQuote, decimal.NewFromInt(1)
+	case usdQuote:
+		return usdQuote, decimal.NewFromInt(1)
+	}
+
+	if symbol := c.quoteSymbol(); symbol != "" {
+		if rate, ok := c.Prices[symbol]; ok && rate.IsPositive() && c.Manager.rates.quoteOf(symbol) == defaultQuote {
+			return c.Quote, rate
+		}
+	}
+	if rate, ok := c.Manager.rates.usdt(c.Quote); ok {
+		return c.Quote, rate
+	}
+	return defaultQuote, decimal.NewFromInt(1)
+}
+
+// conversion returns the factor turning prices of symbol into the quote
+// priced at rate USDT. It reports false while the rate of the quote of the
+// symbol is unknown.
+func (c *Client) conversion(symbol string, rate decimal.Decimal) (decimal.Decimal, bool) {
+	symbolRate, ok := c.Manager.rates.usdt(c.Manager.rates.quoteOf(symbol))
+	if !ok {
+		return decimal.Zero, false
+	}
+	return symbolRate.Div(rate), true
+}
+
+// quoteSymbol returns the pair the quote is priced by, or "" when the quote
+// is not followed through a pair. The caller must hold c.mu.
+func (c *Client) quoteSymbol() string {
+	return c.quotePair
+}
+
+// holds reports whether symbol is part of the streamed portfolio. The caller
+// must hold c.mu.
+func (c *Client) holds(symbol string) bool {
+	for _, coin := range c.coins() {
+		if coin.Symbol == symbol {
+			return true
+		}
+	}
+	return false
+}
+
+// followed returns every symbol the client needs a price stream for. The
+// caller must hold c.mu.
+func (c *Client) followed() map[string]struct{} {
+	symbols := make(map[string]struct{})
+	for _, coin := range c.coins() {
+		symbols[coin.Symbol] = struct{}{}
+	}
+	for _, symbol := range c.watched() {
+		symbols[symbol] = struct{}{}
+	}
+	if symbol := c.quoteSymbol(); symbol != "" {
+		symbols[symbol] = struct{}{}
+	}
+	return symbols
+}
+
+// hasPortfolio reports whether the profile still owns the portfolio. The
+// caller must hold c.mu.
+func (c *Client) hasPortfolio(id uint) bool {
+	for _, portfolio := range c.Profile.Portfolios {
+		if portfolio.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// watches reports whether symbol is pushed as a price frame. The caller must
+// hold c.mu.
+func (c *Client) watches(symbol string) bool {
+	if _, ok := c.Watch[symbol]; ok {
+		return true
+	}
+	return c.inWatchlist(symbol)
+}
+
+// inWatchlist reports whether symbol is in one of the watchlists of the
+// profile. The caller must hold c.mu.
+func (c *Client) inWatchlist(symbol string) bool {
+	for _, watchlist := range c.Profile.Watchlists {
+		for _, item := range watchlist.Items {
+			if item.Symbol == symbol {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// watchlisted returns the symbols of all watchlists of the profile.
+func (c *Client) watchlisted() []string {
+	seen := make(map[string]struct{})
+	var symbols []string
+	for _, watchlist := range c.Profile.Watchlists {
+		for _, item := range watchlist.Items {
+			if _, ok := seen[item.Symbol]; !ok {
+				seen[item.Symbol] = struct{}{}
+				symbols = append(symbols, item.Symbol)
+			}
+		}
+	}
+	return symbols
+}
+
+// watched returns the symbols subscribed over the connection and those of
+// the watchlists, sorted. The caller must hold c.mu.
+func (c *Client) watched() []string {
+	symbols := c.watchList()
+	for _, symbol := range c.watchlisted() {
+		if _, ok := c.Watch[symbol]; !ok {
+			symbols = append(symbols, symbol)
+		}
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// watchList returns the symbols subscribed over the connection sorted. The
+// caller must hold c.mu.
+func (c *Client) watchList() []string {
+	symbols := make([]string, 0, len(c.Watch))
+	for symbol := range c.Watch {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// normalizeSymbols lowercases symbols as the Aggregator publishes them and
+// drops duplicates.
+func normalizeSymbols(symbols []string) ([]string, error) {
+	if len(symbols) == 0 {
+		return nil, errors.New("symbols are required")
+	}
+
+	seen := make(map[string]struct{}, len(symbols))
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToLower(strings.TrimSpace(symbol))
+		if !symbolPattern.MatchString(symbol) {
+			return nil, fmt.Errorf("invalid symbol %q", symbol)
+		}
+		if _, ok := seen[symbol]; ok {
+			continue
+		}
+		seen[symbol] = struct{}{}
+		normalized = append(normalized, symbol)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,73 @@
+package websocket
+
+import (
+	"sync"
+
+	"github.com/shopspring/decimal"
+)
+
+// rateTable holds the USDT price of every asset, as last published by the
+// Aggregator, and the quote asset of every symbol seen on the price stream.
+type rateTable struct {
+	mu     sync.RWMutex
+	rates  map[string]decimal.Decimal
+	quotes map[string]string
+}
+
+func newRateTable() *rateTable {
+	return &rateTable{
+		rates:  make(map[string]decimal.Decimal),
+		quotes: make(map[string]string),
+	}
+}
+
+// set replaces the table. Non-positive rates are dropped.
+func (t *rateTable) set(rates map[string]float64) {
+	table := make(map[string]decimal.Decimal, len(rates))
+	for asset, rate := range rates {
+		if rate > 0 {
+			table[asset] = decimal.NewFromFloat(rate)
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.rates = table
+}
+
+func (t *rateTable) setQuote(symbol, quote string) {
+	if quote == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.quotes[symbol] = quote
+}
+
+// quoteOf returns the asset symbol is quoted in. Symbols are taken to be
+// quoted in USDT until their first price says otherwise.
+func (t *rateTable) quoteOf(symbol string) string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	if quote, ok := t.quotes[symbol]; ok {
+		return quote
+	}
+	return defaultQuote
+}
+
+// usdt returns the price of one unit of asset in USDT.
+func (t *rateTable) usdt(asset string) (decimal.Decimal, bool) {
+	if asset == defaultQuote {
+		return decimal.NewFromInt(1), true
+	}
+
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	rate, ok := t.rates[asset]
+	return rate, ok
+}
@@ -0,0 +1,53 @@
+package websocket
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestConversion(t *testing.T) {
+	log := slog.New(slog.NewTextHandler(io.Discard, nil))
+	m := NewManager(log, nil, nil, nil, nil, nil, nil, noAlerts{}, 0)
+	// The Aggregator publishes the USDT price of every asset it can reach.
+	m.rates.set(map[string]float64{"btc": 60000, "try": 1.0 / 30, "eur": 1.08, "bnb": 0})
+	m.rates.setQuote("btcusdt", "usdt")
+	m.rates.setQuote("usdttry", "try")
+	m.rates.setQuote("ethbtc", "btc")
+	m.rates.setQuote("xyzbnb", "bnb")
+	client := &Client{Manager: m}
+
+	cases := []struct {
+		name   string
+		symbol string
+		price  decimal.Decimal
+		rate   decimal.Decimal
+		want   decimal.Decimal
+	}{
+		{name: "direct_pair", symbol: "btcusdt", price: decimal.NewFromInt(60000), rate: decimal.NewFromInt(1), want: decimal.NewFromInt(60000)},
+		{name: "inverse_pair", symbol: "usdttry", price: decimal.NewFromInt(30), rate: decimal.NewFromInt(1), want: decimal.NewFromInt(1)},
+		{name: "two_hops_through_btc", symbol: "ethbtc", price: decimal.NewFromFloat(0.05), rate: decimal.NewFromFloat(1.08), want: decimal.RequireFromString("2777.78")},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			factor, ok := client.conversion(tc.symbol, tc.rate)
+			if !ok {
+				t.Fatalf("Expected a conversion for %s", tc.symbol)
+			}
+			if got := tc.price.Mul(factor).Round(2); !got.Equal(tc.want) {
+				t.Errorf("Expected %s, got %s", tc.want, got)
+			}
+		})
+	}
+
+	t.Run("unreachable_quote", func(t *testing.T) {
+		if _, ok := client.conversion("xyzbnb", decimal.NewFromInt(1)); ok {
+			t.Errorf("Expected no conversion without a bnb rate")
+		}
+		if _, ok := m.USDTRate("xyzbnb"); ok {
+			t.Errorf("Expected no USDT rate for xyzbnb")
+		}
+	})
+}
@@ -9,3 +9,21 @@ var ErrAlreadyExists = errors.New("already exists")
 var ErrInternal = errors.New("internal error")
 
 var ErrInsufficientFunds = errors.New("insufficient funds")
+
+var ErrInvalidTransaction = errors.New("invalid transaction")
+
+var ErrTransactionVoided = errors.New("transaction already voided")
+
+var ErrInvalidPortfolio = errors.New("invalid portfolio")
+
+var ErrPortfolioNotEmpty = errors.New("portfolio is not empty")
+
+var ErrInvalidAlert = errors.New("invalid alert")
+
+var ErrInvalidRange = errors.New("invalid range")
+
+var ErrInvalidWatchlist = errors.New("invalid watchlist")
+
+var ErrInvalidSymbol = errors.New("invalid symbol")
+
+var ErrSymbolsUnavailable = errors.New("symbol registry is unavailable")
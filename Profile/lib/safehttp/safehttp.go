@@ -0,0 +1,106 @@
+// Package safehttp guards outgoing requests to URLs users configured, such
+// as alert webhooks, against reaching the service network.
+package safehttp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var (
+	ErrInvalidURL    = errors.New("must be an absolute http(s) URL")
+	ErrForbiddenHost = errors.New("must not point to a loopback, private or internal host")
+)
+
+// blocked lists the ranges IsPrivate, IsLoopback and friends leave out.
+var blocked = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+}
+
+// PublicIP reports whether ip may be connected to.
+func PublicIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	if !ip.IsValid() || ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, prefix := range blocked {
+		if prefix.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckURL accepts an absolute http(s) URL whose host is a public IP or a
+// fully qualified name. Single-label names such as the compose services
+// (redis, postgres-profile) and the local suffixes are refused. A name can
+// still resolve to a private address later, which the dialer of NewClient
+// refuses.
+func CheckURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return ErrInvalidURL
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if ip, err := netip.ParseAddr(host); err == nil {
+		if !PublicIP(ip) {
+			return ErrForbiddenHost
+		}
+		return nil
+	}
+
+	if !strings.Contains(host, ".") {
+		return ErrForbiddenHost
+	}
+	for _, suffix := range []string{".localhost", ".local", ".internal", ".lan", ".home.arpa"} {
+		if strings.HasSuffix(host, suffix) {
+			return ErrForbiddenHost
+		}
+	}
+	return nil
+}
+
+// NewClient returns a client that refuses to connect to an address
+// PublicIP rejects. The check runs on the address actually dialed, after
+// DNS resolution, so a name that changed its answer since CheckURL cannot
+// reach the service network. Proxies from the environment are not used.
+func NewClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil {
+				return fmt.Errorf("safehttp: %w", err)
+			}
+			if !PublicIP(addrPort.Addr()) {
+				return fmt.Errorf("safehttp: dial %s: %w", address, ErrForbiddenHost)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, addr)
+			},
+			TLSHandshakeTimeout: timeout,
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package safehttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/lib/safehttp"
+)
+
+func TestCheckURL(t *testing.T) {
+	cases := map[string]error{
+		"https://hooks.example.com/alert":     nil,
+		"http://93.184.216.34:8080/alert":     nil,
+		"ftp://hooks.example.com/alert":       safehttp.ErrInvalidURL,
+		"/relative":                           safehttp.ErrInvalidURL,
+		"http://127.0.0.1:8080/":              safehttp.ErrForbiddenHost,
+		"http://[::1]/":                       safehttp.ErrForbiddenHost,
+		"http://10.0.0.5/":                    safehttp.ErrForbiddenHost,
+		"http://192.168.1.1/":                 safehttp.ErrForbiddenHost,
+		"http://169.254.169.254/latest/":      safehttp.ErrForbiddenHost,
+		"http://[::ffff:127.0.0.1]/":          safehttp.ErrForbiddenHost,
+		"http://localhost:6379/":              safehttp.ErrForbiddenHost,
+		"http://redis:6379/":                  safehttp.ErrForbiddenHost,
+		"http://aggregator-service:8088/coin": safehttp.ErrForbiddenHost,
+		"http://printer.local/":               safehttp.ErrForbiddenHost,
+		"http://metadata.google.internal/":    safehttp.ErrForbiddenHost,
+	}
+
+	for raw, want := range cases {
+		if err := safehttp.CheckURL(raw); !errors.Is(err, want) {
+			t.Errorf("Expected CheckURL(%q) to return %v, got %v", raw, want, err)
+		}
+	}
+}
+
+func TestClientRefusesPrivateAddress(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request to reach the loopback server")
+	}))
+	defer server.Close()
+
+	_, err := safehttp.NewClient(time.Second).Post(server.URL, "application/json", nil)
+	if !errors.Is(err, safehttp.ErrForbiddenHost) {
+		t.Errorf("Expected dial to loopback to be refused, got %v", err)
+	}
+}
@@ -3,10 +3,13 @@ package postgres
 import (
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/config"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/shopspring/decimal"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"gorm.io/gorm/logger"
@@ -16,7 +19,9 @@ type Storage struct {
 	DB *gorm.DB
 }
 
-func New(cfg config.DBConfig) (*Storage, error) {
+// New connects to the database and migrates it. quotes is the quote
+// preference the pairs of holdings stored before assets are split with.
+func New(cfg config.DBConfig, quotes []string) (*Storage, error) {
 	const op = "storage/postgres"
 
 	dsn := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%d sslmode=disable",
@@ -42,11 +47,24 @@ func New(cfg config.DBConfig) (*Storage, error) {
 
 	slog.Info("Successfully connected to PostgreSQL.")
 
-	if err := db.AutoMigrate(&models.User{}, &models.Coin{}); err != nil {
+	if err := db.AutoMigrate(&models.User{}, &models.Portfolio{}, &models.Coin{}, &models.Transaction{}, &models.Alert{},
+		&models.PortfolioSnapshot{}, &models.SnapshotCoin{}, &models.Watchlist{}, &models.WatchlistItem{}); err != nil {
 		return nil, fmt.Errorf("%s: failed to auto-migrate database: %w", op, err)
 	}
 	slog.Info("Database auto-migration completed.")
 
+	if err := backfillPortfolios(db); err != nil {
+		return nil, fmt.Errorf("%s: failed to backfill portfolios: %w", op, err)
+	}
+
+	if err := backfillLedger(db); err != nil {
+		return nil, fmt.Errorf("%s: failed to backfill transaction ledger: %w", op, err)
+	}
+
+	if err := backfillAssets(db, quotes); err != nil {
+		return nil, fmt.Errorf("%s: failed to backfill assets: %w", op, err)
+	}
+
 	return &Storage{DB: db}, nil
 }
 
@@ -58,3 +76,180 @@ func (s *Storage) Stop() error {
 
 	return sqlDb.Close()
 }
+
+// backfillPortfolios gives every user created before portfolios existed a
+// default portfolio and moves their coins and transactions into it.
+func backfillPortfolios(db *gorm.DB) error {
+	var users []models.User
+	err := db.Where(
+		"NOT EXISTS (SELECT 1 FROM portfolios p WHERE p.user_id = users.id AND p.is_default = ?)", true,
+	).Find(&users).Error
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		portfolio := models.Portfolio{
+			UserID:    user.ID,
+			Name:      models.DefaultPortfolioName,
+			IsDefault: true,
+		}
+		if err := db.Create(&portfolio).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, table := range []string{"coins", "transactions"} {
+		err := db.Exec(fmt.Sprintf(
+			"UPDATE %[1]s SET portfolio_id = (SELECT p.id FROM portfolios p WHERE p.user_id = %[1]s.user_id AND p.is_default = ?) "+
+				"WHERE portfolio_id IS NULL OR portfolio_id = 0", table,
+		), true).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(users) > 0 {
+		slog.Info("Default portfolios backfilled.", "users", len(users))
+	}
+
+	return nil
+}
+
+// backfillLedger gives every coin created before the transaction ledger
+// existed an opening transaction, so rebuilding it from the ledger keeps the
+// stored quantity.
+func backfillLedger(db *gorm.DB) error {
+	var coins []models.Coin
+	err := db.Where(
+		"NOT EXISTS (SELECT 1 FROM transactions t WHERE t.portfolio_id = coins.portfolio_id AND t.symbol = coins.symbol)",
+	).Find(&coins).Error
+	if err != nil {
+		return err
+	}
+
+	for _, coin := range coins {
+		if !coin.Quantity.IsPositive() {
+			continue
+		}
+
+		tx := models.Transaction{
+			UserID:      coin.UserID,
+			PortfolioID: coin.PortfolioID,
+			Symbol:      coin.Symbol,
+			Side:        models.SideBuy,
+			Quantity:    coin.Quantity,
+			Price:       coin.CostBasis.Div(coin.Quantity),
+			Fee:         decimal.Zero,
+			ExecutedAt:  coin.CreatedAt,
+			Note:        "opening balance",
+		}
+		if err := db.Create(&tx).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(coins) > 0 {
+		slog.Info("Transaction ledger backfilled.", "coins", len(coins))
+	}
+
+	return nil
+}
+
+// backfillAssets names the asset of every coin and transaction stored while
+// holdings were kept by pair, splitting the pair at the longest of quotes.
+// The ledger of a holding is kept in a single quote, so when a portfolio
+// held one asset through pairs of different quotes only the pair with the
+// most preferred quote takes the asset; the others stay holdings of their
+// own, named after the pair, which is logged.
+func backfillAssets(db *gorm.DB, quotes []string) error {
+	return db.Transaction(func(dbTx *gorm.DB) error {
+		for _, table := range []string{"coins", "transactions"} {
+			var symbols []string
+			err := dbTx.Table(table).Where("asset = ''").Distinct().Pluck("symbol", &symbols).Error
+			if err != nil {
+				return err
+			}
+
+			for _, symbol := range symbols {
+				asset := symbol
+				if base, _, ok := models.SplitSymbol(symbol, quotes); ok {
+					asset = base
+				} else {
+					slog.Warn("Pair ends with no preferred quote, keeping it as the asset.", "symbol", symbol)
+				}
+
+				err := dbTx.Table(table).Where("asset = '' AND symbol = ?", symbol).Update("asset", asset).Error
+				if err != nil {
+					return err
+				}
+			}
+
+			if len(symbols) > 0 {
+				slog.Info("Assets backfilled.", "table", table, "symbols", len(symbols))
+			}
+		}
+
+		return separateQuotes(dbTx, quotes)
+	})
+}
+
+// separateQuotes gives every pair but the most preferred one of an asset
+// held in a portfolio through several pairs the pair itself as the asset.
+func separateQuotes(db *gorm.DB, quotes []string) error {
+	type holding struct {
+		PortfolioID uint
+		Asset       string
+		Symbol      string
+	}
+
+	var rows []holding
+	err := db.Raw(
+		"SELECT portfolio_id, asset, symbol FROM coins WHERE deleted_at IS NULL " +
+			"UNION SELECT portfolio_id, asset, symbol FROM transactions WHERE deleted_at IS NULL",
+	).Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	pairs := make(map[holding][]string)
+	for _, row := range rows {
+		key := holding{PortfolioID: row.PortfolioID, Asset: row.Asset}
+		pairs[key] = append(pairs[key], row.Symbol)
+	}
+
+	rank := func(symbol string) int {
+		_, quote, _ := models.SplitSymbol(symbol, quotes)
+		if i := slices.Index(quotes, quote); i >= 0 {
+			return i
+		}
+		return len(quotes)
+	}
+
+	for key, symbols := range pairs {
+		if len(symbols) < 2 {
+			continue
+		}
+
+		slices.SortFunc(symbols, func(a, b string) int {
+			if d := rank(a) - rank(b); d != 0 {
+				return d
+			}
+			return strings.Compare(a, b)
+		})
+		for _, symbol := range symbols[1:] {
+			for _, table := range []string{"coins", "transactions"} {
+				err := db.Table(table).Where("portfolio_id = ? AND asset = ? AND symbol = ?", key.PortfolioID, key.Asset, symbol).
+					Update("asset", symbol).Error
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		slog.Warn("Asset held through pairs of several quotes, keeping the other pairs as holdings of their own.",
+			"portfolio_id", key.PortfolioID, "asset", key.Asset, "symbol", symbols[0], "separated", symbols[1:])
+	}
+
+	return nil
+}
@@ -0,0 +1,89 @@
+package redis
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Intents keeps the symbols this replica needs prices for in a Redis set
+// of its own, which the Aggregator reconciles its streams from. The set
+// expires unless it is renewed, so the demand of a replica that died goes
+// away with it.
+type Intents struct {
+	client  *redis.Client
+	log     *slog.Logger
+	key     string
+	ttl     time.Duration
+	changed chan struct{}
+}
+
+func NewIntents(log *slog.Logger, ttl time.Duration) *Intents {
+	return &Intents{
+		client:  newClient(),
+		log:     log,
+		key:     models.SubscriptionsKeyPrefix + uuid.NewString(),
+		ttl:     ttl,
+		changed: make(chan struct{}, 1),
+	}
+}
+
+// Changed asks Run to write the symbols again. It never blocks.
+func (i *Intents) Changed() {
+	select {
+	case i.changed <- struct{}{}:
+	default:
+	}
+}
+
+// Run writes the symbols returned by snapshot on every change and renews
+// them three times per TTL until ctx is done.
+func (i *Intents) Run(ctx context.Context, snapshot func() []string) {
+	ticker := time.NewTicker(i.ttl / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-i.changed:
+		case <-ticker.C:
+		}
+
+		if err := i.write(ctx, snapshot()); err != nil && ctx.Err() == nil {
+			i.log.Error("failed to write subscription intents", "key", i.key, "error", err)
+		}
+	}
+}
+
+func (i *Intents) write(ctx context.Context, symbols []string) error {
+	pipe := i.client.TxPipeline()
+	pipe.Del(ctx, i.key)
+	if len(symbols) > 0 {
+		members := make([]any, len(symbols))
+		for n, symbol := range symbols {
+			members[n] = symbol
+		}
+		pipe.SAdd(ctx, i.key, members...)
+		pipe.Expire(ctx, i.key, i.ttl)
+	}
+	pipe.Publish(ctx, models.SubscriptionsChannel, i.key)
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// Close drops the intents of this replica, so the Aggregator stops its
+// streams without waiting for them to expire.
+func (i *Intents) Close() {
+	if err := i.write(context.Background(), nil); err != nil {
+		i.log.Warn("failed to drop subscription intents", "key", i.key, "error", err)
+	}
+	if err := i.client.Close(); err != nil {
+		i.log.Warn("error closing redis intents", "error", err)
+	}
+}
@@ -0,0 +1,61 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/redis/go-redis/v9"
+)
+
+// Prices reads the last price of every symbol the Aggregator cached.
+type Prices struct {
+	client *redis.Client
+	log    *slog.Logger
+}
+
+func NewPrices(log *slog.Logger) *Prices {
+	return &Prices{
+		client: newClient(),
+		log:    log,
+	}
+}
+
+// Get returns the cached prices of symbols, skipping those without one.
+func (p *Prices) Get(ctx context.Context, symbols []string) ([]models.PriceUpdate, error) {
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		keys[i] = models.PriceKeyPrefix + symbol
+	}
+
+	values, err := p.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	prices := make([]models.PriceUpdate, 0, len(values))
+	for i, value := range values {
+		payload, ok := value.(string)
+		if !ok {
+			continue
+		}
+		var price models.PriceUpdate
+		if err := json.Unmarshal([]byte(payload), &price); err != nil {
+			p.log.Warn("skipping malformed cached price", "symbol", symbols[i], "error", err)
+			continue
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+func (p *Prices) Close() {
+	if err := p.client.Close(); err != nil {
+		p.log.Warn("error closing redis prices", "error", err)
+	}
+}
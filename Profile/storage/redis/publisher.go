@@ -0,0 +1,59 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Profile/internal/models"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Publisher fans profile events out to every Profile replica over Redis
+// pub/sub.
+type Publisher struct {
+	client *redis.Client
+	log    *slog.Logger
+}
+
+func NewPublisher(log *slog.Logger) *Publisher {
+	return &Publisher{
+		client: newClient(),
+		log:    log,
+	}
+}
+
+// PublishProfileEvent implements service.EventPublisher.
+func (p *Publisher) PublishProfileEvent(ctx context.Context, event models.ProfileEvent) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		p.log.Error("failed to marshal profile event", "error", err, "userID", event.UserID)
+		return
+	}
+
+	// The write has been committed already, so the event must not be
+	// dropped because the request context was cancelled.
+	if err := p.client.Publish(context.WithoutCancel(ctx), models.ProfileEventsChannel, payload).Err(); err != nil {
+		p.log.Error("failed to publish profile event", "error", err, "userID", event.UserID, "kind", event.Kind)
+	}
+}
+
+// PublishAlert implements alerts.Notifier.
+func (p *Publisher) PublishAlert(ctx context.Context, userID uuid.UUID, event models.AlertEvent) {
+	payload, err := json.Marshal(models.AlertDelivery{UserID: userID, Event: event})
+	if err != nil {
+		p.log.Error("failed to marshal alert event", "error", err, "userID", userID, "alertID", event.AlertID)
+		return
+	}
+
+	if err := p.client.Publish(context.WithoutCancel(ctx), models.AlertsChannel, payload).Err(); err != nil {
+		p.log.Error("failed to publish alert event", "error", err, "userID", userID, "alertID", event.AlertID)
+	}
+}
+
+func (p *Publisher) Close() {
+	if err := p.client.Close(); err != nil {
+		p.log.Warn("error closing redis publisher", "error", err)
+	}
+}
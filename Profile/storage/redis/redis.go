@@ -5,41 +5,90 @@ import (
 	"log/slog"
 	"os"
 	"sync"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 )
 
+// Message is a payload read from Redis. AddedAt is the time a stream entry
+// was appended, zero for Pub/Sub messages, which are never replayed.
 type Message struct {
 	Channel string
 	Payload string
+	AddedAt time.Time
 }
 
+// Subscriber delivers the messages of the Redis channels it is subscribed
+// to on Messages. Prices come over Pub/Sub as well, unless the subscriber
+// was made with NewStreamSubscriber.
 type Subscriber struct {
 	client        *redis.Client
 	Messages      chan Message
 	subscriptions map[string]*redis.PubSub
+	prices        *priceStream
 	mu            sync.RWMutex
 	log           *slog.Logger
 }
 
-func NewSubscriber(log *slog.Logger) *Subscriber {
+// newClient connects to the Redis instance named by REDIS_ADDR.
+func newClient() *redis.Client {
 	redisAddr := os.Getenv("REDIS_ADDR")
 	if redisAddr == "" {
-		redisAddr = "localhost:6379" 
+		redisAddr = "localhost:6379"
 	}
 
+	return redis.NewClient(&redis.Options{
+		Addr:     redisAddr,
+		Password: "",
+		DB:       0,
+	})
+}
+
+func NewSubscriber(log *slog.Logger) *Subscriber {
 	return &Subscriber{
-		client: redis.NewClient(&redis.Options{
-			Addr:     redisAddr,
-			Password: "", 
-			DB:       0,  
-		}),
+		client:        newClient(),
 		Messages:      make(chan Message, 1000), 
 		subscriptions: make(map[string]*redis.PubSub),
 		log:           log,
 	}
 }
 
+// NewStreamSubscriber reads prices from the Redis stream named stream
+// through the consumer group group, which must stay the same across
+// restarts of the replica for it to resume where it stopped.
+func NewStreamSubscriber(log *slog.Logger, stream, group string) *Subscriber {
+	s := NewSubscriber(log)
+	s.prices = newPriceStream(s.client, log, stream, group)
+	return s
+}
+
+// Run reads the price stream until ctx is done. It returns right away when
+// prices come over Pub/Sub.
+func (s *Subscriber) Run(ctx context.Context) {
+	if s.prices == nil {
+		return
+	}
+	s.prices.run(ctx, s.Messages)
+}
+
+// SubscribePrices delivers the prices of symbol with the channel set to
+// symbol.
+func (s *Subscriber) SubscribePrices(ctx context.Context, symbol string) error {
+	if s.prices == nil {
+		return s.Subscribe(ctx, symbol)
+	}
+	s.prices.add(symbol)
+	return nil
+}
+
+func (s *Subscriber) UnsubscribePrices(ctx context.Context, symbol string) error {
+	if s.prices == nil {
+		return s.Unsubscribe(ctx, symbol)
+	}
+	s.prices.remove(symbol)
+	return nil
+}
+
 func (s *Subscriber) Subscribe(ctx context.Context, symbol string) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -117,6 +166,10 @@ func (s *Subscriber) listener(ctx context.Context, pubsub *redis.PubSub) {
 }
 
 func (s *Subscriber) Close() {
+	if s.prices != nil {
+		s.prices.wait()
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
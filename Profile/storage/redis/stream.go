@@ -0,0 +1,176 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// priceStream reads the prices the Aggregator appends to a Redis stream
+// through a consumer group of its own. Entries are acknowledged once they
+// are handed over, so after a reconnect or a restart under the same group
+// the replica resumes after the last price it took instead of losing the
+// ones published meanwhile.
+type priceStream struct {
+	client *redis.Client
+	log    *slog.Logger
+	stream string
+	group  string
+
+	mu      sync.RWMutex
+	symbols map[string]struct{}
+	running bool
+	done    chan struct{}
+}
+
+func newPriceStream(client *redis.Client, log *slog.Logger, stream, group string) *priceStream {
+	return &priceStream{
+		client:  client,
+		log:     log,
+		stream:  stream,
+		group:   group,
+		symbols: make(map[string]struct{}),
+		done:    make(chan struct{}),
+	}
+}
+
+func (p *priceStream) add(symbol string) {
+	p.mu.Lock()
+	p.symbols[symbol] = struct{}{}
+	p.mu.Unlock()
+}
+
+func (p *priceStream) remove(symbol string) {
+	p.mu.Lock()
+	delete(p.symbols, symbol)
+	p.mu.Unlock()
+}
+
+func (p *priceStream) wants(symbol string) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	_, ok := p.symbols[symbol]
+	return ok
+}
+
+// run passes the prices of the added symbols to out until ctx is done. It
+// first drains the entries delivered to the group but never acknowledged,
+// then reads the new ones.
+func (p *priceStream) run(ctx context.Context, out chan<- Message) {
+	p.mu.Lock()
+	p.running = true
+	p.mu.Unlock()
+	defer close(p.done)
+
+	p.log.Info("reading prices from redis stream", "stream", p.stream, "group", p.group)
+
+	id, grouped := "0", false
+	for ctx.Err() == nil {
+		if !grouped {
+			if err := p.createGroup(ctx); err != nil {
+				p.fail(ctx, "failed to create consumer group", err)
+				continue
+			}
+			grouped = true
+		}
+
+		streams, err := p.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    p.group,
+			Consumer: p.group,
+			Streams:  []string{p.stream, id},
+			Count:    100,
+			Block:    5 * time.Second,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			// The group is gone with the stream, e.g. after Redis lost
+			// its data.
+			if strings.HasPrefix(err.Error(), "NOGROUP") {
+				grouped = false
+			}
+			p.fail(ctx, "failed to read price stream", err)
+			continue
+		}
+
+		var entries []redis.XMessage
+		for _, stream := range streams {
+			entries = append(entries, stream.Messages...)
+		}
+		if id == "0" && len(entries) == 0 {
+			id = ">"
+			continue
+		}
+
+		ids := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			symbol, _ := entry.Values["symbol"].(string)
+			payload, _ := entry.Values["data"].(string)
+			if symbol != "" && p.wants(symbol) {
+				select {
+				case out <- Message{Channel: symbol, Payload: payload, AddedAt: entryTime(entry.ID)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			ids = append(ids, entry.ID)
+		}
+		if len(ids) > 0 {
+			if err := p.client.XAck(ctx, p.stream, p.group, ids...).Err(); err != nil {
+				p.fail(ctx, "failed to acknowledge prices", err)
+			}
+		}
+	}
+}
+
+// entryTime reads the time an entry was appended from its ID, which is the
+// Unix time in milliseconds followed by a sequence number.
+func entryTime(id string) time.Time {
+	ms, _, _ := strings.Cut(id, "-")
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(n).UTC()
+}
+
+// wait returns once run has stopped, right away when it never started.
+func (p *priceStream) wait() {
+	p.mu.RLock()
+	running := p.running
+	p.mu.RUnlock()
+
+	if running {
+		<-p.done
+	}
+}
+
+// createGroup starts a new group at the end of the stream; an existing
+// group keeps its position.
+func (p *priceStream) createGroup(ctx context.Context) error {
+	err := p.client.XGroupCreateMkStream(ctx, p.stream, p.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return err
+	}
+	return nil
+}
+
+func (p *priceStream) fail(ctx context.Context, msg string, err error) {
+	if ctx.Err() != nil {
+		return
+	}
+	p.log.Error(msg, "stream", p.stream, "group", p.group, "error", err)
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(time.Second):
+	}
+}
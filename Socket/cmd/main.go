@@ -8,7 +8,9 @@ import (
 	"sync"
 
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/connsock"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/registry"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/svr"
 )
 
@@ -21,8 +23,20 @@ func main() {
 
 	connManager := connsock.NewConnectionManager(ctx, wg)
 
+	symbols := registry.New(exchange.NewBinance().(exchange.SymbolSource))
+	if path := getenv.GetString("SYMBOLS_FILE", ""); path != "" {
+		if err := symbols.LoadFile(path); err != nil {
+			slog.Error("Could not load symbols file", "error", err)
+			os.Exit(1)
+		}
+		slog.Info("📚 Symbol registry loaded from file", "path", path, "symbols", symbols.Len())
+	} else {
+		wg.Add(1)
+		go symbols.Run(ctx, wg)
+	}
+
 	wg.Add(1)
-	go svr.StartServer(wg, connManager, ctx)
+	go svr.StartServer(wg, connManager, symbols, ctx)
 
 	slog.Info("🚀 Server started", "port", getenv.GetString("PORT", ":5052"))
 
@@ -0,0 +1,227 @@
+package connsock
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+)
+
+// SlowConsumerPolicy decides what happens when the buffer of a subscriber
+// is full.
+type SlowConsumerPolicy string
+
+const (
+	// DropOldest discards the oldest queued message to make room.
+	DropOldest SlowConsumerPolicy = "drop_oldest"
+	// Disconnect closes the subscription; Err reports ErrSlowConsumer.
+	Disconnect SlowConsumerPolicy = "disconnect"
+)
+
+const defaultSubscriberBuffer = 256
+
+var ErrSlowConsumer = errors.New("subscriber is too slow, disconnected")
+
+func parsePolicy(value string) SlowConsumerPolicy {
+	switch policy := SlowConsumerPolicy(value); policy {
+	case DropOldest, Disconnect:
+		return policy
+	default:
+		slog.Warn("Unknown slow consumer policy, using default", "policy", value, "default", DropOldest)
+		return DropOldest
+	}
+}
+
+// SubscriberStats is a snapshot of how far a subscriber is behind. Lag is
+// the number of messages queued for it right now, MaxLag the highest lag
+// seen so far.
+type SubscriberStats struct {
+	ID        uint64 `json:"id"`
+	Stream    string `json:"stream"`
+	Lag       int    `json:"lag"`
+	MaxLag    int64  `json:"max_lag"`
+	Capacity  int    `json:"capacity"`
+	Delivered uint64 `json:"delivered"`
+	Dropped   uint64 `json:"dropped"`
+}
+
+// Subscription is the buffered channel of one consumer of a broadcaster.
+// C is closed by Close or by the Disconnect policy.
+type Subscription[T any] struct {
+	C <-chan T
+
+	id     uint64
+	ch     chan T
+	b      *broadcaster[T]
+	closed bool
+	err    error
+
+	published atomic.Uint64
+	dropped   atomic.Uint64
+	maxLag    atomic.Int64
+}
+
+func (s *Subscription[T]) Close() {
+	s.b.remove(s, nil)
+}
+
+// Err returns ErrSlowConsumer once the subscription was disconnected.
+func (s *Subscription[T]) Err() error {
+	s.b.mu.RLock()
+	defer s.b.mu.RUnlock()
+
+	return s.err
+}
+
+func (s *Subscription[T]) Stats() SubscriberStats {
+	lag := len(s.ch)
+	published := s.published.Load()
+	dropped := s.dropped.Load()
+
+	var delivered uint64
+	if pending := dropped + uint64(lag); published > pending {
+		delivered = published - pending
+	}
+
+	return SubscriberStats{
+		ID:        s.id,
+		Stream:    s.b.name,
+		Lag:       lag,
+		MaxLag:    s.maxLag.Load(),
+		Capacity:  cap(s.ch),
+		Delivered: delivered,
+		Dropped:   dropped,
+	}
+}
+
+// broadcaster fans the messages of one stream out to every subscriber.
+// publish never blocks: a full subscriber is handled by the policy.
+type broadcaster[T any] struct {
+	name   string
+	policy SlowConsumerPolicy
+	buffer int
+
+	mu     sync.RWMutex
+	subs   map[uint64]*Subscription[T]
+	nextID uint64
+
+	// onIdle is called once the last subscriber is gone.
+	onIdle func()
+}
+
+func newBroadcaster[T any](name string, policy SlowConsumerPolicy, buffer int) *broadcaster[T] {
+	return &broadcaster[T]{
+		name:   name,
+		policy: policy,
+		buffer: buffer,
+		subs:   make(map[uint64]*Subscription[T]),
+	}
+}
+
+func (b *broadcaster[T]) subscribe() *Subscription[T] {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	ch := make(chan T, b.buffer)
+	sub := &Subscription[T]{
+		C:  ch,
+		id: b.nextID,
+		ch: ch,
+		b:  b,
+	}
+	b.subs[sub.id] = sub
+	return sub
+}
+
+func (b *broadcaster[T]) remove(sub *Subscription[T], err error) {
+	b.mu.Lock()
+	if sub.closed {
+		b.mu.Unlock()
+		return
+	}
+	sub.closed = true
+	sub.err = err
+	delete(b.subs, sub.id)
+	close(sub.ch)
+	idle := len(b.subs) == 0
+	b.mu.Unlock()
+
+	if idle && b.onIdle != nil {
+		b.onIdle()
+	}
+}
+
+func (b *broadcaster[T]) publish(msg T) {
+	var slow []*Subscription[T]
+
+	b.mu.RLock()
+	for _, sub := range b.subs {
+		if !b.deliver(sub, msg) {
+			slow = append(slow, sub)
+		}
+	}
+	b.mu.RUnlock()
+
+	for _, sub := range slow {
+		slog.Warn("Disconnecting slow subscriber", "stream", b.name, "id", sub.id, "lag", len(sub.ch))
+		b.remove(sub, ErrSlowConsumer)
+	}
+}
+
+// deliver reports false when sub has to be disconnected.
+func (b *broadcaster[T]) deliver(sub *Subscription[T], msg T) bool {
+	sub.published.Add(1)
+
+	for {
+		select {
+		case sub.ch <- msg:
+			if lag := int64(len(sub.ch)); lag > sub.maxLag.Load() {
+				sub.maxLag.Store(lag)
+			}
+			return true
+		default:
+		}
+
+		if b.policy == Disconnect {
+			sub.dropped.Add(1)
+			return false
+		}
+
+		select {
+		case <-sub.ch:
+			sub.dropped.Add(1)
+		default:
+		}
+	}
+}
+
+// closeAll ends every subscription without calling onIdle.
+func (b *broadcaster[T]) closeAll() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for id, sub := range b.subs {
+		sub.closed = true
+		delete(b.subs, id)
+		close(sub.ch)
+	}
+}
+
+func (b *broadcaster[T]) size() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subs)
+}
+
+func (b *broadcaster[T]) stats() []SubscriberStats {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	stats := make([]SubscriberStats, 0, len(b.subs))
+	for _, sub := range b.subs {
+		stats = append(stats, sub.Stats())
+	}
+	return stats
+}
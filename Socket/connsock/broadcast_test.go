@@ -0,0 +1,105 @@
+package connsock
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBroadcaster(t *testing.T) {
+	t.Run("every_subscriber_sees_every_message", func(t *testing.T) {
+		b := newBroadcaster[int]("btcusdt", DropOldest, 4)
+		first, second := b.subscribe(), b.subscribe()
+
+		for i := 1; i <= 3; i++ {
+			b.publish(i)
+		}
+
+		for _, sub := range []*Subscription[int]{first, second} {
+			for want := 1; want <= 3; want++ {
+				if got := <-sub.C; got != want {
+					t.Errorf("Expected %d, got %d", want, got)
+				}
+			}
+		}
+	})
+
+	t.Run("drop_oldest_keeps_newest", func(t *testing.T) {
+		b := newBroadcaster[int]("btcusdt", DropOldest, 2)
+		sub := b.subscribe()
+
+		for i := 1; i <= 5; i++ {
+			b.publish(i)
+		}
+
+		stats := sub.Stats()
+		if stats.Lag != 2 || stats.Dropped != 3 || stats.MaxLag != 2 {
+			t.Errorf("Expected lag 2 with 3 dropped, got %+v", stats)
+		}
+		if got := <-sub.C; got != 4 {
+			t.Errorf("Expected oldest kept message to be 4, got %d", got)
+		}
+	})
+
+	t.Run("disconnect_closes_slow_subscriber", func(t *testing.T) {
+		b := newBroadcaster[int]("btcusdt", Disconnect, 1)
+		slow, fast := b.subscribe(), b.subscribe()
+
+		b.publish(1)
+		<-fast.C
+		b.publish(2)
+
+		<-slow.C
+		if _, ok := <-slow.C; ok {
+			t.Fatal("Expected slow subscriber to be closed")
+		}
+		if !errors.Is(slow.Err(), ErrSlowConsumer) {
+			t.Errorf("Expected ErrSlowConsumer, but got %v", slow.Err())
+		}
+		if got := <-fast.C; got != 2 {
+			t.Errorf("Expected fast subscriber to get 2, got %d", got)
+		}
+	})
+
+	t.Run("close_is_idempotent", func(t *testing.T) {
+		b := newBroadcaster[int]("btcusdt", DropOldest, 1)
+		sub := b.subscribe()
+
+		sub.Close()
+		sub.Close()
+		b.publish(1)
+
+		if len(b.stats()) != 0 || sub.Err() != nil {
+			t.Errorf("Expected no subscribers and no error, got %+v, %v", b.stats(), sub.Err())
+		}
+	})
+
+	t.Run("last_close_goes_idle", func(t *testing.T) {
+		b := newBroadcaster[int]("btcusdt", DropOldest, 1)
+		idle := 0
+		b.onIdle = func() { idle++ }
+		first, second := b.subscribe(), b.subscribe()
+
+		first.Close()
+		if idle != 0 {
+			t.Fatal("Expected broadcaster with a subscriber left not to go idle")
+		}
+		second.Close()
+		second.Close()
+		if idle != 1 {
+			t.Errorf("Expected one idle call, got %d", idle)
+		}
+	})
+
+	t.Run("close_all_ends_subscriptions", func(t *testing.T) {
+		b := newBroadcaster[int]("btcusdt", DropOldest, 1)
+		b.onIdle = func() { t.Error("Expected closeAll not to go idle") }
+		sub := b.subscribe()
+
+		b.closeAll()
+		sub.Close()
+
+		if _, ok := <-sub.C; ok || b.size() != 0 {
+			t.Error("Expected subscription to be closed")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package connsock
 
 import (
 	"context"
+	"errors"
 	"io"
 	"log/slog"
 	"math"
@@ -11,20 +12,28 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
 	"github.com/gorilla/websocket"
 )
 
-const (
-	EvTypeAggTrade = "@aggTrade"
-)
+var errNotConnected = errors.New("not connected")
+
+// retryAfter is how long a producer waits before trying again once all the
+// reconnect attempts have failed.
+const retryAfter = 30 * time.Second
 
 type socketProducer struct {
 	outputChan chan []byte
 
+	exchange      string
 	urlConnection string
 	readMsgError  chan error
 	conn          *websocket.Conn
 	mu            sync.RWMutex
+	writeMu       sync.Mutex
+
+	// subscriptions returns the frames sent after every (re)connect.
+	subscriptions func() [][]byte
 
 	readMsgCancel context.CancelFunc
 	readMsgWg     sync.WaitGroup
@@ -33,11 +42,13 @@ type socketProducer struct {
 	reconnectMu  sync.Mutex
 }
 
-func NewSocketProduecer(outChan chan []byte, url string, errChan chan error) *socketProducer {
+func NewSocketProduecer(outChan chan []byte, name string, stream exchange.Stream, errChan chan error) *socketProducer {
 	return &socketProducer{
 		outputChan:    outChan,
-		urlConnection: url,
+		exchange:      name,
+		urlConnection: stream.URL,
 		readMsgError:  errChan,
+		subscriptions: func() [][]byte { return stream.Subscribe },
 	}
 }
 
@@ -47,17 +58,9 @@ func (sp *socketProducer) Start(ctx context.Context, wg *sync.WaitGroup) {
 	reconnectTicker := time.NewTicker(23 * time.Hour)
 	defer reconnectTicker.Stop()
 
-	conn, err := sp.connect()
-	if err != nil || conn == nil {
-		conn, err := sp.reconnect(ctx)
-		if err != nil || conn == nil {
-			return
-		}
-	}
-
-	sp.mu.Lock()
-	sp.conn = conn
-	sp.mu.Unlock()
+	retryTimer := time.NewTimer(retryAfter)
+	retryTimer.Stop()
+	defer retryTimer.Stop()
 
 	defer func() {
 
@@ -73,16 +76,31 @@ func (sp *socketProducer) Start(ctx context.Context, wg *sync.WaitGroup) {
 		sp.mu.Unlock()
 	}()
 
-	sp.sendPong(conn)
-	sp.startReadMessage(ctx, conn)
+	conn, err := sp.connect()
+	if err != nil || conn == nil {
+		conn, err = sp.reconnect(ctx)
+	}
+	if err != nil || conn == nil || !sp.setup(ctx, conn) {
+		if ctx.Err() != nil {
+			return
+		}
+		retryTimer.Reset(retryAfter)
+	}
 
 	for {
 		select {
 		case <-ctx.Done():
-			slog.Info("Got interrupting singal, stop receiving binance api")
+			slog.Info("Got interrupting singal, stop receiving exchange api", "exchange", sp.exchange)
 			return
 		case <-reconnectTicker.C:
-			sp.doReconnect(ctx)
+			if !sp.doReconnect(ctx) {
+				retryTimer.Reset(retryAfter)
+			}
+
+		case <-retryTimer.C:
+			if !sp.doReconnect(ctx) {
+				retryTimer.Reset(retryAfter)
+			}
 
 		case err := <-sp.readMsgError:
 			sp.reconnectMu.Lock()
@@ -94,12 +112,54 @@ func (sp *socketProducer) Start(ctx context.Context, wg *sync.WaitGroup) {
 				continue
 			}
 
-			slog.Warn("⚠️ Connection was broken, try to connect again", "error", err)
-			sp.doReconnect(ctx)
+			slog.Warn("⚠️ Connection was broken, try to connect again", "exchange", sp.exchange, "error", err)
+			if !sp.doReconnect(ctx) {
+				retryTimer.Reset(retryAfter)
+			}
 		}
 	}
 }
 
+// setup stores conn, answers its pings, sends the subscribe frames of the
+// stream and starts reading. A failed subscription closes conn.
+func (sp *socketProducer) setup(ctx context.Context, conn *websocket.Conn) bool {
+	for _, frame := range sp.subscriptions() {
+		if err := sp.write(conn, frame); err != nil {
+			slog.Error("❌ Could not subscribe", "exchange", sp.exchange, "error", err)
+			conn.Close()
+			return false
+		}
+	}
+
+	sp.mu.Lock()
+	sp.conn = conn
+	sp.mu.Unlock()
+
+	sp.sendPong(conn)
+	sp.startReadMessage(ctx, conn)
+	return true
+}
+
+// send writes a control frame on the open connection.
+func (sp *socketProducer) send(frame []byte) error {
+	sp.mu.RLock()
+	conn := sp.conn
+	sp.mu.RUnlock()
+
+	if conn == nil {
+		return errNotConnected
+	}
+	return sp.write(conn, frame)
+}
+
+func (sp *socketProducer) write(conn *websocket.Conn, frame []byte) error {
+	sp.writeMu.Lock()
+	defer sp.writeMu.Unlock()
+
+	conn.SetWriteDeadline(time.Now().Add(5 * time.Second))
+	return conn.WriteMessage(websocket.TextMessage, frame)
+}
+
 func (sp *socketProducer) startReadMessage(parentCtx context.Context, conn *websocket.Conn) {
 	readCtx, cancel := context.WithCancel(parentCtx)
 
@@ -125,7 +185,7 @@ func (sp *socketProducer) stopReadMessage() {
 	}
 }
 
-func (sp *socketProducer) doReconnect(ctx context.Context) {
+func (sp *socketProducer) doReconnect(ctx context.Context) bool {
 	sp.reconnectMu.Lock()
 	sp.reconnecting = true
 	sp.reconnectMu.Unlock()
@@ -147,18 +207,16 @@ func (sp *socketProducer) doReconnect(ctx context.Context) {
 
 	conn, err := sp.reconnect(ctx)
 	if err != nil || conn == nil {
-		slog.Error("Failed to reconnect")
-		return
+		slog.Error("Failed to reconnect", "exchange", sp.exchange, "retry_after", retryAfter)
+		return false
 	}
 
-	sp.mu.Lock()
-	sp.conn = conn
-	sp.mu.Unlock()
-
-	sp.sendPong(conn)
-	sp.startReadMessage(ctx, conn)
+	if !sp.setup(ctx, conn) {
+		return false
+	}
 
-	slog.Info("✅ Reconnection completed successfully")
+	slog.Info("✅ Reconnection completed successfully", "exchange", sp.exchange)
+	return true
 }
 
 func (sp *socketProducer) readMessage(ctx context.Context, conn *websocket.Conn) {
@@ -194,8 +252,7 @@ func (sp *socketProducer) readMessage(ctx context.Context, conn *websocket.Conn)
 		case <-ctx.Done():
 			slog.Info("'readMessage' stopped due to context cancellation ")
 			return
-		default:
-			sp.outputChan <- msg
+		case sp.outputChan <- msg:
 		}
 	}
 }
@@ -234,7 +291,7 @@ func (sp *socketProducer) reconnect(ctx context.Context) (*websocket.Conn, error
 		}
 	}
 
-	slog.Error("❌ Could not reconnect to binance api after all the retries")
+	slog.Error("❌ Could not reconnect to exchange api after all the retries", "exchange", sp.exchange)
 	return nil, err
 }
 
@@ -279,7 +336,8 @@ func (sp *socketProducer) setURL(url string) {
 
 func (sp *socketProducer) logHandshakeFailure(err error, resp *http.Response, url string) {
 	if resp == nil {
-		slog.Error("❌ Could not connect to binance",
+		slog.Error("❌ Could not connect to exchange",
+			"exchange", sp.exchange,
 			"error", err,
 			"url", url,
 		)
@@ -295,6 +353,7 @@ func (sp *socketProducer) logHandshakeFailure(err error, resp *http.Response, ur
 	}
 
 	slog.Error("❌ WebSocket handshake failed",
+		"exchange", sp.exchange,
 		"error", err,
 		"url", url,
 		"status", resp.Status,
@@ -336,11 +395,11 @@ func (sp *socketProducer) sendPong(conn *websocket.Conn) {
 		)
 
 		if err != nil {
-			slog.Error("❌ Could not send Pong to binance", "error", err)
+			slog.Error("❌ Could not send Pong", "exchange", sp.exchange, "error", err)
 			return err
 		}
 
-		slog.Info("✅ Send Pong to binance successfully")
+		slog.Info("✅ Send Pong successfully", "exchange", sp.exchange)
 		return nil
 	})
 }
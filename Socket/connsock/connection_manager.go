@@ -3,75 +3,430 @@ package connsock
 import (
 	"context"
 	"log/slog"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/lib/getenv"
 )
 
+const (
+	defaultLagReportInterval = 30 * time.Second
+	defaultGracePeriod       = time.Minute
+)
+
+// ConnectionManager owns the upstream sockets. They are opened for the
+// first subscriber of a symbol and closed once it had no subscriber for
+// the grace period.
 type ConnectionManager struct {
-	connections map[string]*socketProducer
-	mu          sync.RWMutex
-	mainCtx     context.Context
-	mainWg      *sync.WaitGroup
+	muxes      map[string][]*muxConn
+	feeds      map[string]*tradeFeed
+	raw        map[string]*rawFeed
+	books      exchange.BookSource
+	klines     exchange.KlineSource
+	adapters   []exchange.Adapter
+	staleAfter time.Duration
+	grace      time.Duration
+	policy     SlowConsumerPolicy
+	buffer     int
+	mu         sync.RWMutex
+	mainCtx    context.Context
+	mainWg     *sync.WaitGroup
+}
+
+// rawFeed is a Binance socket whose frames are passed on undecoded, such
+// as the all-market miniTicker or the depth of a symbol.
+type rawFeed struct {
+	name  string
+	subs  *broadcaster[[]byte]
+	group *group
+	idle  uint64
 }
 
 func NewConnectionManager(ctx context.Context, wg *sync.WaitGroup) *ConnectionManager {
-	return &ConnectionManager{
-		connections: make(map[string]*socketProducer),
-		mainCtx:     ctx,
-		mainWg:      wg,
+	staleAfter, err := time.ParseDuration(getenv.GetString("FEED_STALE_AFTER", defaultStaleAfter.String()))
+	if err != nil || staleAfter <= 0 {
+		slog.Warn("Invalid FEED_STALE_AFTER, using default", "default", defaultStaleAfter)
+		staleAfter = defaultStaleAfter
+	}
+
+	buffer, err := strconv.Atoi(getenv.GetString("SUBSCRIBER_BUFFER", strconv.Itoa(defaultSubscriberBuffer)))
+	if err != nil || buffer <= 0 {
+		slog.Warn("Invalid SUBSCRIBER_BUFFER, using default", "default", defaultSubscriberBuffer)
+		buffer = defaultSubscriberBuffer
+	}
+
+	reportEvery, err := time.ParseDuration(getenv.GetString("LAG_REPORT_INTERVAL", defaultLagReportInterval.String()))
+	if err != nil || reportEvery <= 0 {
+		slog.Warn("Invalid LAG_REPORT_INTERVAL, using default", "default", defaultLagReportInterval)
+		reportEvery = defaultLagReportInterval
+	}
+
+	grace, err := time.ParseDuration(getenv.GetString("SYMBOL_GRACE_PERIOD", defaultGracePeriod.String()))
+	if err != nil || grace < 0 {
+		slog.Warn("Invalid SYMBOL_GRACE_PERIOD, using default", "default", defaultGracePeriod)
+		grace = defaultGracePeriod
+	}
+
+	cm := &ConnectionManager{
+		muxes:      make(map[string][]*muxConn),
+		feeds:      make(map[string]*tradeFeed),
+		raw:        make(map[string]*rawFeed),
+		books:      exchange.NewBinance().(exchange.BookSource),
+		klines:     exchange.NewBinance().(exchange.KlineSource),
+		adapters:   loadAdapters(getenv.GetString("EXCHANGES", "binance,coinbase,kraken")),
+		staleAfter: staleAfter,
+		grace:      grace,
+		policy:     parsePolicy(getenv.GetString("SLOW_CONSUMER_POLICY", string(DropOldest))),
+		buffer:     buffer,
+		mainCtx:    ctx,
+		mainWg:     wg,
 	}
+
+	wg.Add(1)
+	go cm.reportLag(ctx, wg, reportEvery)
+
+	return cm
 }
 
-func (cm *ConnectionManager) GetOrCreateConnection(symbol string) <-chan []byte {
+// loadAdapters builds the adapters listed in names, highest priority first.
+func loadAdapters(names string) []exchange.Adapter {
+	var adapters []exchange.Adapter
+	seen := make(map[string]struct{})
+
+	for _, name := range strings.Split(names, ",") {
+		adapter, err := exchange.New(name)
+		if err != nil {
+			slog.Warn("Skipping exchange", "error", err)
+			continue
+		}
+		if _, ok := seen[adapter.Name()]; ok {
+			continue
+		}
+		seen[adapter.Name()] = struct{}{}
+		adapters = append(adapters, adapter)
+	}
+
+	if len(adapters) == 0 {
+		slog.Warn("No exchange configured, falling back to binance")
+		adapters = append(adapters, exchange.NewBinance())
+	}
+	return adapters
+}
+
+// Subscribe streams the validated trades of symbol, taken from the best
+// venue currently trading it. Every subscription gets its own buffer and
+// must be closed once the consumer is done.
+func (cm *ConnectionManager) Subscribe(symbol string) *Subscription[exchange.Message] {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	if conn, exists := cm.connections[symbol]; exists {
+	symbol = strings.ToLower(symbol)
+
+	if feed, exists := cm.feeds[symbol]; exists {
 		slog.Info("Reusing existing connection", "symbol", symbol)
-		return conn.outputChan
+		feed.idle++
+		return feed.subs.subscribe()
 	}
 
 	slog.Info("Creating new connection", "symbol", symbol)
 
-	outputChan := make(chan []byte, 100)
-	msgChan := make(chan error, 1)
-	url := getenv.GetString("AGGTRADE_URL", "I don't know") + symbol + EvTypeAggTrade
+	feed := &tradeFeed{
+		symbol:     symbol,
+		in:         make(chan venueMessage, 100),
+		subs:       newBroadcaster[exchange.Message](symbol, cm.policy, cm.buffer),
+		staleAfter: cm.staleAfter,
+		group:      newGroup(cm.mainCtx),
+	}
+	feed.subs.onIdle = func() { cm.feedIdle(feed) }
+
+	var venues []string
+	for priority, adapter := range cm.adapters {
+		feed.venues = append(feed.venues, adapter.Name())
+
+		if mux, ok := adapter.(exchange.Multiplexer); ok {
+			cm.subscribeMux(priority, adapter, mux, symbol)
+			venues = append(venues, adapter.Name())
+			continue
+		}
+
+		stream, ok := adapter.TradeStream(symbol)
+		if !ok {
+			continue
+		}
+
+		frames := make(chan []byte, 100)
+		socketConn := NewSocketProduecer(frames, adapter.Name(), stream, make(chan error, 1))
+		route := func(string) *tradeFeed { return feed }
 
-	socketConn := NewSocketProduecer(outputChan, url, msgChan)
+		feed.group.start(socketConn.Start)
+		feed.group.start(func(ctx context.Context, wg *sync.WaitGroup) {
+			decode(ctx, wg, adapter, priority, frames, route)
+		})
 
-	cm.mainWg.Add(1)
-	go socketConn.Start(cm.mainCtx, cm.mainWg)
+		venues = append(venues, adapter.Name())
+	}
+
+	slog.Info("Streaming trades", "symbol", symbol, "exchanges", venues)
+
+	feed.group.start(feed.run)
+
+	cm.feeds[symbol] = feed
+	return feed.subs.subscribe()
+}
 
-	cm.connections[symbol] = socketConn
-	return outputChan
+// subscribeMux adds symbol to a shared socket of the venue, opening a new
+// one once all are full. Called with cm.mu held.
+func (cm *ConnectionManager) subscribeMux(priority int, adapter exchange.Adapter, mux exchange.Multiplexer, symbol string) {
+	for _, mc := range cm.muxes[adapter.Name()] {
+		if mc.has(symbol) || mc.add(symbol) {
+			return
+		}
+	}
+
+	mc := newMuxConn(mux)
+	mc.add(symbol)
+
+	frames := make(chan []byte, 100)
+	mc.producer = NewSocketProduecer(frames, adapter.Name(), mux.TradeMux(), make(chan error, 1))
+	mc.producer.subscriptions = mc.subscriptions
+	mc.group = newGroup(cm.mainCtx)
+
+	slog.Info("Opening combined stream", "exchange", adapter.Name(), "sockets", len(cm.muxes[adapter.Name()])+1)
+
+	mc.group.start(mc.producer.Start)
+	mc.group.start(mc.run)
+	mc.group.start(func(ctx context.Context, wg *sync.WaitGroup) {
+		decode(ctx, wg, adapter, priority, frames, cm.feed)
+	})
+
+	cm.muxes[adapter.Name()] = append(cm.muxes[adapter.Name()], mc)
+}
+
+// unsubscribeMux removes symbol from the shared sockets and returns the
+// ones left without symbols, which the caller stops. Called with cm.mu held.
+func (cm *ConnectionManager) unsubscribeMux(symbol string) []*muxConn {
+	var empty []*muxConn
+
+	for name, conns := range cm.muxes {
+		kept := conns[:0]
+		for _, mc := range conns {
+			if mc.has(symbol) {
+				mc.remove(symbol)
+			}
+			if mc.empty() {
+				empty = append(empty, mc)
+				continue
+			}
+			kept = append(kept, mc)
+		}
+		cm.muxes[name] = kept
+	}
+	return empty
 }
 
-func (cm *ConnectionManager) GetMiniTickerConnection() <-chan []byte {
+func (cm *ConnectionManager) feed(symbol string) *tradeFeed {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return cm.feeds[symbol]
+}
+
+// feedIdle closes the sockets of feed unless it gets a subscriber within
+// the grace period. The idle counter tells a later subscriber apart.
+func (cm *ConnectionManager) feedIdle(feed *tradeFeed) {
+	cm.mu.Lock()
+	feed.idle++
+	idle := feed.idle
+	cm.mu.Unlock()
+
+	slog.Info("No subscribers left, closing after grace period", "symbol", feed.symbol, "grace", cm.grace)
+
+	time.AfterFunc(cm.grace, func() {
+		cm.mu.Lock()
+		if cm.feeds[feed.symbol] != feed || feed.idle != idle || feed.subs.size() > 0 {
+			cm.mu.Unlock()
+			return
+		}
+		delete(cm.feeds, feed.symbol)
+		empty := cm.unsubscribeMux(feed.symbol)
+		cm.mu.Unlock()
+
+		feed.group.stop()
+		for _, mc := range empty {
+			mc.group.stop()
+		}
+		slog.Info("Closed idle connection", "symbol", feed.symbol, "closed_combined_streams", len(empty))
+	})
+}
+
+// SubscribeMiniTicker streams the raw all-market miniTicker array of
+// Binance, the only venue offering every ticker on one socket.
+func (cm *ConnectionManager) SubscribeMiniTicker() *Subscription[[]byte] {
+	stream, _ := exchange.NewBinance().TickerStream(nil)
+	return cm.subscribeRaw("miniTicker", stream)
+}
+
+// SubscribeDepth streams the raw depth diffs of symbol on Binance.
+func (cm *ConnectionManager) SubscribeDepth(symbol string) *Subscription[[]byte] {
+	symbol = strings.ToLower(symbol)
+	return cm.subscribeRaw(symbol+"@depth", cm.books.DepthStream(symbol))
+}
+
+// SubscribeBookTicker streams the raw best bid and ask of symbol on Binance.
+func (cm *ConnectionManager) SubscribeBookTicker(symbol string) *Subscription[[]byte] {
+	symbol = strings.ToLower(symbol)
+	return cm.subscribeRaw(symbol+"@bookTicker", cm.books.BookTickerStream(symbol))
+}
+
+// SubscribeKline streams the raw candles of symbol over interval on
+// Binance.
+func (cm *ConnectionManager) SubscribeKline(symbol, interval string) *Subscription[[]byte] {
+	symbol = strings.ToLower(symbol)
+	return cm.subscribeRaw(symbol+"@kline_"+interval, cm.klines.KlineStream(symbol, interval))
+}
+
+// subscribeRaw shares one Binance socket named name between subscribers
+// of its undecoded frames.
+func (cm *ConnectionManager) subscribeRaw(name string, stream exchange.Stream) *Subscription[[]byte] {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	key := "miniTicker"
-	if conn, exists := cm.connections[key]; exists {
-		slog.Info("Reusing existing miniTicker connection")
-		return conn.outputChan
+	if feed, exists := cm.raw[name]; exists {
+		slog.Info("Reusing existing connection", "stream", name)
+		feed.idle++
+		return feed.subs.subscribe()
 	}
 
-	slog.Info("Creating new miniTicker connection")
+	slog.Info("Creating new connection", "stream", name)
 
 	outputChan := make(chan []byte, 100)
 	msgChan := make(chan error, 1)
-	url := getenv.GetString("MINITICKER_URL", "I don't care")
 
-	socketConn := NewSocketProduecer(outputChan, url, msgChan)
+	socketConn := NewSocketProduecer(outputChan, exchange.Binance, stream, msgChan)
+	feed := &rawFeed{
+		name:  name,
+		subs:  newBroadcaster[[]byte](name, cm.policy, cm.buffer),
+		group: newGroup(cm.mainCtx),
+	}
+	feed.subs.onIdle = func() { cm.rawIdle(feed) }
+
+	feed.group.start(socketConn.Start)
+	feed.group.start(func(ctx context.Context, wg *sync.WaitGroup) {
+		defer wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case frame := <-outputChan:
+				feed.subs.publish(frame)
+			}
+		}
+	})
+
+	cm.raw[name] = feed
+	return feed.subs.subscribe()
+}
+
+func (cm *ConnectionManager) rawIdle(feed *rawFeed) {
+	cm.mu.Lock()
+	feed.idle++
+	idle := feed.idle
+	cm.mu.Unlock()
+
+	slog.Info("No subscribers left, closing after grace period", "stream", feed.name, "grace", cm.grace)
 
-	cm.mainWg.Add(1)
-	go socketConn.Start(cm.mainCtx, cm.mainWg)
+	time.AfterFunc(cm.grace, func() {
+		cm.mu.Lock()
+		if cm.raw[feed.name] != feed || feed.idle != idle || feed.subs.size() > 0 {
+			cm.mu.Unlock()
+			return
+		}
+		delete(cm.raw, feed.name)
+		cm.mu.Unlock()
 
-	cm.connections[key] = socketConn
-	return outputChan
+		feed.group.stop()
+		slog.Info("Closed idle connection", "stream", feed.name)
+	})
 }
 
+// Stats returns the lag of every subscriber.
+func (cm *ConnectionManager) Stats() []SubscriberStats {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	var stats []SubscriberStats
+	for _, feed := range cm.feeds {
+		stats = append(stats, feed.subs.stats()...)
+	}
+	for _, feed := range cm.raw {
+		stats = append(stats, feed.subs.stats()...)
+	}
+	return stats
+}
+
+// reportLag logs the subscribers that are behind or lost messages.
+func (cm *ConnectionManager) reportLag(ctx context.Context, wg *sync.WaitGroup, every time.Duration) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(every)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, stats := range cm.Stats() {
+				if stats.Lag == 0 && stats.Dropped == 0 {
+					continue
+				}
+				slog.Info("Subscriber lag",
+					"stream", stats.Stream,
+					"id", stats.ID,
+					"lag", stats.Lag,
+					"max_lag", stats.MaxLag,
+					"capacity", stats.Capacity,
+					"delivered", stats.Delivered,
+					"dropped", stats.Dropped,
+				)
+			}
+		}
+	}
+}
+
+// CloseAll stops every socket, waits for their goroutines and ends the
+// subscriptions still open.
 func (cm *ConnectionManager) CloseAll() {
-	slog.Info("Closing all connections", "count", len(cm.connections))
+	cm.mu.Lock()
+	var groups []*group
+	for _, feed := range cm.feeds {
+		groups = append(groups, feed.group)
+		feed.subs.closeAll()
+	}
+	for _, conns := range cm.muxes {
+		for _, mc := range conns {
+			groups = append(groups, mc.group)
+		}
+	}
+	for _, feed := range cm.raw {
+		groups = append(groups, feed.group)
+		feed.subs.closeAll()
+	}
+	clear(cm.feeds)
+	clear(cm.muxes)
+	clear(cm.raw)
+	cm.mu.Unlock()
+
+	slog.Info("Closing all connections", "count", len(groups))
+
+	for _, g := range groups {
+		g.cancel()
+	}
+	for _, g := range groups {
+		g.wg.Wait()
+	}
+	slog.Info("All connections closed")
 }
@@ -0,0 +1,124 @@
+package connsock
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+)
+
+const defaultStaleAfter = 30 * time.Second
+
+type venueMessage struct {
+	priority int
+	msg      exchange.Message
+}
+
+// tradeFeed merges the trades of one symbol from every venue listing it.
+// Venues are ranked by their order in EXCHANGES: trades of a venue are
+// forwarded only while no higher ranked venue has traded within staleAfter,
+// so the feed fails over when a venue goes quiet and back once it recovers.
+type tradeFeed struct {
+	symbol     string
+	venues     []string
+	in         chan venueMessage
+	subs       *broadcaster[exchange.Message]
+	staleAfter time.Duration
+
+	// group runs the sockets of venues without multiplexing and run itself.
+	group *group
+	// idle changes whenever the feed gains a subscriber or loses the last.
+	idle uint64
+}
+
+func (f *tradeFeed) run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	lastSeen := make([]time.Time, len(f.venues))
+	active := -1
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case vm := <-f.in:
+			now := time.Now()
+			lastSeen[vm.priority] = now
+
+			if f.preferred(lastSeen, vm.priority, now) {
+				continue
+			}
+
+			if active != vm.priority {
+				slog.Info("Switching price source",
+					"symbol", f.symbol,
+					"exchange", f.venues[vm.priority],
+				)
+				active = vm.priority
+			}
+
+			msg := vm.msg
+			msg.Symbol = f.symbol
+			f.subs.publish(msg)
+		}
+	}
+}
+
+// preferred reports whether a venue ranked above priority is still live.
+func (f *tradeFeed) preferred(lastSeen []time.Time, priority int, now time.Time) bool {
+	for i := 0; i < priority; i++ {
+		if !lastSeen[i].IsZero() && now.Sub(lastSeen[i]) < f.staleAfter {
+			return true
+		}
+	}
+	return false
+}
+
+// decode turns the frames of one venue into trades, handed to the feed
+// route returns for their symbol. Trades of symbols without a feed are
+// dropped.
+func decode(
+	ctx context.Context,
+	wg *sync.WaitGroup,
+	adapter exchange.Adapter,
+	priority int,
+	frames <-chan []byte,
+	route func(symbol string) *tradeFeed,
+) {
+	defer wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case frame := <-frames:
+			messages, err := adapter.Decode(frame)
+			if err != nil {
+				slog.Warn("Could not decode frame", "exchange", adapter.Name(), "error", err)
+				continue
+			}
+
+			for _, msg := range messages {
+				if msg.Kind != exchange.KindTrade {
+					continue
+				}
+				if err := msg.Validate(); err != nil {
+					slog.Warn("Dropping invalid trade", "exchange", adapter.Name(), "symbol", msg.Symbol, "error", err)
+					continue
+				}
+
+				feed := route(msg.Symbol)
+				if feed == nil {
+					continue
+				}
+				select {
+				case <-ctx.Done():
+					return
+				case feed.in <- venueMessage{priority: priority, msg: msg}:
+				}
+			}
+		}
+	}
+}
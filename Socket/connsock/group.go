@@ -0,0 +1,31 @@
+package connsock
+
+import (
+	"context"
+	"sync"
+)
+
+// group ties the goroutines serving one upstream resource, such as the
+// sockets of a symbol, to their own context so they can be torn down
+// without stopping the others.
+type group struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newGroup(parent context.Context) *group {
+	ctx, cancel := context.WithCancel(parent)
+	return &group{ctx: ctx, cancel: cancel}
+}
+
+func (g *group) start(fn func(ctx context.Context, wg *sync.WaitGroup)) {
+	g.wg.Add(1)
+	go fn(g.ctx, &g.wg)
+}
+
+// stop cancels the goroutines and waits until they are done.
+func (g *group) stop() {
+	g.cancel()
+	g.wg.Wait()
+}
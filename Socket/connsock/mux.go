@@ -0,0 +1,175 @@
+package connsock
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+)
+
+// controlInterval paces the SUBSCRIBE/UNSUBSCRIBE frames of one socket;
+// Binance drops connections sending more than 5 messages a second.
+const controlInterval = 500 * time.Millisecond
+
+// muxConn is one socket carrying the trades of many symbols of a venue.
+// Symbols are added and removed while it is open; changes are batched into
+// at most one subscribe and one unsubscribe frame per controlInterval.
+type muxConn struct {
+	mux      exchange.Multiplexer
+	producer *socketProducer
+	group    *group
+
+	mu      sync.Mutex
+	symbols map[string]struct{}
+	added   map[string]struct{}
+	removed map[string]struct{}
+	nextID  int64
+}
+
+func newMuxConn(mux exchange.Multiplexer) *muxConn {
+	return &muxConn{
+		mux:     mux,
+		symbols: make(map[string]struct{}),
+		added:   make(map[string]struct{}),
+		removed: make(map[string]struct{}),
+	}
+}
+
+func (mc *muxConn) has(symbol string) bool {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	_, ok := mc.symbols[symbol]
+	return ok
+}
+
+func (mc *muxConn) empty() bool {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	return len(mc.symbols) == 0
+}
+
+// add reports false when the socket is full.
+func (mc *muxConn) add(symbol string) bool {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, ok := mc.symbols[symbol]; ok {
+		return true
+	}
+	if len(mc.symbols) >= mc.mux.MaxTradeStreams() {
+		return false
+	}
+
+	mc.symbols[symbol] = struct{}{}
+	mc.added[symbol] = struct{}{}
+	delete(mc.removed, symbol)
+	return true
+}
+
+func (mc *muxConn) remove(symbol string) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	if _, ok := mc.symbols[symbol]; !ok {
+		return
+	}
+
+	delete(mc.symbols, symbol)
+	delete(mc.added, symbol)
+	mc.removed[symbol] = struct{}{}
+}
+
+// subscriptions subscribes every symbol at once after a (re)connect, which
+// makes the pending changes moot.
+func (mc *muxConn) subscriptions() [][]byte {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	clear(mc.added)
+	clear(mc.removed)
+
+	if len(mc.symbols) == 0 {
+		return nil
+	}
+	mc.nextID++
+	return [][]byte{mc.mux.SubscribeTrades(mc.nextID, sortedKeys(mc.symbols))}
+}
+
+func (mc *muxConn) run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	ticker := time.NewTicker(controlInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			mc.flush()
+		}
+	}
+}
+
+func (mc *muxConn) flush() {
+	mc.mu.Lock()
+	added := sortedKeys(mc.added)
+	removed := sortedKeys(mc.removed)
+	clear(mc.added)
+	clear(mc.removed)
+	mc.mu.Unlock()
+
+	if len(removed) > 0 {
+		if err := mc.producer.send(mc.mux.UnsubscribeTrades(mc.id(), removed)); err != nil {
+			mc.requeue(removed, false)
+		}
+	}
+	if len(added) > 0 {
+		if err := mc.producer.send(mc.mux.SubscribeTrades(mc.id(), added)); err != nil {
+			mc.requeue(added, true)
+			return
+		}
+		slog.Info("Subscribed trades", "exchange", mc.producer.exchange, "symbols", added)
+	}
+}
+
+// requeue keeps the changes a closed socket could not take for the next
+// flush, unless they were reverted meanwhile. A reconnect resubscribes
+// everything anyway.
+func (mc *muxConn) requeue(symbols []string, subscribed bool) {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	for _, symbol := range symbols {
+		if _, ok := mc.symbols[symbol]; ok != subscribed {
+			continue
+		}
+		if subscribed {
+			mc.added[symbol] = struct{}{}
+		} else {
+			mc.removed[symbol] = struct{}{}
+		}
+	}
+}
+
+func (mc *muxConn) id() int64 {
+	mc.mu.Lock()
+	defer mc.mu.Unlock()
+
+	mc.nextID++
+	return mc.nextID
+}
+
+func sortedKeys(set map[string]struct{}) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,362 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/lib/getenv"
+)
+
+const Binance = "binance"
+
+// defaultMaxStreams stays well below the 1024 streams Binance accepts per
+// connection, so a reconnect resubscribes a bounded number of symbols.
+const defaultMaxStreams = 200
+
+type binance struct {
+	tradeURL   string
+	tickerURL  string
+	streamURL  string
+	restURL    string
+	maxStreams int
+	http       *http.Client
+}
+
+// NewBinance streams trades of many symbols over combined-stream sockets
+// and tickers from the all-market miniTicker socket.
+func NewBinance() Adapter {
+	maxStreams, err := strconv.Atoi(getenv.GetString("BINANCE_MAX_STREAMS", strconv.Itoa(defaultMaxStreams)))
+	if err != nil || maxStreams <= 0 || maxStreams > 1024 {
+		maxStreams = defaultMaxStreams
+	}
+
+	return &binance{
+		tradeURL:   getenv.GetString("AGGTRADE_URL", "wss://stream.binance.com:443/ws/"),
+		tickerURL:  getenv.GetString("MINITICKER_URL", "wss://stream.binance.com:443/ws/!miniTicker@arr"),
+		streamURL:  getenv.GetString("BINANCE_STREAM_URL", "wss://stream.binance.com:443/stream"),
+		restURL:    getenv.GetString("BINANCE_REST_URL", "https://api.binance.com"),
+		maxStreams: maxStreams,
+		http:       &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (b *binance) Name() string {
+	return Binance
+}
+
+func (b *binance) TradeStream(symbol string) (Stream, bool) {
+	return Stream{URL: b.tradeURL + strings.ToLower(symbol) + "@aggTrade"}, true
+}
+
+func (b *binance) TradeMux() Stream {
+	return Stream{URL: b.streamURL}
+}
+
+func (b *binance) SubscribeTrades(id int64, symbols []string) []byte {
+	return b.control("SUBSCRIBE", id, symbols)
+}
+
+func (b *binance) UnsubscribeTrades(id int64, symbols []string) []byte {
+	return b.control("UNSUBSCRIBE", id, symbols)
+}
+
+func (b *binance) MaxTradeStreams() int {
+	return b.maxStreams
+}
+
+func (b *binance) control(method string, id int64, symbols []string) []byte {
+	params := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		params = append(params, strings.ToLower(symbol)+"@aggTrade")
+	}
+
+	frame, _ := json.Marshal(map[string]any{
+		"method": method,
+		"params": params,
+		"id":     id,
+	})
+	return frame
+}
+
+// TickerStream only supports the all-market stream.
+func (b *binance) TickerStream(symbols []string) (Stream, bool) {
+	if symbols != nil {
+		return Stream{}, false
+	}
+	return Stream{URL: b.tickerURL}, true
+}
+
+// binanceEnvelope wraps the payloads of a combined stream. Replies to
+// control frames carry the id and either a result or an error instead.
+type binanceEnvelope struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+	Error  *struct {
+		Code int    `json:"code"`
+		Msg  string `json:"msg"`
+	} `json:"error"`
+}
+
+type binanceAggTrade struct {
+	EventType    string `json:"e"`
+	EventTime    int64  `json:"E"`
+	Symbol       string `json:"s"`
+	TradeID      int64  `json:"a"`
+	Price        string `json:"p"`
+	Quantity     string `json:"q"`
+	FirstTradeID int64  `json:"f"`
+	LastTradeID  int64  `json:"l"`
+	TradeTime    int64  `json:"T"`
+	BuyerMaker   bool   `json:"m"`
+	Ignore       bool   `json:"M"`
+}
+
+type binanceMiniTicker struct {
+	EventType   string `json:"e"`
+	EventTime   int64  `json:"E"`
+	Symbol      string `json:"s"`
+	Close       string `json:"c"`
+	Open        string `json:"o"`
+	High        string `json:"h"`
+	Low         string `json:"l"`
+	Volume      string `json:"v"`
+	QuoteVolume string `json:"q"`
+}
+
+func (b *binance) Decode(frame []byte) ([]Message, error) {
+	if len(frame) > 0 && frame[0] == '[' {
+		var tickers []binanceMiniTicker
+		if err := json.Unmarshal(frame, &tickers); err != nil {
+			return nil, err
+		}
+
+		messages := make([]Message, 0, len(tickers))
+		for _, t := range tickers {
+			messages = append(messages, Message{
+				Exchange:    Binance,
+				Kind:        KindTicker,
+				Symbol:      strings.ToLower(t.Symbol),
+				Time:        time.UnixMilli(t.EventTime).UTC(),
+				Price:       t.Close,
+				Open:        t.Open,
+				High:        t.High,
+				Low:         t.Low,
+				Volume:      t.Volume,
+				QuoteVolume: t.QuoteVolume,
+			})
+		}
+		return messages, nil
+	}
+
+	var envelope binanceEnvelope
+	if err := json.Unmarshal(frame, &envelope); err != nil {
+		return nil, err
+	}
+	if envelope.Error != nil {
+		return nil, &FeedError{Exchange: Binance, Message: envelope.Error.Msg}
+	}
+	if envelope.Stream != "" {
+		return b.Decode(envelope.Data)
+	}
+
+	var trade binanceAggTrade
+	if err := json.Unmarshal(frame, &trade); err != nil {
+		return nil, err
+	}
+	if trade.EventType != "aggTrade" {
+		return nil, nil
+	}
+
+	return []Message{{
+		Exchange: Binance,
+		Kind:     KindTrade,
+		Symbol:   strings.ToLower(trade.Symbol),
+		Time:     time.UnixMilli(trade.TradeTime).UTC(),
+		Price:    trade.Price,
+		Quantity: trade.Quantity,
+
+		TradeID:      trade.TradeID,
+		FirstTradeID: trade.FirstTradeID,
+		LastTradeID:  trade.LastTradeID,
+		BuyerMaker:   trade.BuyerMaker,
+	}}, nil
+}
+
+func (b *binance) DepthStream(symbol string) Stream {
+	return Stream{URL: b.tradeURL + strings.ToLower(symbol) + "@depth@100ms"}
+}
+
+func (b *binance) BookTickerStream(symbol string) Stream {
+	return Stream{URL: b.tradeURL + strings.ToLower(symbol) + "@bookTicker"}
+}
+
+type binanceDepthUpdate struct {
+	EventType     string      `json:"e"`
+	EventTime     int64       `json:"E"`
+	Symbol        string      `json:"s"`
+	FirstUpdateID int64       `json:"U"`
+	FinalUpdateID int64       `json:"u"`
+	Bids          [][2]string `json:"b"`
+	Asks          [][2]string `json:"a"`
+}
+
+type binanceBookTicker struct {
+	UpdateID    int64  `json:"u"`
+	Symbol      string `json:"s"`
+	BidPrice    string `json:"b"`
+	BidQuantity string `json:"B"`
+	AskPrice    string `json:"a"`
+	AskQuantity string `json:"A"`
+}
+
+type binanceDepthSnapshot struct {
+	LastUpdateID int64       `json:"lastUpdateId"`
+	Bids         [][2]string `json:"bids"`
+	Asks         [][2]string `json:"asks"`
+	Code         int         `json:"code"`
+	Msg          string      `json:"msg"`
+}
+
+func (b *binance) DecodeDepth(frame []byte) (DepthUpdate, error) {
+	var update binanceDepthUpdate
+	if err := json.Unmarshal(frame, &update); err != nil {
+		return DepthUpdate{}, err
+	}
+	if update.EventType != "depthUpdate" {
+		return DepthUpdate{}, fmt.Errorf("unexpected depth event %q", update.EventType)
+	}
+
+	return DepthUpdate{
+		Symbol:        strings.ToLower(update.Symbol),
+		EventTime:     update.EventTime,
+		FirstUpdateID: update.FirstUpdateID,
+		FinalUpdateID: update.FinalUpdateID,
+		Bids:          binanceLevels(update.Bids),
+		Asks:          binanceLevels(update.Asks),
+	}, nil
+}
+
+func (b *binance) DecodeBookTicker(frame []byte) (BookTicker, error) {
+	var ticker binanceBookTicker
+	if err := json.Unmarshal(frame, &ticker); err != nil {
+		return BookTicker{}, err
+	}
+	if ticker.Symbol == "" {
+		return BookTicker{}, fmt.Errorf("bookTicker without symbol")
+	}
+
+	return BookTicker{
+		Symbol:      strings.ToLower(ticker.Symbol),
+		UpdateID:    ticker.UpdateID,
+		BidPrice:    ticker.BidPrice,
+		BidQuantity: ticker.BidQuantity,
+		AskPrice:    ticker.AskPrice,
+		AskQuantity: ticker.AskQuantity,
+	}, nil
+}
+
+// DepthSnapshot fetches the book from the REST API. Binance accepts a
+// limit of 1 to 5000 levels.
+func (b *binance) DepthSnapshot(ctx context.Context, symbol string, limit int) (DepthSnapshot, error) {
+	query := url.Values{}
+	query.Set("symbol", strings.ToUpper(symbol))
+	query.Set("limit", strconv.Itoa(limit))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.restURL+"/api/v3/depth?"+query.Encode(), nil)
+	if err != nil {
+		return DepthSnapshot{}, err
+	}
+
+	resp, err := b.http.Do(req)
+	if err != nil {
+		return DepthSnapshot{}, err
+	}
+	defer resp.Body.Close()
+
+	var snapshot binanceDepthSnapshot
+	if err := json.NewDecoder(resp.Body).Decode(&snapshot); err != nil {
+		return DepthSnapshot{}, fmt.Errorf("decode depth snapshot: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return DepthSnapshot{}, &FeedError{Exchange: Binance, Message: fmt.Sprintf("depth snapshot: %d %s", snapshot.Code, snapshot.Msg)}
+	}
+
+	return DepthSnapshot{
+		Symbol:       strings.ToLower(symbol),
+		LastUpdateID: snapshot.LastUpdateID,
+		Bids:         binanceLevels(snapshot.Bids),
+		Asks:         binanceLevels(snapshot.Asks),
+	}, nil
+}
+
+func binanceLevels(pairs [][2]string) []Level {
+	levels := make([]Level, 0, len(pairs))
+	for _, pair := range pairs {
+		levels = append(levels, Level{Price: pair[0], Quantity: pair[1]})
+	}
+	return levels
+}
+
+func (b *binance) KlineStream(symbol, interval string) Stream {
+	return Stream{URL: b.tradeURL + strings.ToLower(symbol) + "@kline_" + interval}
+}
+
+// binanceKline spells out every key of the candle, as several differ from
+// each other by case only ("t"/"T", "v"/"V", "q"/"Q").
+type binanceKline struct {
+	EventType string `json:"e"`
+	EventTime int64  `json:"E"`
+	Symbol    string `json:"s"`
+	Candle    struct {
+		OpenTime         int64  `json:"t"`
+		CloseTime        int64  `json:"T"`
+		Symbol           string `json:"s"`
+		Interval         string `json:"i"`
+		FirstTradeID     int64  `json:"f"`
+		LastTradeID      int64  `json:"L"`
+		Open             string `json:"o"`
+		Close            string `json:"c"`
+		High             string `json:"h"`
+		Low              string `json:"l"`
+		Volume           string `json:"v"`
+		Trades           int64  `json:"n"`
+		Closed           bool   `json:"x"`
+		QuoteVolume      string `json:"q"`
+		TakerVolume      string `json:"V"`
+		TakerQuoteVolume string `json:"Q"`
+		Ignore           string `json:"B"`
+	} `json:"k"`
+}
+
+func (b *binance) DecodeKline(frame []byte) (Kline, error) {
+	var kline binanceKline
+	if err := json.Unmarshal(frame, &kline); err != nil {
+		return Kline{}, err
+	}
+	if kline.EventType != "kline" {
+		return Kline{}, fmt.Errorf("unexpected kline event %q", kline.EventType)
+	}
+
+	candle := kline.Candle
+	return Kline{
+		Symbol:      strings.ToLower(kline.Symbol),
+		Interval:    candle.Interval,
+		OpenTime:    candle.OpenTime,
+		CloseTime:   candle.CloseTime,
+		Open:        candle.Open,
+		High:        candle.High,
+		Low:         candle.Low,
+		Close:       candle.Close,
+		Volume:      candle.Volume,
+		QuoteVolume: candle.QuoteVolume,
+		Trades:      candle.Trades,
+		Closed:      candle.Closed,
+		EventTime:   kline.EventTime,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package exchange
+
+import "context"
+
+// Level is one price level of an order book. A zero quantity in a depth
+// update removes the level.
+type Level struct {
+	Price    string
+	Quantity string
+}
+
+// DepthUpdate holds the levels changed by the updates FirstUpdateID to
+// FinalUpdateID.
+type DepthUpdate struct {
+	Symbol        string
+	EventTime     int64
+	FirstUpdateID int64
+	FinalUpdateID int64
+	Bids          []Level
+	Asks          []Level
+}
+
+// DepthSnapshot is the book as of LastUpdateID, best levels first.
+type DepthSnapshot struct {
+	Symbol       string
+	LastUpdateID int64
+	Bids         []Level
+	Asks         []Level
+}
+
+// BookTicker is the best bid and ask of a symbol.
+type BookTicker struct {
+	Symbol      string
+	UpdateID    int64
+	BidPrice    string
+	BidQuantity string
+	AskPrice    string
+	AskQuantity string
+}
+
+// BookSource is implemented by venues serving order book streams. A local
+// book is built from DepthSnapshot and kept current with the diffs of
+// DepthStream.
+type BookSource interface {
+	DepthStream(symbol string) Stream
+	BookTickerStream(symbol string) Stream
+	DecodeDepth(frame []byte) (DepthUpdate, error)
+	DecodeBookTicker(frame []byte) (BookTicker, error)
+	DepthSnapshot(ctx context.Context, symbol string, limit int) (DepthSnapshot, error)
+}
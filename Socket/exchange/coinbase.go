@@ -0,0 +1,131 @@
+package exchange
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/lib/getenv"
+)
+
+const Coinbase = "coinbase"
+
+type coinbase struct {
+	url string
+}
+
+// NewCoinbase streams the public feed of Coinbase Exchange. Coinbase quotes
+// most assets in USD only, so USDT pairs are served from the USD book.
+func NewCoinbase() Adapter {
+	return &coinbase{
+		url: getenv.GetString("COINBASE_WS_URL", "wss://ws-feed.exchange.coinbase.com"),
+	}
+}
+
+func (c *coinbase) Name() string {
+	return Coinbase
+}
+
+func (c *coinbase) TradeStream(symbol string) (Stream, bool) {
+	product, ok := coinbaseProduct(symbol)
+	if !ok {
+		return Stream{}, false
+	}
+	return c.stream("matches", []string{product}), true
+}
+
+func (c *coinbase) TickerStream(symbols []string) (Stream, bool) {
+	if len(symbols) == 0 {
+		return Stream{}, false
+	}
+
+	products := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		if product, ok := coinbaseProduct(symbol); ok {
+			products = append(products, product)
+		}
+	}
+	if len(products) == 0 {
+		return Stream{}, false
+	}
+	return c.stream("ticker", products), true
+}
+
+func (c *coinbase) stream(channel string, products []string) Stream {
+	frame, _ := json.Marshal(map[string]any{
+		"type":        "subscribe",
+		"product_ids": products,
+		"channels":    []string{channel},
+	})
+	return Stream{URL: c.url, Subscribe: [][]byte{frame}}
+}
+
+type coinbaseMessage struct {
+	Type      string    `json:"type"`
+	ProductID string    `json:"product_id"`
+	Price     string    `json:"price"`
+	Size      string    `json:"size"`
+	Open24h   string    `json:"open_24h"`
+	High24h   string    `json:"high_24h"`
+	Low24h    string    `json:"low_24h"`
+	Volume24h string    `json:"volume_24h"`
+	Time      time.Time `json:"time"`
+	Message   string    `json:"message"`
+	Reason    string    `json:"reason"`
+	TradeID   int64     `json:"trade_id"`
+	// Side is the side of the maker order.
+	Side string `json:"side"`
+}
+
+func (c *coinbase) Decode(frame []byte) ([]Message, error) {
+	var msg coinbaseMessage
+	if err := json.Unmarshal(frame, &msg); err != nil {
+		return nil, err
+	}
+
+	switch msg.Type {
+	case "match", "last_match":
+		return []Message{{
+			Exchange: Coinbase,
+			Kind:     KindTrade,
+			Symbol:   joinSymbol(msg.ProductID),
+			Time:     msg.Time.UTC(),
+			Price:    msg.Price,
+			Quantity: msg.Size,
+
+			TradeID:      msg.TradeID,
+			FirstTradeID: msg.TradeID,
+			LastTradeID:  msg.TradeID,
+			BuyerMaker:   msg.Side == "buy",
+		}}, nil
+	case "ticker":
+		return []Message{{
+			Exchange: Coinbase,
+			Kind:     KindTicker,
+			Symbol:   joinSymbol(msg.ProductID),
+			Time:     msg.Time.UTC(),
+			Price:    msg.Price,
+			Open:     msg.Open24h,
+			High:     msg.High24h,
+			Low:      msg.Low24h,
+			Volume:   msg.Volume24h,
+		}}, nil
+	case "error":
+		return nil, &FeedError{Exchange: Coinbase, Message: strings.TrimSpace(msg.Message + " " + msg.Reason)}
+	default:
+		return nil, nil
+	}
+}
+
+// coinbaseProduct maps "btcusdt" to "BTC-USD".
+func coinbaseProduct(symbol string) (string, bool) {
+	base, quote, ok := splitSymbol(symbol)
+	if !ok {
+		return "", false
+	}
+	switch quote {
+	case "usdt", "usdc", "fdusd", "busd":
+		quote = "usd"
+	}
+	return strings.ToUpper(base + "-" + quote), true
+}
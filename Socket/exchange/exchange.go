@@ -0,0 +1,165 @@
+package exchange
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type Kind string
+
+const (
+	KindTrade  Kind = "trade"
+	KindTicker Kind = "ticker"
+)
+
+// Message is a trade or a ticker normalized from any venue. Symbol is the
+// pair in lower case without separator ("btcusdt"); prices and quantities
+// are decimal strings exactly as the venue sent them.
+type Message struct {
+	Exchange string
+	Kind     Kind
+	Symbol   string
+	Time     time.Time
+
+	// Price is the trade price, or the last price of a ticker.
+	Price string
+
+	// Quantity is set for trades only.
+	Quantity string
+
+	// TradeID identifies a trade on its venue. Binance aggregates fills of
+	// one taker order into a trade spanning FirstTradeID to LastTradeID;
+	// the other venues report single fills, so all three are equal.
+	TradeID      int64
+	FirstTradeID int64
+	LastTradeID  int64
+	// BuyerMaker is set when the buyer placed the resting order, i.e. the
+	// taker sold.
+	BuyerMaker bool
+
+	// Open, High, Low and Volume describe the rolling 24h window of a ticker.
+	// QuoteVolume is only sent by venues that report it.
+	Open        string
+	High        string
+	Low         string
+	Volume      string
+	QuoteVolume string
+}
+
+var decimalPattern = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+
+// Validate reports whether m carries a usable price: a symbol, a positive
+// decimal price and well-formed optional fields.
+func (m Message) Validate() error {
+	if m.Symbol == "" {
+		return errors.New("missing symbol")
+	}
+	if !decimalPattern.MatchString(m.Price) || strings.Trim(m.Price, "0.") == "" {
+		return fmt.Errorf("invalid price %q", m.Price)
+	}
+
+	for name, value := range map[string]string{
+		"quantity": m.Quantity,
+		"open":     m.Open,
+		"high":     m.High,
+		"low":      m.Low,
+		"volume":   m.Volume,
+		"quote":    m.QuoteVolume,
+	} {
+		if value != "" && !decimalPattern.MatchString(value) {
+			return fmt.Errorf("invalid %s %q", name, value)
+		}
+	}
+	return nil
+}
+
+// Stream is one websocket the Socket service keeps open on a venue.
+// Subscribe frames are written after every (re)connect.
+type Stream struct {
+	URL       string
+	Subscribe [][]byte
+}
+
+// Adapter turns the market data API of one venue into Messages.
+type Adapter interface {
+	Name() string
+
+	// TradeStream returns the stream carrying the trades of symbol, or false
+	// when the venue does not list it.
+	TradeStream(symbol string) (Stream, bool)
+
+	// TickerStream returns the stream carrying the tickers of symbols; nil
+	// asks for every symbol of the venue, which not every venue supports.
+	TickerStream(symbols []string) (Stream, bool)
+
+	// Decode parses one websocket frame. Frames without market data, such
+	// as subscription acks and heartbeats, yield no messages.
+	Decode(frame []byte) ([]Message, error)
+}
+
+// Multiplexer is implemented by venues that carry the trades of many symbols
+// on one socket, subscribed and unsubscribed while it is open.
+type Multiplexer interface {
+	// TradeMux returns the socket the trade subscriptions are sent on.
+	TradeMux() Stream
+
+	// SubscribeTrades and UnsubscribeTrades build the control frames adding
+	// or removing symbols; id correlates the venue's reply.
+	SubscribeTrades(id int64, symbols []string) []byte
+	UnsubscribeTrades(id int64, symbols []string) []byte
+
+	// MaxTradeStreams caps the symbols carried by one socket.
+	MaxTradeStreams() int
+}
+
+// FeedError is an error frame sent by a venue, e.g. a rejected subscription.
+type FeedError struct {
+	Exchange string
+	Message  string
+}
+
+func (e *FeedError) Error() string {
+	return e.Exchange + ": " + e.Message
+}
+
+// New returns the adapter registered under name.
+func New(name string) (Adapter, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "binance":
+		return NewBinance(), nil
+	case "coinbase":
+		return NewCoinbase(), nil
+	case "kraken":
+		return NewKraken(), nil
+	default:
+		return nil, fmt.Errorf("unknown exchange %q", name)
+	}
+}
+
+// quoteAssets are tried longest first so that "usdt" wins over "usd".
+var quoteAssets = []string{"usdt", "usdc", "fdusd", "busd", "usd", "eur", "gbp", "btc", "eth"}
+
+// splitSymbol splits "btcusdt" into "btc" and "usdt".
+func splitSymbol(symbol string) (string, string, bool) {
+	symbol = strings.ToLower(symbol)
+
+	best := ""
+	for _, quote := range quoteAssets {
+		if len(quote) > len(best) && strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			best = quote
+		}
+	}
+	if best == "" {
+		return "", "", false
+	}
+	return strings.TrimSuffix(symbol, best), best, true
+}
+
+// joinSymbol drops the separator of a venue pair such as "BTC-USD" or
+// "BTC/USD".
+func joinSymbol(pair string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "/", "", "_", "").Replace(pair))
+}
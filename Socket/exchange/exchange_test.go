@@ -0,0 +1,161 @@
+package exchange_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+)
+
+func TestDecode(t *testing.T) {
+	t.Run("binance_agg_trade", func(t *testing.T) {
+		messages, err := exchange.NewBinance().Decode([]byte(`{"e":"aggTrade","E":1,"s":"BTCUSDT","p":"65000.10","q":"0.5","T":1700000000000}`))
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if len(messages) != 1 || messages[0].Symbol != "btcusdt" || messages[0].Price != "65000.10" || messages[0].Kind != exchange.KindTrade {
+			t.Errorf("Expected one btcusdt trade at 65000.10, got %+v", messages)
+		}
+	})
+
+	t.Run("binance_agg_trade_ids", func(t *testing.T) {
+		messages, err := exchange.NewBinance().Decode([]byte(`{"e":"aggTrade","E":1,"s":"BTCUSDT","a":26129,"p":"65000.10","q":"0.5","f":100,"l":105,"T":1700000000000,"m":true,"M":true}`))
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if len(messages) != 1 || messages[0].TradeID != 26129 || messages[0].FirstTradeID != 100 || messages[0].LastTradeID != 105 || !messages[0].BuyerMaker {
+			t.Errorf("Expected trade 26129 of fills 100 to 105 sold by the taker, got %+v", messages)
+		}
+	})
+
+	t.Run("binance_combined_stream", func(t *testing.T) {
+		messages, err := exchange.NewBinance().Decode([]byte(`{"stream":"ethusdt@aggTrade","data":{"e":"aggTrade","E":1,"s":"ETHUSDT","p":"3000.5","q":"2","T":1700000000000}}`))
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if len(messages) != 1 || messages[0].Symbol != "ethusdt" {
+			t.Errorf("Expected one ethusdt trade, got %+v", messages)
+		}
+	})
+
+	t.Run("binance_subscribe_reply", func(t *testing.T) {
+		messages, err := exchange.NewBinance().Decode([]byte(`{"result":null,"id":1}`))
+		if err != nil || len(messages) != 0 {
+			t.Errorf("Expected no messages, got %+v, %v", messages, err)
+		}
+
+		_, err = exchange.NewBinance().Decode([]byte(`{"error":{"code":2,"msg":"Invalid request"},"id":2}`))
+		var feedErr *exchange.FeedError
+		if !errors.As(err, &feedErr) {
+			t.Errorf("Expected FeedError, but got %v", err)
+		}
+	})
+
+	t.Run("binance_depth_update", func(t *testing.T) {
+		book := exchange.NewBinance().(exchange.BookSource)
+		update, err := book.DecodeDepth([]byte(`{"e":"depthUpdate","E":1,"s":"BTCUSDT","U":157,"u":160,"b":[["65000.10","1.5"]],"a":[["65001.00","0"]]}`))
+		if err != nil {
+			t.Fatalf("DecodeDepth failed: %v", err)
+		}
+		if update.FirstUpdateID != 157 || update.FinalUpdateID != 160 || len(update.Bids) != 1 || update.Asks[0].Quantity != "0" {
+			t.Errorf("Expected updates 157 to 160 with one bid and a removed ask, got %+v", update)
+		}
+	})
+
+	t.Run("binance_book_ticker", func(t *testing.T) {
+		book := exchange.NewBinance().(exchange.BookSource)
+		ticker, err := book.DecodeBookTicker([]byte(`{"u":400900217,"s":"BNBUSDT","b":"25.35190000","B":"31.21000000","a":"25.36520000","A":"40.66000000"}`))
+		if err != nil {
+			t.Fatalf("DecodeBookTicker failed: %v", err)
+		}
+		if ticker.Symbol != "bnbusdt" || ticker.BidPrice != "25.35190000" || ticker.AskQuantity != "40.66000000" {
+			t.Errorf("Expected bnbusdt best bid 25.3519 and ask size 40.66, got %+v", ticker)
+		}
+	})
+
+	t.Run("binance_closed_kline", func(t *testing.T) {
+		klines := exchange.NewBinance().(exchange.KlineSource)
+		kline, err := klines.DecodeKline([]byte(`{"e":"kline","E":1700000060001,"s":"BTCUSDT","k":{"t":1700000000000,"T":1700000059999,"s":"BTCUSDT","i":"1m","f":100,"L":200,"o":"65000.1","c":"65010.2","h":"65020","l":"64990","v":"12.5","n":101,"x":true,"q":"812500.5","V":"6","Q":"390000","B":"0"}}`))
+		if err != nil {
+			t.Fatalf("DecodeKline failed: %v", err)
+		}
+		if !kline.Closed || kline.OpenTime != 1700000000000 || kline.CloseTime != 1700000059999 || kline.Volume != "12.5" || kline.QuoteVolume != "812500.5" || kline.Trades != 101 {
+			t.Errorf("Expected the closed 1m candle, got %+v", kline)
+		}
+	})
+
+	t.Run("coinbase_match", func(t *testing.T) {
+		messages, err := exchange.NewCoinbase().Decode([]byte(`{"type":"match","product_id":"BTC-USD","price":"65001.5","size":"0.01","time":"2024-01-02T03:04:05.000001Z","trade_id":42,"side":"sell"}`))
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if len(messages) != 1 || messages[0].Symbol != "btcusd" || messages[0].Quantity != "0.01" || messages[0].TradeID != 42 || messages[0].BuyerMaker {
+			t.Errorf("Expected one btcusd trade of 0.01, got %+v", messages)
+		}
+	})
+
+	t.Run("coinbase_error", func(t *testing.T) {
+		_, err := exchange.NewCoinbase().Decode([]byte(`{"type":"error","message":"Failed to subscribe","reason":"FOO-USD is not a valid product"}`))
+		var feedErr *exchange.FeedError
+		if !errors.As(err, &feedErr) {
+			t.Errorf("Expected FeedError, but got %v", err)
+		}
+	})
+
+	t.Run("kraken_trade_keeps_precision", func(t *testing.T) {
+		messages, err := exchange.NewKraken().Decode([]byte(`{"channel":"trade","type":"update","data":[{"symbol":"BTC/USDT","side":"buy","price":65002.00000001,"qty":0.00012,"timestamp":"2024-01-02T03:04:05.123456Z"}]}`))
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if len(messages) != 1 || messages[0].Symbol != "btcusdt" || messages[0].Price != "65002.00000001" {
+			t.Errorf("Expected one btcusdt trade at 65002.00000001, got %+v", messages)
+		}
+	})
+
+	t.Run("kraken_ticker_derives_open", func(t *testing.T) {
+		messages, err := exchange.NewKraken().Decode([]byte(`{"channel":"ticker","type":"update","data":[{"symbol":"ETH/USD","last":3000.5,"change":-10.25,"high":3100,"low":2900,"volume":1234.5}]}`))
+		if err != nil {
+			t.Fatalf("Decode failed: %v", err)
+		}
+		if len(messages) != 1 || messages[0].Open != "3010.75" {
+			t.Errorf("Expected open 3010.75, got %+v", messages)
+		}
+	})
+
+	t.Run("heartbeat_yields_nothing", func(t *testing.T) {
+		messages, err := exchange.NewKraken().Decode([]byte(`{"channel":"heartbeat"}`))
+		if err != nil || len(messages) != 0 {
+			t.Errorf("Expected no messages, got %+v, %v", messages, err)
+		}
+	})
+}
+
+func TestStreams(t *testing.T) {
+	t.Run("coinbase_serves_usdt_from_usd_book", func(t *testing.T) {
+		stream, ok := exchange.NewCoinbase().TradeStream("btcusdt")
+		if !ok || len(stream.Subscribe) != 1 {
+			t.Fatalf("Expected a subscribe frame, got %+v", stream)
+		}
+		want := `{"channels":["matches"],"product_ids":["BTC-USD"],"type":"subscribe"}`
+		if string(stream.Subscribe[0]) != want {
+			t.Errorf("Expected %s, got %s", want, stream.Subscribe[0])
+		}
+	})
+
+	t.Run("binance_subscribe_frame", func(t *testing.T) {
+		mux, ok := exchange.NewBinance().(exchange.Multiplexer)
+		if !ok {
+			t.Fatal("Expected binance to multiplex trades")
+		}
+		want := `{"id":3,"method":"SUBSCRIBE","params":["btcusdt@aggTrade","ethusdt@aggTrade"]}`
+		if got := string(mux.SubscribeTrades(3, []string{"BTCUSDT", "ethusdt"})); got != want {
+			t.Errorf("Expected %s, got %s", want, got)
+		}
+	})
+
+	t.Run("unknown_quote_is_not_listed", func(t *testing.T) {
+		if _, ok := exchange.NewKraken().TradeStream("xyz"); ok {
+			t.Error("Expected xyz to be unlisted")
+		}
+	})
+}
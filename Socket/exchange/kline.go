@@ -0,0 +1,32 @@
+package exchange
+
+// Kline is a candle of Symbol over Interval. It is updated until Closed is
+// set; OpenTime and CloseTime are Unix milliseconds.
+type Kline struct {
+	Symbol      string
+	Interval    string
+	OpenTime    int64
+	CloseTime   int64
+	Open        string
+	High        string
+	Low         string
+	Close       string
+	Volume      string
+	QuoteVolume string
+	Trades      int64
+	Closed      bool
+	EventTime   int64
+}
+
+// KlineIntervals are the candle intervals Binance streams.
+var KlineIntervals = map[string]struct{}{
+	"1s": {}, "1m": {}, "3m": {}, "5m": {}, "15m": {}, "30m": {},
+	"1h": {}, "2h": {}, "4h": {}, "6h": {}, "8h": {}, "12h": {},
+	"1d": {}, "3d": {}, "1w": {}, "1M": {},
+}
+
+// KlineSource is implemented by venues streaming candles.
+type KlineSource interface {
+	KlineStream(symbol, interval string) Stream
+	DecodeKline(frame []byte) (Kline, error)
+}
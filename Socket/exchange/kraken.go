@@ -0,0 +1,190 @@
+package exchange
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/lib/getenv"
+)
+
+const Kraken = "kraken"
+
+type kraken struct {
+	url string
+}
+
+// NewKraken streams the v2 public websocket of Kraken.
+func NewKraken() Adapter {
+	return &kraken{
+		url: getenv.GetString("KRAKEN_WS_URL", "wss://ws.kraken.com/v2"),
+	}
+}
+
+func (k *kraken) Name() string {
+	return Kraken
+}
+
+func (k *kraken) TradeStream(symbol string) (Stream, bool) {
+	pair, ok := krakenPair(symbol)
+	if !ok {
+		return Stream{}, false
+	}
+	return k.stream("trade", []string{pair}), true
+}
+
+func (k *kraken) TickerStream(symbols []string) (Stream, bool) {
+	if len(symbols) == 0 {
+		return Stream{}, false
+	}
+
+	pairs := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		if pair, ok := krakenPair(symbol); ok {
+			pairs = append(pairs, pair)
+		}
+	}
+	if len(pairs) == 0 {
+		return Stream{}, false
+	}
+	return k.stream("ticker", pairs), true
+}
+
+func (k *kraken) stream(channel string, pairs []string) Stream {
+	frame, _ := json.Marshal(map[string]any{
+		"method": "subscribe",
+		"params": map[string]any{
+			"channel":  channel,
+			"symbol":   pairs,
+			"snapshot": false,
+		},
+	})
+	return Stream{URL: k.url, Subscribe: [][]byte{frame}}
+}
+
+type krakenMessage struct {
+	Channel string            `json:"channel"`
+	Type    string            `json:"type"`
+	Data    []json.RawMessage `json:"data"`
+
+	// Method, Success and Error are set on subscription acks.
+	Method  string `json:"method"`
+	Success *bool  `json:"success"`
+	Error   string `json:"error"`
+}
+
+type krakenTrade struct {
+	Symbol    string      `json:"symbol"`
+	Price     json.Number `json:"price"`
+	Qty       json.Number `json:"qty"`
+	Timestamp time.Time   `json:"timestamp"`
+	TradeID   int64       `json:"trade_id"`
+	// Side is the side of the taker order.
+	Side string `json:"side"`
+}
+
+type krakenTicker struct {
+	Symbol string      `json:"symbol"`
+	Last   json.Number `json:"last"`
+	Change json.Number `json:"change"`
+	High   json.Number `json:"high"`
+	Low    json.Number `json:"low"`
+	Volume json.Number `json:"volume"`
+}
+
+func (k *kraken) Decode(frame []byte) ([]Message, error) {
+	var msg krakenMessage
+	if err := json.Unmarshal(frame, &msg); err != nil {
+		return nil, err
+	}
+
+	if msg.Success != nil && !*msg.Success {
+		return nil, &FeedError{Exchange: Kraken, Message: msg.Error}
+	}
+
+	switch msg.Channel {
+	case "trade":
+		messages := make([]Message, 0, len(msg.Data))
+		for _, raw := range msg.Data {
+			var trade krakenTrade
+			if err := json.Unmarshal(raw, &trade); err != nil {
+				return nil, err
+			}
+			messages = append(messages, Message{
+				Exchange: Kraken,
+				Kind:     KindTrade,
+				Symbol:   joinSymbol(trade.Symbol),
+				Time:     trade.Timestamp.UTC(),
+				Price:    krakenDecimal(trade.Price),
+				Quantity: krakenDecimal(trade.Qty),
+
+				TradeID:      trade.TradeID,
+				FirstTradeID: trade.TradeID,
+				LastTradeID:  trade.TradeID,
+				BuyerMaker:   trade.Side == "sell",
+			})
+		}
+		return messages, nil
+	case "ticker":
+		now := time.Now().UTC()
+		messages := make([]Message, 0, len(msg.Data))
+		for _, raw := range msg.Data {
+			var ticker krakenTicker
+			if err := json.Unmarshal(raw, &ticker); err != nil {
+				return nil, err
+			}
+			messages = append(messages, Message{
+				Exchange: Kraken,
+				Kind:     KindTicker,
+				Symbol:   joinSymbol(ticker.Symbol),
+				Time:     now,
+				Price:    krakenDecimal(ticker.Last),
+				Open:     krakenOpen(ticker.Last, ticker.Change),
+				High:     krakenDecimal(ticker.High),
+				Low:      krakenDecimal(ticker.Low),
+				Volume:   krakenDecimal(ticker.Volume),
+			})
+		}
+		return messages, nil
+	default:
+		return nil, nil
+	}
+}
+
+// krakenOpen derives the 24h open since Kraken only sends the change.
+func krakenOpen(last, change json.Number) string {
+	l, err := last.Float64()
+	if err != nil {
+		return ""
+	}
+	c, err := change.Float64()
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatFloat(l-c, 'f', -1, 64)
+}
+
+// krakenDecimal keeps the number as sent unless it uses an exponent, which
+// is spelled out so every venue yields plain decimal strings.
+func krakenDecimal(n json.Number) string {
+	s := n.String()
+	if !strings.ContainsAny(s, "eE") {
+		return s
+	}
+	f, ok := new(big.Float).SetPrec(128).SetString(s)
+	if !ok {
+		return s
+	}
+	return f.Text('f', -1)
+}
+
+// krakenPair maps "btcusdt" to "BTC/USDT".
+func krakenPair(symbol string) (string, bool) {
+	base, quote, ok := splitSymbol(symbol)
+	if !ok {
+		return "", false
+	}
+	return strings.ToUpper(base + "/" + quote), true
+}
@@ -0,0 +1,103 @@
+package exchange
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// SymbolInfo is the exchange metadata of a symbol. TickSize is the price
+// step, StepSize, MinQty and MaxQty bound the order quantity.
+type SymbolInfo struct {
+	Symbol      string
+	BaseAsset   string
+	QuoteAsset  string
+	Status      string
+	TickSize    string
+	StepSize    string
+	MinQty      string
+	MaxQty      string
+	MinNotional string
+}
+
+// StatusTrading is the status of a symbol open for trading.
+const StatusTrading = "TRADING"
+
+// SymbolSource is implemented by venues listing their symbols.
+type SymbolSource interface {
+	Symbols(ctx context.Context) ([]SymbolInfo, error)
+}
+
+type binanceExchangeInfo struct {
+	Code    int    `json:"code"`
+	Msg     string `json:"msg"`
+	Symbols []struct {
+		Symbol     string `json:"symbol"`
+		Status     string `json:"status"`
+		BaseAsset  string `json:"baseAsset"`
+		QuoteAsset string `json:"quoteAsset"`
+		Filters    []struct {
+			FilterType  string `json:"filterType"`
+			TickSize    string `json:"tickSize"`
+			StepSize    string `json:"stepSize"`
+			MinQty      string `json:"minQty"`
+			MaxQty      string `json:"maxQty"`
+			MinNotional string `json:"minNotional"`
+		} `json:"filters"`
+	} `json:"symbols"`
+}
+
+// Symbols fetches the spot symbols from the exchangeInfo endpoint.
+func (b *binance) Symbols(ctx context.Context) ([]SymbolInfo, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.restURL+"/api/v3/exchangeInfo?permissions=SPOT", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := b.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var info binanceExchangeInfo
+		json.NewDecoder(resp.Body).Decode(&info)
+		return nil, &FeedError{Exchange: Binance, Message: fmt.Sprintf("exchange info: %d %s", info.Code, info.Msg)}
+	}
+	return DecodeExchangeInfo(resp.Body)
+}
+
+// DecodeExchangeInfo reads a Binance exchangeInfo document, as served by
+// the REST API or saved to a file.
+func DecodeExchangeInfo(r io.Reader) ([]SymbolInfo, error) {
+	var info binanceExchangeInfo
+	if err := json.NewDecoder(r).Decode(&info); err != nil {
+		return nil, fmt.Errorf("decode exchange info: %w", err)
+	}
+
+	symbols := make([]SymbolInfo, 0, len(info.Symbols))
+	for _, s := range info.Symbols {
+		symbol := SymbolInfo{
+			Symbol:     strings.ToUpper(s.Symbol),
+			BaseAsset:  strings.ToUpper(s.BaseAsset),
+			QuoteAsset: strings.ToUpper(s.QuoteAsset),
+			Status:     s.Status,
+		}
+		for _, f := range s.Filters {
+			switch f.FilterType {
+			case "PRICE_FILTER":
+				symbol.TickSize = f.TickSize
+			case "LOT_SIZE":
+				symbol.StepSize, symbol.MinQty, symbol.MaxQty = f.StepSize, f.MinQty, f.MaxQty
+			case "NOTIONAL", "MIN_NOTIONAL":
+				symbol.MinNotional = f.MinNotional
+			}
+		}
+		symbols = append(symbols, symbol)
+	}
+	return symbols, nil
+}
@@ -15,3 +15,5 @@ require (
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20251022142026-3a174f9686a8 // indirect
 	google.golang.org/protobuf v1.36.10 // indirect
 )
+
+replace github.com/Tonic56/proto-crypto-asset-tracker => ../proto-crypto-asset-tracker
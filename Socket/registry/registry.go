@@ -0,0 +1,193 @@
+// Package registry keeps the symbols listed by Binance, so that clients can
+// validate and search symbols before streaming them.
+package registry
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/lib/getenv"
+)
+
+const (
+	defaultRefreshInterval = time.Hour
+	retryDelay             = 30 * time.Second
+)
+
+// Registry holds the symbols of the last exchangeInfo, keyed by their
+// normalized name.
+type Registry struct {
+	source  exchange.SymbolSource
+	refresh time.Duration
+
+	mu      sync.RWMutex
+	symbols map[string]exchange.SymbolInfo
+	sorted  []exchange.SymbolInfo
+}
+
+func New(source exchange.SymbolSource) *Registry {
+	refresh, err := time.ParseDuration(getenv.GetString("SYMBOLS_REFRESH_INTERVAL", defaultRefreshInterval.String()))
+	if err != nil || refresh <= 0 {
+		slog.Warn("Invalid SYMBOLS_REFRESH_INTERVAL, using default", "default", defaultRefreshInterval)
+		refresh = defaultRefreshInterval
+	}
+
+	return &Registry{
+		source:  source,
+		refresh: refresh,
+		symbols: make(map[string]exchange.SymbolInfo),
+	}
+}
+
+// Normalize lowercases symbol and drops the separators people put between
+// the assets, so "BTC/USDT", "btc-usdt" and "BTCUSDT" are the same symbol.
+func Normalize(symbol string) string {
+	return strings.ToLower(strings.NewReplacer("/", "", "-", "", "_", "", " ", "").Replace(symbol))
+}
+
+// Set replaces the symbols of the registry.
+func (r *Registry) Set(symbols []exchange.SymbolInfo) {
+	index := make(map[string]exchange.SymbolInfo, len(symbols))
+	for _, symbol := range symbols {
+		index[Normalize(symbol.Symbol)] = symbol
+	}
+	sorted := make([]exchange.SymbolInfo, 0, len(index))
+	for _, symbol := range index {
+		sorted = append(sorted, symbol)
+	}
+	slices.SortFunc(sorted, func(a, b exchange.SymbolInfo) int {
+		return strings.Compare(a.Symbol, b.Symbol)
+	})
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.symbols = index
+	r.sorted = sorted
+}
+
+// LoadFile fills the registry from a saved exchangeInfo document. A
+// registry loaded from a file is never refreshed.
+func (r *Registry) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	symbols, err := exchange.DecodeExchangeInfo(f)
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	r.Set(symbols)
+	return nil
+}
+
+// Run loads the symbols from the source and refreshes them every
+// SYMBOLS_REFRESH_INTERVAL. A failed load is retried sooner and keeps the
+// symbols already known.
+func (r *Registry) Run(ctx context.Context, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for {
+		next := r.refresh
+		symbols, err := r.source.Symbols(ctx)
+		if err != nil {
+			slog.Error("Could not load symbols", "error", err, "retry_in", retryDelay)
+			next = retryDelay
+		} else {
+			r.Set(symbols)
+			slog.Info("📚 Symbol registry loaded", "symbols", len(symbols))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(next):
+		}
+	}
+}
+
+// Len reports how many symbols are known; 0 until the first load.
+func (r *Registry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return len(r.symbols)
+}
+
+func (r *Registry) Lookup(symbol string) (exchange.SymbolInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	info, ok := r.symbols[Normalize(symbol)]
+	return info, ok
+}
+
+// Search returns the symbols matching query, best matches first: the symbol
+// itself, then symbols starting with the query, then those whose base
+// asset is the query, then any other symbol containing it. Symbols open for
+// trading come before the others of the same rank. A limit of 0 returns all
+// matches.
+func (r *Registry) Search(query string, limit int) []exchange.SymbolInfo {
+	query = Normalize(query)
+
+	r.mu.RLock()
+	type match struct {
+		rank   int
+		symbol exchange.SymbolInfo
+	}
+	var matches []match
+	for _, symbol := range r.sorted {
+		if rank, ok := searchRank(query, symbol); ok {
+			matches = append(matches, match{rank: rank, symbol: symbol})
+		}
+	}
+	r.mu.RUnlock()
+
+	slices.SortStableFunc(matches, func(a, b match) int {
+		return cmp.Or(
+			cmp.Compare(a.rank, b.rank),
+			cmp.Compare(tradingRank(a.symbol), tradingRank(b.symbol)),
+		)
+	})
+
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	symbols := make([]exchange.SymbolInfo, len(matches))
+	for i, m := range matches {
+		symbols[i] = m.symbol
+	}
+	return symbols
+}
+
+func searchRank(query string, symbol exchange.SymbolInfo) (int, bool) {
+	name := Normalize(symbol.Symbol)
+	switch {
+	case query == "" || name == query:
+		return 0, true
+	case strings.HasPrefix(name, query):
+		return 1, true
+	case strings.ToLower(symbol.BaseAsset) == query:
+		return 2, true
+	case strings.Contains(name, query):
+		return 3, true
+	}
+	return 0, false
+}
+
+func tradingRank(symbol exchange.SymbolInfo) int {
+	if symbol.Status == exchange.StatusTrading {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,92 @@
+package registry_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/registry"
+)
+
+func loadFixture(t *testing.T) *registry.Registry {
+	t.Helper()
+
+	r := registry.New(nil)
+	if err := r.LoadFile("testdata/exchange_info.json"); err != nil {
+		t.Fatalf("LoadFile failed: %v", err)
+	}
+	return r
+}
+
+func TestRegistry(t *testing.T) {
+	t.Run("fixture_filters", func(t *testing.T) {
+		r := loadFixture(t)
+
+		info, ok := r.Lookup("BTCUSDT")
+		if !ok {
+			t.Fatal("Expected BTCUSDT to be listed")
+		}
+		want := exchange.SymbolInfo{
+			Symbol:      "BTCUSDT",
+			BaseAsset:   "BTC",
+			QuoteAsset:  "USDT",
+			Status:      exchange.StatusTrading,
+			TickSize:    "0.01000000",
+			StepSize:    "0.00001000",
+			MinQty:      "0.00001000",
+			MaxQty:      "9000.00000000",
+			MinNotional: "5.00000000",
+		}
+		if info != want {
+			t.Errorf("Expected %+v, got %+v", want, info)
+		}
+	})
+
+	t.Run("lookup_normalizes", func(t *testing.T) {
+		r := loadFixture(t)
+
+		for _, input := range []string{"btcusdt", "BTC/USDT", "btc-usdt", " BTC_USDT"} {
+			if _, ok := r.Lookup(input); !ok {
+				t.Errorf("Expected %q to resolve to BTCUSDT", input)
+			}
+		}
+		if _, ok := r.Lookup("BTCUSD"); ok {
+			t.Error("Expected BTCUSD not to be listed")
+		}
+	})
+
+	t.Run("search_ranks_matches", func(t *testing.T) {
+		r := loadFixture(t)
+
+		got := symbolNames(r.Search("eth", 0))
+		want := []string{"ETHBTC", "ETHUSDT"}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+
+		got = symbolNames(r.Search("usdt", 0))
+		want = []string{"USDTTRY", "BTCUSDT", "ETHUSDT", "LUNAUSDT"}
+		if !slices.Equal(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("search_limit", func(t *testing.T) {
+		r := loadFixture(t)
+
+		if got := r.Search("", 2); len(got) != 2 {
+			t.Errorf("Expected 2 symbols, got %d", len(got))
+		}
+		if got := r.Search("", 0); len(got) != r.Len() {
+			t.Errorf("Expected all %d symbols, got %d", r.Len(), len(got))
+		}
+	})
+}
+
+func symbolNames(symbols []exchange.SymbolInfo) []string {
+	names := make([]string, len(symbols))
+	for i, symbol := range symbols {
+		names[i] = symbol.Symbol
+	}
+	return names
+}
@@ -0,0 +1,122 @@
+package svr
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultDepthLimit = 1000
+	maxDepthLimit     = 5000
+)
+
+func (s *server) ReceiveDepth(
+	req *socket.DepthRequest,
+	stream socket.SocketService_ReceiveDepthServer,
+) error {
+	symbol := strings.ToLower(req.GetSymbol())
+	if symbol == "" {
+		return status.Error(codes.InvalidArgument, "symbol is required")
+	}
+	slog.Info("Client connected to ReceiveDepth stream", "symbol", symbol)
+
+	sub := s.connManager.SubscribeDepth(symbol)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-s.mainCtx.Done():
+			slog.Info("Got Interruption signal from streaming server from main context")
+			return stream.Context().Err()
+		case frame, ok := <-sub.C:
+			if !ok {
+				slog.Warn("Output chan closed")
+				return subscriptionErr(sub.Err())
+			}
+			update, err := s.books.DecodeDepth(frame)
+			if err != nil {
+				slog.Error("Could not decode depth frame", "symbol", symbol, "error", err)
+				continue
+			}
+			if err := stream.Send(depthUpdateProto(update)); err != nil {
+				slog.Error("Could not send depth update to client", "error", err)
+				return err
+			}
+		}
+	}
+}
+
+func (s *server) GetDepthSnapshot(ctx context.Context, req *socket.DepthSnapshotRequest) (*socket.DepthSnapshot, error) {
+	symbol := strings.ToLower(req.GetSymbol())
+	if symbol == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol is required")
+	}
+
+	limit := int(req.GetLimit())
+	if limit <= 0 {
+		limit = defaultDepthLimit
+	}
+	limit = min(limit, maxDepthLimit)
+
+	ctx, cancel := context.WithTimeout(ctx, 15*time.Second)
+	defer cancel()
+
+	snapshot, err := s.books.DepthSnapshot(ctx, symbol, limit)
+	if err != nil {
+		slog.Error("Could not fetch depth snapshot", "symbol", symbol, "error", err)
+		var feedErr *exchange.FeedError
+		if errors.As(err, &feedErr) {
+			return nil, status.Error(codes.FailedPrecondition, err.Error())
+		}
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	return depthSnapshotProto(snapshot), nil
+}
+
+func (s *server) ReceiveBookTicker(
+	req *socket.BookTickerRequest,
+	stream socket.SocketService_ReceiveBookTickerServer,
+) error {
+	symbol := strings.ToLower(req.GetSymbol())
+	if symbol == "" {
+		return status.Error(codes.InvalidArgument, "symbol is required")
+	}
+	slog.Info("Client connected to ReceiveBookTicker stream", "symbol", symbol)
+
+	sub := s.connManager.SubscribeBookTicker(symbol)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-s.mainCtx.Done():
+			slog.Info("Got Interruption signal from streaming server from main context")
+			return stream.Context().Err()
+		case frame, ok := <-sub.C:
+			if !ok {
+				slog.Warn("Output chan closed")
+				return subscriptionErr(sub.Err())
+			}
+			ticker, err := s.books.DecodeBookTicker(frame)
+			if err != nil {
+				slog.Error("Could not decode bookTicker frame", "symbol", symbol, "error", err)
+				continue
+			}
+			if err := stream.Send(bookTickerProto(ticker)); err != nil {
+				slog.Error("Could not send bookTicker to client", "error", err)
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package svr
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+)
+
+// rawAggTrade is the Binance aggTrade shape the raw stream has always
+// carried. Exchange tells which venue the trade came from.
+type rawAggTrade struct {
+	EventType    string `json:"e"`
+	EventTime    int64  `json:"E"`
+	Symbol       string `json:"s"`
+	TradeID      int64  `json:"a"`
+	Price        string `json:"p"`
+	Quantity     string `json:"q"`
+	FirstTradeID int64  `json:"f"`
+	LastTradeID  int64  `json:"l"`
+	TradeTime    int64  `json:"T"`
+	BuyerMaker   bool   `json:"m"`
+	Exchange     string `json:"exchange"`
+}
+
+func encodeRawAggTrade(msg exchange.Message) ([]byte, error) {
+	return json.Marshal(rawAggTrade{
+		EventType:    "aggTrade",
+		EventTime:    time.Now().UnixMilli(),
+		Symbol:       strings.ToUpper(msg.Symbol),
+		TradeID:      msg.TradeID,
+		Price:        msg.Price,
+		Quantity:     msg.Quantity,
+		FirstTradeID: msg.FirstTradeID,
+		LastTradeID:  msg.LastTradeID,
+		TradeTime:    msg.Time.UnixMilli(),
+		BuyerMaker:   msg.BuyerMaker,
+		Exchange:     msg.Exchange,
+	})
+}
+
+func aggTradeProto(msg exchange.Message) *socket.AggTrade {
+	return &socket.AggTrade{
+		Symbol:    msg.Symbol,
+		Price:     msg.Price,
+		Quantity:  msg.Quantity,
+		TradeTime: msg.Time.UnixMilli(),
+		EventTime: time.Now().UnixMilli(),
+		Exchange:  msg.Exchange,
+
+		AggregateTradeId: msg.TradeID,
+		FirstTradeId:     msg.FirstTradeID,
+		LastTradeId:      msg.LastTradeID,
+		BuyerMaker:       msg.BuyerMaker,
+	}
+}
+
+func miniTickerProto(msg exchange.Message) *socket.MiniTicker {
+	return &socket.MiniTicker{
+		Symbol:      msg.Symbol,
+		Close:       msg.Price,
+		Open:        msg.Open,
+		High:        msg.High,
+		Low:         msg.Low,
+		BaseVolume:  msg.Volume,
+		QuoteVolume: msg.QuoteVolume,
+		EventTime:   msg.Time.UnixMilli(),
+	}
+}
+
+func levelsProto(levels []exchange.Level) []*socket.PriceLevel {
+	out := make([]*socket.PriceLevel, 0, len(levels))
+	for _, level := range levels {
+		out = append(out, &socket.PriceLevel{Price: level.Price, Quantity: level.Quantity})
+	}
+	return out
+}
+
+func depthUpdateProto(update exchange.DepthUpdate) *socket.DepthUpdate {
+	return &socket.DepthUpdate{
+		Symbol:        update.Symbol,
+		FirstUpdateId: update.FirstUpdateID,
+		FinalUpdateId: update.FinalUpdateID,
+		Bids:          levelsProto(update.Bids),
+		Asks:          levelsProto(update.Asks),
+		EventTime:     update.EventTime,
+	}
+}
+
+func depthSnapshotProto(snapshot exchange.DepthSnapshot) *socket.DepthSnapshot {
+	return &socket.DepthSnapshot{
+		Symbol:       snapshot.Symbol,
+		LastUpdateId: snapshot.LastUpdateID,
+		Bids:         levelsProto(snapshot.Bids),
+		Asks:         levelsProto(snapshot.Asks),
+	}
+}
+
+func bookTickerProto(ticker exchange.BookTicker) *socket.BookTicker {
+	return &socket.BookTicker{
+		Symbol:      ticker.Symbol,
+		UpdateId:    ticker.UpdateID,
+		BidPrice:    ticker.BidPrice,
+		BidQuantity: ticker.BidQuantity,
+		AskPrice:    ticker.AskPrice,
+		AskQuantity: ticker.AskQuantity,
+	}
+}
+
+func klineProto(kline exchange.Kline) *socket.Kline {
+	return &socket.Kline{
+		Symbol:      kline.Symbol,
+		Interval:    kline.Interval,
+		OpenTime:    kline.OpenTime,
+		CloseTime:   kline.CloseTime,
+		Open:        kline.Open,
+		High:        kline.High,
+		Low:         kline.Low,
+		Close:       kline.Close,
+		BaseVolume:  kline.Volume,
+		QuoteVolume: kline.QuoteVolume,
+		Trades:      kline.Trades,
+		Closed:      kline.Closed,
+		EventTime:   kline.EventTime,
+	}
+}
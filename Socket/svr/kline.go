@@ -0,0 +1,53 @@
+package svr
+
+import (
+	"log/slog"
+	"strings"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *server) ReceiveKline(
+	req *socket.KlineRequest,
+	stream socket.SocketService_ReceiveKlineServer,
+) error {
+	symbol := strings.ToLower(req.GetSymbol())
+	if symbol == "" {
+		return status.Error(codes.InvalidArgument, "symbol is required")
+	}
+	interval := req.GetInterval()
+	if _, ok := exchange.KlineIntervals[interval]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unsupported interval %q", interval)
+	}
+	slog.Info("Client connected to ReceiveKline stream", "symbol", symbol, "interval", interval)
+
+	sub := s.connManager.SubscribeKline(symbol, interval)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-s.mainCtx.Done():
+			slog.Info("Got Interruption signal from streaming server from main context")
+			return stream.Context().Err()
+		case frame, ok := <-sub.C:
+			if !ok {
+				slog.Warn("Output chan closed")
+				return subscriptionErr(sub.Err())
+			}
+			kline, err := s.klines.DecodeKline(frame)
+			if err != nil {
+				slog.Error("Could not decode kline frame", "symbol", symbol, "error", err)
+				continue
+			}
+			if err := stream.Send(klineProto(kline)); err != nil {
+				slog.Error("Could not send kline to client", "error", err)
+				return err
+			}
+		}
+	}
+}
@@ -2,30 +2,52 @@ package svr
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 	"net"
 	"sync"
 
 	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/connsock"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
 	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/lib/getenv"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/registry"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type ConnectionManager interface {
-	GetOrCreateConnection(symbol string) <-chan []byte
-	GetMiniTickerConnection() <-chan []byte
+	Subscribe(symbol string) *connsock.Subscription[exchange.Message]
+	SubscribeMiniTicker() *connsock.Subscription[[]byte]
+	SubscribeDepth(symbol string) *connsock.Subscription[[]byte]
+	SubscribeBookTicker(symbol string) *connsock.Subscription[[]byte]
+	SubscribeKline(symbol, interval string) *connsock.Subscription[[]byte]
 }
 
 type server struct {
 	socket.UnimplementedSocketServiceServer
 	connManager ConnectionManager
 	mainCtx     context.Context
+
+	// tickers decodes the frames of the miniTicker connection.
+	tickers exchange.Adapter
+	// books decodes depth and bookTicker frames and serves snapshots.
+	books exchange.BookSource
+	// klines decodes candle frames.
+	klines exchange.KlineSource
+	// symbols lists the Binance symbols.
+	symbols *registry.Registry
 }
 
-func register(gRPC *grpc.Server, connManager ConnectionManager, ctx context.Context) {
+func register(gRPC *grpc.Server, connManager ConnectionManager, symbols *registry.Registry, ctx context.Context) {
 	socket.RegisterSocketServiceServer(gRPC, &server{
 		connManager: connManager,
+		symbols:     symbols,
 		mainCtx:     ctx,
+		tickers:     exchange.NewBinance(),
+		books:       exchange.NewBinance().(exchange.BookSource),
+		klines:      exchange.NewBinance().(exchange.KlineSource),
 	})
 }
 
@@ -35,7 +57,8 @@ func (s *server) ReceiveRawMiniTicker(
 ) error {
 	slog.Info("Client connected to ReceiveRawMiniTicker stream")
 
-	outputChan := s.connManager.GetMiniTickerConnection()
+	sub := s.connManager.SubscribeMiniTicker()
+	defer sub.Close()
 
 	for {
 		select {
@@ -45,10 +68,10 @@ func (s *server) ReceiveRawMiniTicker(
 		case <-s.mainCtx.Done():
 			slog.Info("Got Interruption signal from streaming server from main context")
 			return stream.Context().Err()
-		case msg, ok := <-outputChan:
+		case msg, ok := <-sub.C:
 			if !ok {
 				slog.Warn("Output chan closed")
-				return nil
+				return subscriptionErr(sub.Err())
 			}
 			if err := stream.Send(&socket.RawResponse{Data: msg}); err != nil {
 				slog.Error(
@@ -69,7 +92,8 @@ func (s *server) ReceiveRawAggTrade(
 	slog.Info("Client connected to ReceiveRawMiniTicker stream")
 
 	symbol := req.Symbol
-	outputChan := s.connManager.GetOrCreateConnection(symbol)
+	sub := s.connManager.Subscribe(symbol)
+	defer sub.Close()
 
 	for {
 		select {
@@ -79,12 +103,17 @@ func (s *server) ReceiveRawAggTrade(
 		case <-s.mainCtx.Done():
 			slog.Info("Got Interruption signal from streaming server from main context")
 			return stream.Context().Err()
-		case msg, ok := <-outputChan:
+		case msg, ok := <-sub.C:
 			if !ok {
 				slog.Warn("Output chan closed")
-				return nil
+				return subscriptionErr(sub.Err())
 			}
-			if err := stream.Send(&socket.RawResponse{Data: msg}); err != nil {
+			data, err := encodeRawAggTrade(msg)
+			if err != nil {
+				slog.Error("Could not encode aggTrade", "error", err)
+				continue
+			}
+			if err := stream.Send(&socket.RawResponse{Data: data}); err != nil {
 				slog.Error(
 					"Could not send raw message from aggTrade stream to clietn",
 					"error",
@@ -96,7 +125,90 @@ func (s *server) ReceiveRawAggTrade(
 	}
 }
 
-func StartServer(wg *sync.WaitGroup, connManager ConnectionManager, ctx context.Context) {
+func (s *server) ReceiveMiniTicker(
+	req *socket.MiniTickerRequest,
+	stream socket.SocketService_ReceiveMiniTickerServer,
+) error {
+	slog.Info("Client connected to ReceiveMiniTicker stream")
+
+	sub := s.connManager.SubscribeMiniTicker()
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			slog.Warn("Got Interruption signal from streaming server from stream context")
+			return stream.Context().Err()
+		case <-s.mainCtx.Done():
+			slog.Info("Got Interruption signal from streaming server from main context")
+			return stream.Context().Err()
+		case frame, ok := <-sub.C:
+			if !ok {
+				slog.Warn("Output chan closed")
+				return subscriptionErr(sub.Err())
+			}
+			messages, err := s.tickers.Decode(frame)
+			if err != nil {
+				slog.Error("Could not decode miniTicker frame", "error", err)
+				continue
+			}
+			for _, msg := range messages {
+				if msg.Kind != exchange.KindTicker {
+					continue
+				}
+				if err := msg.Validate(); err != nil {
+					slog.Warn("Dropping invalid miniTicker", "symbol", msg.Symbol, "error", err)
+					continue
+				}
+				if err := stream.Send(miniTickerProto(msg)); err != nil {
+					slog.Error("Could not send miniTicker to client", "error", err)
+					return err
+				}
+			}
+		}
+	}
+}
+
+func (s *server) ReceiveAggTrade(
+	req *socket.AggTradeRequest,
+	stream socket.SocketService_ReceiveAggTradeServer,
+) error {
+	slog.Info("Client connected to ReceiveAggTrade stream", "symbol", req.Symbol)
+
+	sub := s.connManager.Subscribe(req.Symbol)
+	defer sub.Close()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			slog.Warn("Got Interruption signal from streaming server from stream context")
+			return stream.Context().Err()
+		case <-s.mainCtx.Done():
+			slog.Info("Got Interruption signal from streaming server from main context")
+			return stream.Context().Err()
+		case msg, ok := <-sub.C:
+			if !ok {
+				slog.Warn("Output chan closed")
+				return subscriptionErr(sub.Err())
+			}
+			if err := stream.Send(aggTradeProto(msg)); err != nil {
+				slog.Error("Could not send aggTrade to client", "error", err)
+				return err
+			}
+		}
+	}
+}
+
+// subscriptionErr tells a client disconnected for being too slow why its
+// stream ended.
+func subscriptionErr(err error) error {
+	if errors.Is(err, connsock.ErrSlowConsumer) {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return nil
+}
+
+func StartServer(wg *sync.WaitGroup, connManager ConnectionManager, symbols *registry.Registry, ctx context.Context) {
 	defer wg.Done()
 
 	address := getenv.GetString("ADDRESS", "0.0.0.0:12345")
@@ -109,7 +221,7 @@ func StartServer(wg *sync.WaitGroup, connManager ConnectionManager, ctx context.
 
 	svr := grpc.NewServer()
 
-	register(svr, connManager, ctx)
+	register(svr, connManager, symbols, ctx)
 
 	slog.Info("👂 Server listening", "address", address)
 
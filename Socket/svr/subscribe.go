@@ -0,0 +1,132 @@
+package svr
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/connsock"
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+)
+
+// Subscribe streams the trades of every symbol the client subscribed on
+// this call, until it unsubscribes them or closes the stream.
+func (s *server) Subscribe(stream socket.SocketService_SubscribeServer) error {
+	slog.Info("Client connected to Subscribe stream")
+
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	requests := make(chan *socket.SubscribeRequest)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case requests <- req:
+			}
+		}
+	}()
+
+	trades := make(chan exchange.Message, 256)
+	closed := make(chan error, 1)
+	forwards := make(map[string]context.CancelFunc)
+	defer func() {
+		for _, stop := range forwards {
+			stop()
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Warn("Got Interruption signal from streaming server from stream context")
+			return ctx.Err()
+		case <-s.mainCtx.Done():
+			slog.Info("Got Interruption signal from streaming server from main context")
+			return s.mainCtx.Err()
+		case err := <-closed:
+			return err
+		case err := <-recvErr:
+			if errors.Is(err, io.EOF) {
+				slog.Info("Client closed Subscribe stream")
+				return nil
+			}
+			return err
+		case req := <-requests:
+			for _, symbol := range normalizeSymbols(req.GetUnsubscribe()) {
+				if stop, ok := forwards[symbol]; ok {
+					stop()
+					delete(forwards, symbol)
+					slog.Info("Client unsubscribed", "symbol", symbol)
+				}
+			}
+			for _, symbol := range normalizeSymbols(req.GetSubscribe()) {
+				if _, ok := forwards[symbol]; ok {
+					continue
+				}
+				forwardCtx, stop := context.WithCancel(ctx)
+				forwards[symbol] = stop
+				go forward(forwardCtx, s.connManager.Subscribe(symbol), trades, closed)
+				slog.Info("Client subscribed", "symbol", symbol)
+			}
+		case msg := <-trades:
+			if err := stream.Send(aggTradeProto(msg)); err != nil {
+				slog.Error("Could not send aggTrade to client", "error", err)
+				return err
+			}
+		}
+	}
+}
+
+// forward copies the trades of one symbol into the stream of a Subscribe
+// call until ctx is cancelled. A subscription dropped for being too slow
+// ends the whole call through closed.
+func forward(
+	ctx context.Context,
+	sub *connsock.Subscription[exchange.Message],
+	out chan<- exchange.Message,
+	closed chan<- error,
+) {
+	defer sub.Close()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-sub.C:
+			if !ok {
+				select {
+				case closed <- subscriptionErr(sub.Err()):
+				default:
+				}
+				return
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case out <- msg:
+			}
+		}
+	}
+}
+
+func normalizeSymbols(symbols []string) []string {
+	normalized := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		symbol = strings.ToLower(strings.TrimSpace(symbol))
+		if symbol != "" {
+			normalized = append(normalized, symbol)
+		}
+	}
+	return normalized
+}
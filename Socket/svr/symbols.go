@@ -0,0 +1,43 @@
+package svr
+
+import (
+	"context"
+
+	"github.com/Tonic56/crypto-asset-tracker-microservice/Socket/exchange"
+	socket "github.com/Tonic56/proto-crypto-asset-tracker/proto/gen/go/socket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *server) ListSymbols(_ context.Context, req *socket.ListSymbolsRequest) (*socket.ListSymbolsResponse, error) {
+	if s.symbols.Len() == 0 {
+		return nil, status.Error(codes.Unavailable, "symbol registry is not loaded yet")
+	}
+	if req.GetLimit() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "limit cannot be negative")
+	}
+
+	symbols := s.symbols.Search(req.GetSearch(), int(req.GetLimit()))
+
+	resp := &socket.ListSymbolsResponse{
+		Symbols: make([]*socket.SymbolInfo, 0, len(symbols)),
+	}
+	for _, symbol := range symbols {
+		resp.Symbols = append(resp.Symbols, symbolInfoProto(symbol))
+	}
+	return resp, nil
+}
+
+func symbolInfoProto(symbol exchange.SymbolInfo) *socket.SymbolInfo {
+	return &socket.SymbolInfo{
+		Symbol:      symbol.Symbol,
+		BaseAsset:   symbol.BaseAsset,
+		QuoteAsset:  symbol.QuoteAsset,
+		Status:      symbol.Status,
+		TickSize:    symbol.TickSize,
+		StepSize:    symbol.StepSize,
+		MinQty:      symbol.MinQty,
+		MaxQty:      symbol.MaxQty,
+		MinNotional: symbol.MinNotional,
+	}
+}
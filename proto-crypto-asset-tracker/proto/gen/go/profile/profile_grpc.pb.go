@@ -0,0 +1,499 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: profile/profile.proto
+
+package profile
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ProfileClient is the client API for Profile service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ProfileClient interface {
+	GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*GetUserProfileResponse, error)
+	UpdateCoinQuantity(ctx context.Context, in *UpdateCoinQuantityRequest, opts ...grpc.CallOption) (*UpdateCoinQuantityResponse, error)
+	DeleteCoin(ctx context.Context, in *DeleteCoinRequest, opts ...grpc.CallOption) (*DeleteCoinResponse, error)
+	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
+	EditTransaction(ctx context.Context, in *EditTransactionRequest, opts ...grpc.CallOption) (*EditTransactionResponse, error)
+	VoidTransaction(ctx context.Context, in *VoidTransactionRequest, opts ...grpc.CallOption) (*VoidTransactionResponse, error)
+	SetCostMethod(ctx context.Context, in *SetCostMethodRequest, opts ...grpc.CallOption) (*SetCostMethodResponse, error)
+	ListPortfolios(ctx context.Context, in *ListPortfoliosRequest, opts ...grpc.CallOption) (*ListPortfoliosResponse, error)
+	GetPortfolio(ctx context.Context, in *GetPortfolioRequest, opts ...grpc.CallOption) (*GetPortfolioResponse, error)
+	CreatePortfolio(ctx context.Context, in *CreatePortfolioRequest, opts ...grpc.CallOption) (*CreatePortfolioResponse, error)
+	RenamePortfolio(ctx context.Context, in *RenamePortfolioRequest, opts ...grpc.CallOption) (*RenamePortfolioResponse, error)
+	DeletePortfolio(ctx context.Context, in *DeletePortfolioRequest, opts ...grpc.CallOption) (*DeletePortfolioResponse, error)
+}
+
+type profileClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewProfileClient(cc grpc.ClientConnInterface) ProfileClient {
+	return &profileClient{cc}
+}
+
+func (c *profileClient) GetUserProfile(ctx context.Context, in *GetUserProfileRequest, opts ...grpc.CallOption) (*GetUserProfileResponse, error) {
+	out := new(GetUserProfileResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/GetUserProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) UpdateCoinQuantity(ctx context.Context, in *UpdateCoinQuantityRequest, opts ...grpc.CallOption) (*UpdateCoinQuantityResponse, error) {
+	out := new(UpdateCoinQuantityResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/UpdateCoinQuantity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) DeleteCoin(ctx context.Context, in *DeleteCoinRequest, opts ...grpc.CallOption) (*DeleteCoinResponse, error) {
+	out := new(DeleteCoinResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/DeleteCoin", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error) {
+	out := new(ListTransactionsResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/ListTransactions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) EditTransaction(ctx context.Context, in *EditTransactionRequest, opts ...grpc.CallOption) (*EditTransactionResponse, error) {
+	out := new(EditTransactionResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/EditTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) VoidTransaction(ctx context.Context, in *VoidTransactionRequest, opts ...grpc.CallOption) (*VoidTransactionResponse, error) {
+	out := new(VoidTransactionResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/VoidTransaction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) SetCostMethod(ctx context.Context, in *SetCostMethodRequest, opts ...grpc.CallOption) (*SetCostMethodResponse, error) {
+	out := new(SetCostMethodResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/SetCostMethod", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) ListPortfolios(ctx context.Context, in *ListPortfoliosRequest, opts ...grpc.CallOption) (*ListPortfoliosResponse, error) {
+	out := new(ListPortfoliosResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/ListPortfolios", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) GetPortfolio(ctx context.Context, in *GetPortfolioRequest, opts ...grpc.CallOption) (*GetPortfolioResponse, error) {
+	out := new(GetPortfolioResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/GetPortfolio", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) CreatePortfolio(ctx context.Context, in *CreatePortfolioRequest, opts ...grpc.CallOption) (*CreatePortfolioResponse, error) {
+	out := new(CreatePortfolioResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/CreatePortfolio", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) RenamePortfolio(ctx context.Context, in *RenamePortfolioRequest, opts ...grpc.CallOption) (*RenamePortfolioResponse, error) {
+	out := new(RenamePortfolioResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/RenamePortfolio", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) DeletePortfolio(ctx context.Context, in *DeletePortfolioRequest, opts ...grpc.CallOption) (*DeletePortfolioResponse, error) {
+	out := new(DeletePortfolioResponse)
+	err := c.cc.Invoke(ctx, "/profile.Profile/DeletePortfolio", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ProfileServer is the server API for Profile service.
+// All implementations should embed UnimplementedProfileServer
+// for forward compatibility
+type ProfileServer interface {
+	GetUserProfile(context.Context, *GetUserProfileRequest) (*GetUserProfileResponse, error)
+	UpdateCoinQuantity(context.Context, *UpdateCoinQuantityRequest) (*UpdateCoinQuantityResponse, error)
+	DeleteCoin(context.Context, *DeleteCoinRequest) (*DeleteCoinResponse, error)
+	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
+	EditTransaction(context.Context, *EditTransactionRequest) (*EditTransactionResponse, error)
+	VoidTransaction(context.Context, *VoidTransactionRequest) (*VoidTransactionResponse, error)
+	SetCostMethod(context.Context, *SetCostMethodRequest) (*SetCostMethodResponse, error)
+	ListPortfolios(context.Context, *ListPortfoliosRequest) (*ListPortfoliosResponse, error)
+	GetPortfolio(context.Context, *GetPortfolioRequest) (*GetPortfolioResponse, error)
+	CreatePortfolio(context.Context, *CreatePortfolioRequest) (*CreatePortfolioResponse, error)
+	RenamePortfolio(context.Context, *RenamePortfolioRequest) (*RenamePortfolioResponse, error)
+	DeletePortfolio(context.Context, *DeletePortfolioRequest) (*DeletePortfolioResponse, error)
+}
+
+// UnimplementedProfileServer should be embedded to have forward compatible implementations.
+type UnimplementedProfileServer struct {
+}
+
+func (UnimplementedProfileServer) GetUserProfile(context.Context, *GetUserProfileRequest) (*GetUserProfileResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserProfile not implemented")
+}
+func (UnimplementedProfileServer) UpdateCoinQuantity(context.Context, *UpdateCoinQuantityRequest) (*UpdateCoinQuantityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateCoinQuantity not implemented")
+}
+func (UnimplementedProfileServer) DeleteCoin(context.Context, *DeleteCoinRequest) (*DeleteCoinResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteCoin not implemented")
+}
+func (UnimplementedProfileServer) ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTransactions not implemented")
+}
+func (UnimplementedProfileServer) EditTransaction(context.Context, *EditTransactionRequest) (*EditTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EditTransaction not implemented")
+}
+func (UnimplementedProfileServer) VoidTransaction(context.Context, *VoidTransactionRequest) (*VoidTransactionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VoidTransaction not implemented")
+}
+func (UnimplementedProfileServer) SetCostMethod(context.Context, *SetCostMethodRequest) (*SetCostMethodResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetCostMethod not implemented")
+}
+func (UnimplementedProfileServer) ListPortfolios(context.Context, *ListPortfoliosRequest) (*ListPortfoliosResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPortfolios not implemented")
+}
+func (UnimplementedProfileServer) GetPortfolio(context.Context, *GetPortfolioRequest) (*GetPortfolioResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolio not implemented")
+}
+func (UnimplementedProfileServer) CreatePortfolio(context.Context, *CreatePortfolioRequest) (*CreatePortfolioResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePortfolio not implemented")
+}
+func (UnimplementedProfileServer) RenamePortfolio(context.Context, *RenamePortfolioRequest) (*RenamePortfolioResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenamePortfolio not implemented")
+}
+func (UnimplementedProfileServer) DeletePortfolio(context.Context, *DeletePortfolioRequest) (*DeletePortfolioResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePortfolio not implemented")
+}
+
+// UnsafeProfileServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ProfileServer will
+// result in compilation errors.
+type UnsafeProfileServer interface {
+	mustEmbedUnimplementedProfileServer()
+}
+
+func RegisterProfileServer(s grpc.ServiceRegistrar, srv ProfileServer) {
+	s.RegisterService(&Profile_ServiceDesc, srv)
+}
+
+func _Profile_GetUserProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserProfileRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).GetUserProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/GetUserProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).GetUserProfile(ctx, req.(*GetUserProfileRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_UpdateCoinQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCoinQuantityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).UpdateCoinQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/UpdateCoinQuantity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).UpdateCoinQuantity(ctx, req.(*UpdateCoinQuantityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_DeleteCoin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCoinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).DeleteCoin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/DeleteCoin",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).DeleteCoin(ctx, req.(*DeleteCoinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_ListTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).ListTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/ListTransactions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).ListTransactions(ctx, req.(*ListTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_EditTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).EditTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/EditTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).EditTransaction(ctx, req.(*EditTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_VoidTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidTransactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).VoidTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/VoidTransaction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).VoidTransaction(ctx, req.(*VoidTransactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_SetCostMethod_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCostMethodRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).SetCostMethod(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/SetCostMethod",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).SetCostMethod(ctx, req.(*SetCostMethodRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_ListPortfolios_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPortfoliosRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).ListPortfolios(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/ListPortfolios",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).ListPortfolios(ctx, req.(*ListPortfoliosRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_GetPortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).GetPortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/GetPortfolio",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).GetPortfolio(ctx, req.(*GetPortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_CreatePortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).CreatePortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/CreatePortfolio",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).CreatePortfolio(ctx, req.(*CreatePortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_RenamePortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenamePortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).RenamePortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/RenamePortfolio",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).RenamePortfolio(ctx, req.(*RenamePortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_DeletePortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).DeletePortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/profile.Profile/DeletePortfolio",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).DeletePortfolio(ctx, req.(*DeletePortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Profile_ServiceDesc is the grpc.ServiceDesc for Profile service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Profile_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "profile.Profile",
+	HandlerType: (*ProfileServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetUserProfile",
+			Handler:    _Profile_GetUserProfile_Handler,
+		},
+		{
+			MethodName: "UpdateCoinQuantity",
+			Handler:    _Profile_UpdateCoinQuantity_Handler,
+		},
+		{
+			MethodName: "DeleteCoin",
+			Handler:    _Profile_DeleteCoin_Handler,
+		},
+		{
+			MethodName: "ListTransactions",
+			Handler:    _Profile_ListTransactions_Handler,
+		},
+		{
+			MethodName: "EditTransaction",
+			Handler:    _Profile_EditTransaction_Handler,
+		},
+		{
+			MethodName: "VoidTransaction",
+			Handler:    _Profile_VoidTransaction_Handler,
+		},
+		{
+			MethodName: "SetCostMethod",
+			Handler:    _Profile_SetCostMethod_Handler,
+		},
+		{
+			MethodName: "ListPortfolios",
+			Handler:    _Profile_ListPortfolios_Handler,
+		},
+		{
+			MethodName: "GetPortfolio",
+			Handler:    _Profile_GetPortfolio_Handler,
+		},
+		{
+			MethodName: "CreatePortfolio",
+			Handler:    _Profile_CreatePortfolio_Handler,
+		},
+		{
+			MethodName: "RenamePortfolio",
+			Handler:    _Profile_RenamePortfolio_Handler,
+		},
+		{
+			MethodName: "DeletePortfolio",
+			Handler:    _Profile_DeletePortfolio_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "profile/profile.proto",
+}
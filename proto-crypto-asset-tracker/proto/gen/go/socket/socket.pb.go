@@ -0,0 +1,1935 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: socket/socket.proto
+
+package socket
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RawAggTradeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (x *RawAggTradeRequest) Reset() {
+	*x = RawAggTradeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawAggTradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawAggTradeRequest) ProtoMessage() {}
+
+func (x *RawAggTradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawAggTradeRequest.ProtoReflect.Descriptor instead.
+func (*RawAggTradeRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RawAggTradeRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type RawMiniTickerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RawMiniTickerRequest) Reset() {
+	*x = RawMiniTickerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawMiniTickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawMiniTickerRequest) ProtoMessage() {}
+
+func (x *RawMiniTickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawMiniTickerRequest.ProtoReflect.Descriptor instead.
+func (*RawMiniTickerRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{1}
+}
+
+type RawResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *RawResponse) Reset() {
+	*x = RawResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RawResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RawResponse) ProtoMessage() {}
+
+func (x *RawResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RawResponse.ProtoReflect.Descriptor instead.
+func (*RawResponse) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RawResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type AggTradeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (x *AggTradeRequest) Reset() {
+	*x = AggTradeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggTradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggTradeRequest) ProtoMessage() {}
+
+func (x *AggTradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggTradeRequest.ProtoReflect.Descriptor instead.
+func (*AggTradeRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AggTradeRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// Prices and quantities are decimal strings, e.g. "65000.10". A Binance
+// trade aggregates the fills first_trade_id to last_trade_id; trades of
+// other venues are single fills. buyer_maker is set when the taker sold.
+type AggTrade struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol           string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Price            string `protobuf:"bytes,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity         string `protobuf:"bytes,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TradeTime        int64  `protobuf:"varint,4,opt,name=trade_time,json=tradeTime,proto3" json:"trade_time,omitempty"`
+	EventTime        int64  `protobuf:"varint,5,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+	Exchange         string `protobuf:"bytes,6,opt,name=exchange,proto3" json:"exchange,omitempty"`
+	AggregateTradeId int64  `protobuf:"varint,7,opt,name=aggregate_trade_id,json=aggregateTradeId,proto3" json:"aggregate_trade_id,omitempty"`
+	FirstTradeId     int64  `protobuf:"varint,8,opt,name=first_trade_id,json=firstTradeId,proto3" json:"first_trade_id,omitempty"`
+	LastTradeId      int64  `protobuf:"varint,9,opt,name=last_trade_id,json=lastTradeId,proto3" json:"last_trade_id,omitempty"`
+	BuyerMaker       bool   `protobuf:"varint,10,opt,name=buyer_maker,json=buyerMaker,proto3" json:"buyer_maker,omitempty"`
+}
+
+func (x *AggTrade) Reset() {
+	*x = AggTrade{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggTrade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggTrade) ProtoMessage() {}
+
+func (x *AggTrade) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggTrade.ProtoReflect.Descriptor instead.
+func (*AggTrade) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *AggTrade) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *AggTrade) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *AggTrade) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+func (x *AggTrade) GetTradeTime() int64 {
+	if x != nil {
+		return x.TradeTime
+	}
+	return 0
+}
+
+func (x *AggTrade) GetEventTime() int64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+func (x *AggTrade) GetExchange() string {
+	if x != nil {
+		return x.Exchange
+	}
+	return ""
+}
+
+func (x *AggTrade) GetAggregateTradeId() int64 {
+	if x != nil {
+		return x.AggregateTradeId
+	}
+	return 0
+}
+
+func (x *AggTrade) GetFirstTradeId() int64 {
+	if x != nil {
+		return x.FirstTradeId
+	}
+	return 0
+}
+
+func (x *AggTrade) GetLastTradeId() int64 {
+	if x != nil {
+		return x.LastTradeId
+	}
+	return 0
+}
+
+func (x *AggTrade) GetBuyerMaker() bool {
+	if x != nil {
+		return x.BuyerMaker
+	}
+	return false
+}
+
+// SubscribeRequest changes the symbols streamed on a Subscribe call.
+// Subscribing a streamed symbol or unsubscribing an unknown one is a no-op.
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Subscribe   []string `protobuf:"bytes,1,rep,name=subscribe,proto3" json:"subscribe,omitempty"`
+	Unsubscribe []string `protobuf:"bytes,2,rep,name=unsubscribe,proto3" json:"unsubscribe,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SubscribeRequest) GetSubscribe() []string {
+	if x != nil {
+		return x.Subscribe
+	}
+	return nil
+}
+
+func (x *SubscribeRequest) GetUnsubscribe() []string {
+	if x != nil {
+		return x.Unsubscribe
+	}
+	return nil
+}
+
+type MiniTickerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *MiniTickerRequest) Reset() {
+	*x = MiniTickerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MiniTickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MiniTickerRequest) ProtoMessage() {}
+
+func (x *MiniTickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MiniTickerRequest.ProtoReflect.Descriptor instead.
+func (*MiniTickerRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{6}
+}
+
+// MiniTicker describes the rolling 24h window of one symbol.
+type MiniTicker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol      string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Close       string `protobuf:"bytes,2,opt,name=close,proto3" json:"close,omitempty"`
+	Open        string `protobuf:"bytes,3,opt,name=open,proto3" json:"open,omitempty"`
+	High        string `protobuf:"bytes,4,opt,name=high,proto3" json:"high,omitempty"`
+	Low         string `protobuf:"bytes,5,opt,name=low,proto3" json:"low,omitempty"`
+	BaseVolume  string `protobuf:"bytes,6,opt,name=base_volume,json=baseVolume,proto3" json:"base_volume,omitempty"`
+	QuoteVolume string `protobuf:"bytes,7,opt,name=quote_volume,json=quoteVolume,proto3" json:"quote_volume,omitempty"`
+	EventTime   int64  `protobuf:"varint,8,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+}
+
+func (x *MiniTicker) Reset() {
+	*x = MiniTicker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MiniTicker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MiniTicker) ProtoMessage() {}
+
+func (x *MiniTicker) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MiniTicker.ProtoReflect.Descriptor instead.
+func (*MiniTicker) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MiniTicker) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *MiniTicker) GetClose() string {
+	if x != nil {
+		return x.Close
+	}
+	return ""
+}
+
+func (x *MiniTicker) GetOpen() string {
+	if x != nil {
+		return x.Open
+	}
+	return ""
+}
+
+func (x *MiniTicker) GetHigh() string {
+	if x != nil {
+		return x.High
+	}
+	return ""
+}
+
+func (x *MiniTicker) GetLow() string {
+	if x != nil {
+		return x.Low
+	}
+	return ""
+}
+
+func (x *MiniTicker) GetBaseVolume() string {
+	if x != nil {
+		return x.BaseVolume
+	}
+	return ""
+}
+
+func (x *MiniTicker) GetQuoteVolume() string {
+	if x != nil {
+		return x.QuoteVolume
+	}
+	return ""
+}
+
+func (x *MiniTicker) GetEventTime() int64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+type PriceLevel struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Price    string `protobuf:"bytes,1,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity string `protobuf:"bytes,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+}
+
+func (x *PriceLevel) Reset() {
+	*x = PriceLevel{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PriceLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLevel) ProtoMessage() {}
+
+func (x *PriceLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *PriceLevel) GetPrice() string {
+	if x != nil {
+		return x.Price
+	}
+	return ""
+}
+
+func (x *PriceLevel) GetQuantity() string {
+	if x != nil {
+		return x.Quantity
+	}
+	return ""
+}
+
+type DepthRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (x *DepthRequest) Reset() {
+	*x = DepthRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DepthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepthRequest) ProtoMessage() {}
+
+func (x *DepthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepthRequest.ProtoReflect.Descriptor instead.
+func (*DepthRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DepthRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// DepthUpdate holds the levels changed between first_update_id and
+// final_update_id. A zero quantity removes the level.
+type DepthUpdate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol        string        `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	FirstUpdateId int64         `protobuf:"varint,2,opt,name=first_update_id,json=firstUpdateId,proto3" json:"first_update_id,omitempty"`
+	FinalUpdateId int64         `protobuf:"varint,3,opt,name=final_update_id,json=finalUpdateId,proto3" json:"final_update_id,omitempty"`
+	Bids          []*PriceLevel `protobuf:"bytes,4,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks          []*PriceLevel `protobuf:"bytes,5,rep,name=asks,proto3" json:"asks,omitempty"`
+	EventTime     int64         `protobuf:"varint,6,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+}
+
+func (x *DepthUpdate) Reset() {
+	*x = DepthUpdate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DepthUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepthUpdate) ProtoMessage() {}
+
+func (x *DepthUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepthUpdate.ProtoReflect.Descriptor instead.
+func (*DepthUpdate) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *DepthUpdate) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *DepthUpdate) GetFirstUpdateId() int64 {
+	if x != nil {
+		return x.FirstUpdateId
+	}
+	return 0
+}
+
+func (x *DepthUpdate) GetFinalUpdateId() int64 {
+	if x != nil {
+		return x.FinalUpdateId
+	}
+	return 0
+}
+
+func (x *DepthUpdate) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *DepthUpdate) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *DepthUpdate) GetEventTime() int64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+type DepthSnapshotRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *DepthSnapshotRequest) Reset() {
+	*x = DepthSnapshotRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DepthSnapshotRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepthSnapshotRequest) ProtoMessage() {}
+
+func (x *DepthSnapshotRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepthSnapshotRequest.ProtoReflect.Descriptor instead.
+func (*DepthSnapshotRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DepthSnapshotRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *DepthSnapshotRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// DepthSnapshot is the book as of last_update_id, best levels first.
+type DepthSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol       string        `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	LastUpdateId int64         `protobuf:"varint,2,opt,name=last_update_id,json=lastUpdateId,proto3" json:"last_update_id,omitempty"`
+	Bids         []*PriceLevel `protobuf:"bytes,3,rep,name=bids,proto3" json:"bids,omitempty"`
+	Asks         []*PriceLevel `protobuf:"bytes,4,rep,name=asks,proto3" json:"asks,omitempty"`
+}
+
+func (x *DepthSnapshot) Reset() {
+	*x = DepthSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DepthSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DepthSnapshot) ProtoMessage() {}
+
+func (x *DepthSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DepthSnapshot.ProtoReflect.Descriptor instead.
+func (*DepthSnapshot) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *DepthSnapshot) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *DepthSnapshot) GetLastUpdateId() int64 {
+	if x != nil {
+		return x.LastUpdateId
+	}
+	return 0
+}
+
+func (x *DepthSnapshot) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *DepthSnapshot) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+type BookTickerRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+}
+
+func (x *BookTickerRequest) Reset() {
+	*x = BookTickerRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookTickerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookTickerRequest) ProtoMessage() {}
+
+func (x *BookTickerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookTickerRequest.ProtoReflect.Descriptor instead.
+func (*BookTickerRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BookTickerRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+// BookTicker is the best bid and ask of one symbol.
+type BookTicker struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol      string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	UpdateId    int64  `protobuf:"varint,2,opt,name=update_id,json=updateId,proto3" json:"update_id,omitempty"`
+	BidPrice    string `protobuf:"bytes,3,opt,name=bid_price,json=bidPrice,proto3" json:"bid_price,omitempty"`
+	BidQuantity string `protobuf:"bytes,4,opt,name=bid_quantity,json=bidQuantity,proto3" json:"bid_quantity,omitempty"`
+	AskPrice    string `protobuf:"bytes,5,opt,name=ask_price,json=askPrice,proto3" json:"ask_price,omitempty"`
+	AskQuantity string `protobuf:"bytes,6,opt,name=ask_quantity,json=askQuantity,proto3" json:"ask_quantity,omitempty"`
+}
+
+func (x *BookTicker) Reset() {
+	*x = BookTicker{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BookTicker) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookTicker) ProtoMessage() {}
+
+func (x *BookTicker) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookTicker.ProtoReflect.Descriptor instead.
+func (*BookTicker) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *BookTicker) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *BookTicker) GetUpdateId() int64 {
+	if x != nil {
+		return x.UpdateId
+	}
+	return 0
+}
+
+func (x *BookTicker) GetBidPrice() string {
+	if x != nil {
+		return x.BidPrice
+	}
+	return ""
+}
+
+func (x *BookTicker) GetBidQuantity() string {
+	if x != nil {
+		return x.BidQuantity
+	}
+	return ""
+}
+
+func (x *BookTicker) GetAskPrice() string {
+	if x != nil {
+		return x.AskPrice
+	}
+	return ""
+}
+
+func (x *BookTicker) GetAskQuantity() string {
+	if x != nil {
+		return x.AskQuantity
+	}
+	return ""
+}
+
+// KlineRequest names a Binance interval, e.g. "1m", "1h" or "1d".
+type KlineRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol   string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Interval string `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+}
+
+func (x *KlineRequest) Reset() {
+	*x = KlineRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KlineRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KlineRequest) ProtoMessage() {}
+
+func (x *KlineRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KlineRequest.ProtoReflect.Descriptor instead.
+func (*KlineRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *KlineRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *KlineRequest) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+// Kline is a candle of symbol. It is updated until closed is set; open_time
+// and close_time are Unix milliseconds.
+type Kline struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol      string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Interval    string `protobuf:"bytes,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	OpenTime    int64  `protobuf:"varint,3,opt,name=open_time,json=openTime,proto3" json:"open_time,omitempty"`
+	CloseTime   int64  `protobuf:"varint,4,opt,name=close_time,json=closeTime,proto3" json:"close_time,omitempty"`
+	Open        string `protobuf:"bytes,5,opt,name=open,proto3" json:"open,omitempty"`
+	High        string `protobuf:"bytes,6,opt,name=high,proto3" json:"high,omitempty"`
+	Low         string `protobuf:"bytes,7,opt,name=low,proto3" json:"low,omitempty"`
+	Close       string `protobuf:"bytes,8,opt,name=close,proto3" json:"close,omitempty"`
+	BaseVolume  string `protobuf:"bytes,9,opt,name=base_volume,json=baseVolume,proto3" json:"base_volume,omitempty"`
+	QuoteVolume string `protobuf:"bytes,10,opt,name=quote_volume,json=quoteVolume,proto3" json:"quote_volume,omitempty"`
+	Trades      int64  `protobuf:"varint,11,opt,name=trades,proto3" json:"trades,omitempty"`
+	Closed      bool   `protobuf:"varint,12,opt,name=closed,proto3" json:"closed,omitempty"`
+	EventTime   int64  `protobuf:"varint,13,opt,name=event_time,json=eventTime,proto3" json:"event_time,omitempty"`
+}
+
+func (x *Kline) Reset() {
+	*x = Kline{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Kline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Kline) ProtoMessage() {}
+
+func (x *Kline) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Kline.ProtoReflect.Descriptor instead.
+func (*Kline) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Kline) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Kline) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *Kline) GetOpenTime() int64 {
+	if x != nil {
+		return x.OpenTime
+	}
+	return 0
+}
+
+func (x *Kline) GetCloseTime() int64 {
+	if x != nil {
+		return x.CloseTime
+	}
+	return 0
+}
+
+func (x *Kline) GetOpen() string {
+	if x != nil {
+		return x.Open
+	}
+	return ""
+}
+
+func (x *Kline) GetHigh() string {
+	if x != nil {
+		return x.High
+	}
+	return ""
+}
+
+func (x *Kline) GetLow() string {
+	if x != nil {
+		return x.Low
+	}
+	return ""
+}
+
+func (x *Kline) GetClose() string {
+	if x != nil {
+		return x.Close
+	}
+	return ""
+}
+
+func (x *Kline) GetBaseVolume() string {
+	if x != nil {
+		return x.BaseVolume
+	}
+	return ""
+}
+
+func (x *Kline) GetQuoteVolume() string {
+	if x != nil {
+		return x.QuoteVolume
+	}
+	return ""
+}
+
+func (x *Kline) GetTrades() int64 {
+	if x != nil {
+		return x.Trades
+	}
+	return 0
+}
+
+func (x *Kline) GetClosed() bool {
+	if x != nil {
+		return x.Closed
+	}
+	return false
+}
+
+func (x *Kline) GetEventTime() int64 {
+	if x != nil {
+		return x.EventTime
+	}
+	return 0
+}
+
+// ListSymbolsRequest searches the symbol registry. An empty search lists
+// every symbol; a limit of 0 returns all matches.
+type ListSymbolsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Search string `protobuf:"bytes,1,opt,name=search,proto3" json:"search,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *ListSymbolsRequest) Reset() {
+	*x = ListSymbolsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSymbolsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSymbolsRequest) ProtoMessage() {}
+
+func (x *ListSymbolsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSymbolsRequest.ProtoReflect.Descriptor instead.
+func (*ListSymbolsRequest) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListSymbolsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListSymbolsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+// SymbolInfo is the exchange metadata of a Binance symbol. Filters are
+// decimal strings, empty when the exchange sets none.
+type SymbolInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbol      string `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	BaseAsset   string `protobuf:"bytes,2,opt,name=base_asset,json=baseAsset,proto3" json:"base_asset,omitempty"`
+	QuoteAsset  string `protobuf:"bytes,3,opt,name=quote_asset,json=quoteAsset,proto3" json:"quote_asset,omitempty"`
+	Status      string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	TickSize    string `protobuf:"bytes,5,opt,name=tick_size,json=tickSize,proto3" json:"tick_size,omitempty"`
+	StepSize    string `protobuf:"bytes,6,opt,name=step_size,json=stepSize,proto3" json:"step_size,omitempty"`
+	MinQty      string `protobuf:"bytes,7,opt,name=min_qty,json=minQty,proto3" json:"min_qty,omitempty"`
+	MaxQty      string `protobuf:"bytes,8,opt,name=max_qty,json=maxQty,proto3" json:"max_qty,omitempty"`
+	MinNotional string `protobuf:"bytes,9,opt,name=min_notional,json=minNotional,proto3" json:"min_notional,omitempty"`
+}
+
+func (x *SymbolInfo) Reset() {
+	*x = SymbolInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SymbolInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SymbolInfo) ProtoMessage() {}
+
+func (x *SymbolInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SymbolInfo.ProtoReflect.Descriptor instead.
+func (*SymbolInfo) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SymbolInfo) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *SymbolInfo) GetBaseAsset() string {
+	if x != nil {
+		return x.BaseAsset
+	}
+	return ""
+}
+
+func (x *SymbolInfo) GetQuoteAsset() string {
+	if x != nil {
+		return x.QuoteAsset
+	}
+	return ""
+}
+
+func (x *SymbolInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SymbolInfo) GetTickSize() string {
+	if x != nil {
+		return x.TickSize
+	}
+	return ""
+}
+
+func (x *SymbolInfo) GetStepSize() string {
+	if x != nil {
+		return x.StepSize
+	}
+	return ""
+}
+
+func (x *SymbolInfo) GetMinQty() string {
+	if x != nil {
+		return x.MinQty
+	}
+	return ""
+}
+
+func (x *SymbolInfo) GetMaxQty() string {
+	if x != nil {
+		return x.MaxQty
+	}
+	return ""
+}
+
+func (x *SymbolInfo) GetMinNotional() string {
+	if x != nil {
+		return x.MinNotional
+	}
+	return ""
+}
+
+type ListSymbolsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Symbols []*SymbolInfo `protobuf:"bytes,1,rep,name=symbols,proto3" json:"symbols,omitempty"`
+}
+
+func (x *ListSymbolsResponse) Reset() {
+	*x = ListSymbolsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_socket_socket_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListSymbolsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSymbolsResponse) ProtoMessage() {}
+
+func (x *ListSymbolsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_socket_socket_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSymbolsResponse.ProtoReflect.Descriptor instead.
+func (*ListSymbolsResponse) Descriptor() ([]byte, []int) {
+	return file_socket_socket_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListSymbolsResponse) GetSymbols() []*SymbolInfo {
+	if x != nil {
+		return x.Symbols
+	}
+	return nil
+}
+
+var File_socket_socket_proto protoreflect.FileDescriptor
+
+var file_socket_socket_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x22, 0x2c, 0x0a,
+	0x12, 0x52, 0x61, 0x77, 0x41, 0x67, 0x67, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0x16, 0x0a, 0x14, 0x52,
+	0x61, 0x77, 0x4d, 0x69, 0x6e, 0x69, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x22, 0x21, 0x0a, 0x0b, 0x52, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x29, 0x0a, 0x0f, 0x41, 0x67, 0x67, 0x54, 0x72, 0x61,
+	0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f,
+	0x6c, 0x22, 0xc7, 0x02, 0x0a, 0x08, 0x41, 0x67, 0x67, 0x54, 0x72, 0x61, 0x64, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x72, 0x61, 0x64,
+	0x65, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x74, 0x72,
+	0x61, 0x64, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74,
+	0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x78, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x12, 0x2c, 0x0a, 0x12, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x5f,
+	0x74, 0x72, 0x61, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x54, 0x72, 0x61, 0x64, 0x65, 0x49, 0x64,
+	0x12, 0x24, 0x0a, 0x0e, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x74, 0x72, 0x61, 0x64, 0x65, 0x5f,
+	0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x66, 0x69, 0x72, 0x73, 0x74, 0x54,
+	0x72, 0x61, 0x64, 0x65, 0x49, 0x64, 0x12, 0x22, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x74,
+	0x72, 0x61, 0x64, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6c,
+	0x61, 0x73, 0x74, 0x54, 0x72, 0x61, 0x64, 0x65, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x75,
+	0x79, 0x65, 0x72, 0x5f, 0x6d, 0x61, 0x6b, 0x65, 0x72, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x0a, 0x62, 0x75, 0x79, 0x65, 0x72, 0x4d, 0x61, 0x6b, 0x65, 0x72, 0x22, 0x52, 0x0a, 0x10, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x09, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x20, 0x0a,
+	0x0b, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0b, 0x75, 0x6e, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x22,
+	0x13, 0x0a, 0x11, 0x4d, 0x69, 0x6e, 0x69, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x22, 0xd7, 0x01, 0x0a, 0x0a, 0x4d, 0x69, 0x6e, 0x69, 0x54, 0x69, 0x63,
+	0x6b, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a, 0x05, 0x63,
+	0x6c, 0x6f, 0x73, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x6f, 0x73,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f, 0x77,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x1f, 0x0a, 0x0b, 0x62,
+	0x61, 0x73, 0x65, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x3e,
+	0x0a, 0x0a, 0x50, 0x72, 0x69, 0x63, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x12, 0x14, 0x0a, 0x05,
+	0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x72, 0x69,
+	0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74, 0x79, 0x22, 0x26,
+	0x0a, 0x0c, 0x44, 0x65, 0x70, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x22, 0xe4, 0x01, 0x0a, 0x0b, 0x44, 0x65, 0x70, 0x74, 0x68,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x26,
+	0x0a, 0x0f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x66, 0x69, 0x72, 0x73, 0x74, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x0f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x5f,
+	0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0d, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x49, 0x64, 0x12, 0x26,
+	0x0a, 0x04, 0x62, 0x69, 0x64, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73,
+	0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c,
+	0x52, 0x04, 0x62, 0x69, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x05,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x50, 0x72,
+	0x69, 0x63, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x1d,
+	0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x44, 0x0a,
+	0x14, 0x44, 0x65, 0x70, 0x74, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x14, 0x0a,
+	0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x22, 0x9d, 0x01, 0x0a, 0x0d, 0x44, 0x65, 0x70, 0x74, 0x68, 0x53, 0x6e, 0x61,
+	0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x24, 0x0a,
+	0x0e, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x49, 0x64, 0x12, 0x26, 0x0a, 0x04, 0x62, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65,
+	0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x62, 0x69, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x04, 0x61,
+	0x73, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x2e, 0x50, 0x72, 0x69, 0x63, 0x65, 0x4c, 0x65, 0x76, 0x65, 0x6c, 0x52, 0x04, 0x61,
+	0x73, 0x6b, 0x73, 0x22, 0x2b, 0x0a, 0x11, 0x42, 0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x22, 0xc1, 0x01, 0x0a, 0x0a, 0x42, 0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x75, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x69, 0x64, 0x5f, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x69, 0x64, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x69, 0x64, 0x5f, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x69, 0x64, 0x51, 0x75, 0x61, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x61, 0x73, 0x6b, 0x5f, 0x70, 0x72, 0x69, 0x63,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x61, 0x73, 0x6b, 0x50, 0x72, 0x69, 0x63,
+	0x65, 0x12, 0x21, 0x0a, 0x0c, 0x61, 0x73, 0x6b, 0x5f, 0x71, 0x75, 0x61, 0x6e, 0x74, 0x69, 0x74,
+	0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x61, 0x73, 0x6b, 0x51, 0x75, 0x61, 0x6e,
+	0x74, 0x69, 0x74, 0x79, 0x22, 0x42, 0x0a, 0x0c, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x22, 0xda, 0x02, 0x0a, 0x05, 0x4b, 0x6c, 0x69,
+	0x6e, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e,
+	0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x6e, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x6f, 0x70, 0x65, 0x6e, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x5f, 0x74, 0x69, 0x6d,
+	0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x6f, 0x70, 0x65, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x69, 0x67, 0x68, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x69, 0x67, 0x68, 0x12, 0x10, 0x0a, 0x03, 0x6c, 0x6f,
+	0x77, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6c, 0x6f, 0x77, 0x12, 0x14, 0x0a, 0x05,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x63, 0x6c, 0x6f,
+	0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x76, 0x6f, 0x6c, 0x75, 0x6d,
+	0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x56, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x76, 0x6f, 0x6c,
+	0x75, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65,
+	0x56, 0x6f, 0x6c, 0x75, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x74, 0x72, 0x61, 0x64, 0x65, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06,
+	0x63, 0x6c, 0x6f, 0x73, 0x65, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x42, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x65, 0x61, 0x72, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x61,
+	0x72, 0x63, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x8b, 0x02, 0x0a, 0x0a, 0x53, 0x79,
+	0x6d, 0x62, 0x6f, 0x6c, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62,
+	0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c,
+	0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x61, 0x73, 0x65, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x61, 0x73, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12,
+	0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x41, 0x73, 0x73, 0x65, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x69, 0x63, 0x6b,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74, 0x69, 0x63,
+	0x6b, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x74, 0x65, 0x70, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74, 0x65, 0x70, 0x53, 0x69,
+	0x7a, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x6d, 0x69, 0x6e, 0x5f, 0x71, 0x74, 0x79, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x69, 0x6e, 0x51, 0x74, 0x79, 0x12, 0x17, 0x0a, 0x07, 0x6d,
+	0x61, 0x78, 0x5f, 0x71, 0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x61,
+	0x78, 0x51, 0x74, 0x79, 0x12, 0x21, 0x0a, 0x0c, 0x6d, 0x69, 0x6e, 0x5f, 0x6e, 0x6f, 0x74, 0x69,
+	0x6f, 0x6e, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x69, 0x6e, 0x4e,
+	0x6f, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x22, 0x43, 0x0a, 0x13, 0x4c, 0x69, 0x73, 0x74, 0x53,
+	0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c,
+	0x0a, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x12, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x49,
+	0x6e, 0x66, 0x6f, 0x52, 0x07, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x32, 0xb3, 0x05, 0x0a,
+	0x0d, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x4b,
+	0x0a, 0x14, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x61, 0x77, 0x4d, 0x69, 0x6e, 0x69,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x1c, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e,
+	0x52, 0x61, 0x77, 0x4d, 0x69, 0x6e, 0x69, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x52, 0x61,
+	0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x12, 0x52,
+	0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x52, 0x61, 0x77, 0x41, 0x67, 0x67, 0x54, 0x72, 0x61, 0x64,
+	0x65, 0x12, 0x1a, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x52, 0x61, 0x77, 0x41, 0x67,
+	0x67, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x52, 0x61, 0x77, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x11, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4d,
+	0x69, 0x6e, 0x69, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x12, 0x19, 0x2e, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x2e, 0x4d, 0x69, 0x6e, 0x69, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x4d, 0x69,
+	0x6e, 0x69, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x30, 0x01, 0x12, 0x3e, 0x0a, 0x0f, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x76, 0x65, 0x41, 0x67, 0x67, 0x54, 0x72, 0x61, 0x64, 0x65, 0x12, 0x17, 0x2e,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x41, 0x67, 0x67, 0x54, 0x72, 0x61, 0x64, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e,
+	0x41, 0x67, 0x67, 0x54, 0x72, 0x61, 0x64, 0x65, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x09, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x18, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x10, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x41, 0x67, 0x67, 0x54, 0x72,
+	0x61, 0x64, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x3b, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x69,
+	0x76, 0x65, 0x44, 0x65, 0x70, 0x74, 0x68, 0x12, 0x14, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74,
+	0x2e, 0x44, 0x65, 0x70, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x44, 0x65, 0x70, 0x74, 0x68, 0x55, 0x70, 0x64, 0x61,
+	0x74, 0x65, 0x30, 0x01, 0x12, 0x47, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x44, 0x65, 0x70, 0x74, 0x68,
+	0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x1c, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65,
+	0x74, 0x2e, 0x44, 0x65, 0x70, 0x74, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x15, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e,
+	0x44, 0x65, 0x70, 0x74, 0x68, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x12, 0x44, 0x0a,
+	0x11, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x42, 0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b,
+	0x65, 0x72, 0x12, 0x19, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x42, 0x6f, 0x6f, 0x6b,
+	0x54, 0x69, 0x63, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x42, 0x6f, 0x6f, 0x6b, 0x54, 0x69, 0x63, 0x6b, 0x65,
+	0x72, 0x30, 0x01, 0x12, 0x35, 0x0a, 0x0c, 0x52, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x4b, 0x6c,
+	0x69, 0x6e, 0x65, 0x12, 0x14, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x4b, 0x6c, 0x69,
+	0x6e, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0d, 0x2e, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x2e, 0x4b, 0x6c, 0x69, 0x6e, 0x65, 0x30, 0x01, 0x12, 0x46, 0x0a, 0x0b, 0x4c, 0x69,
+	0x73, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x12, 0x1a, 0x2e, 0x73, 0x6f, 0x63, 0x6b,
+	0x65, 0x74, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x4a, 0x5a, 0x48, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x54, 0x6f, 0x6e, 0x69, 0x63, 0x35, 0x36, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2d, 0x63,
+	0x72, 0x79, 0x70, 0x74, 0x6f, 0x2d, 0x61, 0x73, 0x73, 0x65, 0x74, 0x2d, 0x74, 0x72, 0x61, 0x63,
+	0x6b, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x67, 0x6f,
+	0x2f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x3b, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_socket_socket_proto_rawDescOnce sync.Once
+	file_socket_socket_proto_rawDescData = file_socket_socket_proto_rawDesc
+)
+
+func file_socket_socket_proto_rawDescGZIP() []byte {
+	file_socket_socket_proto_rawDescOnce.Do(func() {
+		file_socket_socket_proto_rawDescData = protoimpl.X.CompressGZIP(file_socket_socket_proto_rawDescData)
+	})
+	return file_socket_socket_proto_rawDescData
+}
+
+var file_socket_socket_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_socket_socket_proto_goTypes = []interface{}{
+	(*RawAggTradeRequest)(nil),   // 0: socket.RawAggTradeRequest
+	(*RawMiniTickerRequest)(nil), // 1: socket.RawMiniTickerRequest
+	(*RawResponse)(nil),          // 2: socket.RawResponse
+	(*AggTradeRequest)(nil),      // 3: socket.AggTradeRequest
+	(*AggTrade)(nil),             // 4: socket.AggTrade
+	(*SubscribeRequest)(nil),     // 5: socket.SubscribeRequest
+	(*MiniTickerRequest)(nil),    // 6: socket.MiniTickerRequest
+	(*MiniTicker)(nil),           // 7: socket.MiniTicker
+	(*PriceLevel)(nil),           // 8: socket.PriceLevel
+	(*DepthRequest)(nil),         // 9: socket.DepthRequest
+	(*DepthUpdate)(nil),          // 10: socket.DepthUpdate
+	(*DepthSnapshotRequest)(nil), // 11: socket.DepthSnapshotRequest
+	(*DepthSnapshot)(nil),        // 12: socket.DepthSnapshot
+	(*BookTickerRequest)(nil),    // 13: socket.BookTickerRequest
+	(*BookTicker)(nil),           // 14: socket.BookTicker
+	(*KlineRequest)(nil),         // 15: socket.KlineRequest
+	(*Kline)(nil),                // 16: socket.Kline
+	(*ListSymbolsRequest)(nil),   // 17: socket.ListSymbolsRequest
+	(*SymbolInfo)(nil),           // 18: socket.SymbolInfo
+	(*ListSymbolsResponse)(nil),  // 19: socket.ListSymbolsResponse
+}
+var file_socket_socket_proto_depIdxs = []int32{
+	8,  // 0: socket.DepthUpdate.bids:type_name -> socket.PriceLevel
+	8,  // 1: socket.DepthUpdate.asks:type_name -> socket.PriceLevel
+	8,  // 2: socket.DepthSnapshot.bids:type_name -> socket.PriceLevel
+	8,  // 3: socket.DepthSnapshot.asks:type_name -> socket.PriceLevel
+	18, // 4: socket.ListSymbolsResponse.symbols:type_name -> socket.SymbolInfo
+	1,  // 5: socket.SocketService.ReceiveRawMiniTicker:input_type -> socket.RawMiniTickerRequest
+	0,  // 6: socket.SocketService.ReceiveRawAggTrade:input_type -> socket.RawAggTradeRequest
+	6,  // 7: socket.SocketService.ReceiveMiniTicker:input_type -> socket.MiniTickerRequest
+	3,  // 8: socket.SocketService.ReceiveAggTrade:input_type -> socket.AggTradeRequest
+	5,  // 9: socket.SocketService.Subscribe:input_type -> socket.SubscribeRequest
+	9,  // 10: socket.SocketService.ReceiveDepth:input_type -> socket.DepthRequest
+	11, // 11: socket.SocketService.GetDepthSnapshot:input_type -> socket.DepthSnapshotRequest
+	13, // 12: socket.SocketService.ReceiveBookTicker:input_type -> socket.BookTickerRequest
+	15, // 13: socket.SocketService.ReceiveKline:input_type -> socket.KlineRequest
+	17, // 14: socket.SocketService.ListSymbols:input_type -> socket.ListSymbolsRequest
+	2,  // 15: socket.SocketService.ReceiveRawMiniTicker:output_type -> socket.RawResponse
+	2,  // 16: socket.SocketService.ReceiveRawAggTrade:output_type -> socket.RawResponse
+	7,  // 17: socket.SocketService.ReceiveMiniTicker:output_type -> socket.MiniTicker
+	4,  // 18: socket.SocketService.ReceiveAggTrade:output_type -> socket.AggTrade
+	4,  // 19: socket.SocketService.Subscribe:output_type -> socket.AggTrade
+	10, // 20: socket.SocketService.ReceiveDepth:output_type -> socket.DepthUpdate
+	12, // 21: socket.SocketService.GetDepthSnapshot:output_type -> socket.DepthSnapshot
+	14, // 22: socket.SocketService.ReceiveBookTicker:output_type -> socket.BookTicker
+	16, // 23: socket.SocketService.ReceiveKline:output_type -> socket.Kline
+	19, // 24: socket.SocketService.ListSymbols:output_type -> socket.ListSymbolsResponse
+	15, // [15:25] is the sub-list for method output_type
+	5,  // [5:15] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_socket_socket_proto_init() }
+func file_socket_socket_proto_init() {
+	if File_socket_socket_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_socket_socket_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawAggTradeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawMiniTickerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RawResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggTradeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AggTrade); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MiniTickerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MiniTicker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PriceLevel); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DepthRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DepthUpdate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DepthSnapshotRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DepthSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookTickerRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BookTicker); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KlineRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Kline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSymbolsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SymbolInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_socket_socket_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListSymbolsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_socket_socket_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_socket_socket_proto_goTypes,
+		DependencyIndexes: file_socket_socket_proto_depIdxs,
+		MessageInfos:      file_socket_socket_proto_msgTypes,
+	}.Build()
+	File_socket_socket_proto = out.File
+	file_socket_socket_proto_rawDesc = nil
+	file_socket_socket_proto_goTypes = nil
+	file_socket_socket_proto_depIdxs = nil
+}
@@ -0,0 +1,649 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: socket/socket.proto
+
+package socket
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SocketServiceClient is the client API for SocketService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SocketServiceClient interface {
+	ReceiveRawMiniTicker(ctx context.Context, in *RawMiniTickerRequest, opts ...grpc.CallOption) (SocketService_ReceiveRawMiniTickerClient, error)
+	ReceiveRawAggTrade(ctx context.Context, in *RawAggTradeRequest, opts ...grpc.CallOption) (SocketService_ReceiveRawAggTradeClient, error)
+	ReceiveMiniTicker(ctx context.Context, in *MiniTickerRequest, opts ...grpc.CallOption) (SocketService_ReceiveMiniTickerClient, error)
+	ReceiveAggTrade(ctx context.Context, in *AggTradeRequest, opts ...grpc.CallOption) (SocketService_ReceiveAggTradeClient, error)
+	Subscribe(ctx context.Context, opts ...grpc.CallOption) (SocketService_SubscribeClient, error)
+	ReceiveDepth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (SocketService_ReceiveDepthClient, error)
+	GetDepthSnapshot(ctx context.Context, in *DepthSnapshotRequest, opts ...grpc.CallOption) (*DepthSnapshot, error)
+	ReceiveBookTicker(ctx context.Context, in *BookTickerRequest, opts ...grpc.CallOption) (SocketService_ReceiveBookTickerClient, error)
+	ReceiveKline(ctx context.Context, in *KlineRequest, opts ...grpc.CallOption) (SocketService_ReceiveKlineClient, error)
+	ListSymbols(ctx context.Context, in *ListSymbolsRequest, opts ...grpc.CallOption) (*ListSymbolsResponse, error)
+}
+
+type socketServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSocketServiceClient(cc grpc.ClientConnInterface) SocketServiceClient {
+	return &socketServiceClient{cc}
+}
+
+func (c *socketServiceClient) ReceiveRawMiniTicker(ctx context.Context, in *RawMiniTickerRequest, opts ...grpc.CallOption) (SocketService_ReceiveRawMiniTickerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SocketService_ServiceDesc.Streams[0], "/socket.SocketService/ReceiveRawMiniTicker", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &socketServiceReceiveRawMiniTickerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SocketService_ReceiveRawMiniTickerClient interface {
+	Recv() (*RawResponse, error)
+	grpc.ClientStream
+}
+
+type socketServiceReceiveRawMiniTickerClient struct {
+	grpc.ClientStream
+}
+
+func (x *socketServiceReceiveRawMiniTickerClient) Recv() (*RawResponse, error) {
+	m := new(RawResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *socketServiceClient) ReceiveRawAggTrade(ctx context.Context, in *RawAggTradeRequest, opts ...grpc.CallOption) (SocketService_ReceiveRawAggTradeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SocketService_ServiceDesc.Streams[1], "/socket.SocketService/ReceiveRawAggTrade", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &socketServiceReceiveRawAggTradeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SocketService_ReceiveRawAggTradeClient interface {
+	Recv() (*RawResponse, error)
+	grpc.ClientStream
+}
+
+type socketServiceReceiveRawAggTradeClient struct {
+	grpc.ClientStream
+}
+
+func (x *socketServiceReceiveRawAggTradeClient) Recv() (*RawResponse, error) {
+	m := new(RawResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *socketServiceClient) ReceiveMiniTicker(ctx context.Context, in *MiniTickerRequest, opts ...grpc.CallOption) (SocketService_ReceiveMiniTickerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SocketService_ServiceDesc.Streams[2], "/socket.SocketService/ReceiveMiniTicker", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &socketServiceReceiveMiniTickerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SocketService_ReceiveMiniTickerClient interface {
+	Recv() (*MiniTicker, error)
+	grpc.ClientStream
+}
+
+type socketServiceReceiveMiniTickerClient struct {
+	grpc.ClientStream
+}
+
+func (x *socketServiceReceiveMiniTickerClient) Recv() (*MiniTicker, error) {
+	m := new(MiniTicker)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *socketServiceClient) ReceiveAggTrade(ctx context.Context, in *AggTradeRequest, opts ...grpc.CallOption) (SocketService_ReceiveAggTradeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SocketService_ServiceDesc.Streams[3], "/socket.SocketService/ReceiveAggTrade", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &socketServiceReceiveAggTradeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SocketService_ReceiveAggTradeClient interface {
+	Recv() (*AggTrade, error)
+	grpc.ClientStream
+}
+
+type socketServiceReceiveAggTradeClient struct {
+	grpc.ClientStream
+}
+
+func (x *socketServiceReceiveAggTradeClient) Recv() (*AggTrade, error) {
+	m := new(AggTrade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *socketServiceClient) Subscribe(ctx context.Context, opts ...grpc.CallOption) (SocketService_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SocketService_ServiceDesc.Streams[4], "/socket.SocketService/Subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &socketServiceSubscribeClient{stream}
+	return x, nil
+}
+
+type SocketService_SubscribeClient interface {
+	Send(*SubscribeRequest) error
+	Recv() (*AggTrade, error)
+	grpc.ClientStream
+}
+
+type socketServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *socketServiceSubscribeClient) Send(m *SubscribeRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *socketServiceSubscribeClient) Recv() (*AggTrade, error) {
+	m := new(AggTrade)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *socketServiceClient) ReceiveDepth(ctx context.Context, in *DepthRequest, opts ...grpc.CallOption) (SocketService_ReceiveDepthClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SocketService_ServiceDesc.Streams[5], "/socket.SocketService/ReceiveDepth", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &socketServiceReceiveDepthClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SocketService_ReceiveDepthClient interface {
+	Recv() (*DepthUpdate, error)
+	grpc.ClientStream
+}
+
+type socketServiceReceiveDepthClient struct {
+	grpc.ClientStream
+}
+
+func (x *socketServiceReceiveDepthClient) Recv() (*DepthUpdate, error) {
+	m := new(DepthUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *socketServiceClient) GetDepthSnapshot(ctx context.Context, in *DepthSnapshotRequest, opts ...grpc.CallOption) (*DepthSnapshot, error) {
+	out := new(DepthSnapshot)
+	err := c.cc.Invoke(ctx, "/socket.SocketService/GetDepthSnapshot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *socketServiceClient) ReceiveBookTicker(ctx context.Context, in *BookTickerRequest, opts ...grpc.CallOption) (SocketService_ReceiveBookTickerClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SocketService_ServiceDesc.Streams[6], "/socket.SocketService/ReceiveBookTicker", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &socketServiceReceiveBookTickerClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SocketService_ReceiveBookTickerClient interface {
+	Recv() (*BookTicker, error)
+	grpc.ClientStream
+}
+
+type socketServiceReceiveBookTickerClient struct {
+	grpc.ClientStream
+}
+
+func (x *socketServiceReceiveBookTickerClient) Recv() (*BookTicker, error) {
+	m := new(BookTicker)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *socketServiceClient) ReceiveKline(ctx context.Context, in *KlineRequest, opts ...grpc.CallOption) (SocketService_ReceiveKlineClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SocketService_ServiceDesc.Streams[7], "/socket.SocketService/ReceiveKline", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &socketServiceReceiveKlineClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type SocketService_ReceiveKlineClient interface {
+	Recv() (*Kline, error)
+	grpc.ClientStream
+}
+
+type socketServiceReceiveKlineClient struct {
+	grpc.ClientStream
+}
+
+func (x *socketServiceReceiveKlineClient) Recv() (*Kline, error) {
+	m := new(Kline)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *socketServiceClient) ListSymbols(ctx context.Context, in *ListSymbolsRequest, opts ...grpc.CallOption) (*ListSymbolsResponse, error) {
+	out := new(ListSymbolsResponse)
+	err := c.cc.Invoke(ctx, "/socket.SocketService/ListSymbols", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SocketServiceServer is the server API for SocketService service.
+// All implementations should embed UnimplementedSocketServiceServer
+// for forward compatibility
+type SocketServiceServer interface {
+	ReceiveRawMiniTicker(*RawMiniTickerRequest, SocketService_ReceiveRawMiniTickerServer) error
+	ReceiveRawAggTrade(*RawAggTradeRequest, SocketService_ReceiveRawAggTradeServer) error
+	ReceiveMiniTicker(*MiniTickerRequest, SocketService_ReceiveMiniTickerServer) error
+	ReceiveAggTrade(*AggTradeRequest, SocketService_ReceiveAggTradeServer) error
+	Subscribe(SocketService_SubscribeServer) error
+	ReceiveDepth(*DepthRequest, SocketService_ReceiveDepthServer) error
+	GetDepthSnapshot(context.Context, *DepthSnapshotRequest) (*DepthSnapshot, error)
+	ReceiveBookTicker(*BookTickerRequest, SocketService_ReceiveBookTickerServer) error
+	ReceiveKline(*KlineRequest, SocketService_ReceiveKlineServer) error
+	ListSymbols(context.Context, *ListSymbolsRequest) (*ListSymbolsResponse, error)
+}
+
+// UnimplementedSocketServiceServer should be embedded to have forward compatible implementations.
+type UnimplementedSocketServiceServer struct {
+}
+
+func (UnimplementedSocketServiceServer) ReceiveRawMiniTicker(*RawMiniTickerRequest, SocketService_ReceiveRawMiniTickerServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveRawMiniTicker not implemented")
+}
+func (UnimplementedSocketServiceServer) ReceiveRawAggTrade(*RawAggTradeRequest, SocketService_ReceiveRawAggTradeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveRawAggTrade not implemented")
+}
+func (UnimplementedSocketServiceServer) ReceiveMiniTicker(*MiniTickerRequest, SocketService_ReceiveMiniTickerServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveMiniTicker not implemented")
+}
+func (UnimplementedSocketServiceServer) ReceiveAggTrade(*AggTradeRequest, SocketService_ReceiveAggTradeServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveAggTrade not implemented")
+}
+func (UnimplementedSocketServiceServer) Subscribe(SocketService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedSocketServiceServer) ReceiveDepth(*DepthRequest, SocketService_ReceiveDepthServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveDepth not implemented")
+}
+func (UnimplementedSocketServiceServer) GetDepthSnapshot(context.Context, *DepthSnapshotRequest) (*DepthSnapshot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDepthSnapshot not implemented")
+}
+func (UnimplementedSocketServiceServer) ReceiveBookTicker(*BookTickerRequest, SocketService_ReceiveBookTickerServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveBookTicker not implemented")
+}
+func (UnimplementedSocketServiceServer) ReceiveKline(*KlineRequest, SocketService_ReceiveKlineServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReceiveKline not implemented")
+}
+func (UnimplementedSocketServiceServer) ListSymbols(context.Context, *ListSymbolsRequest) (*ListSymbolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSymbols not implemented")
+}
+
+// UnsafeSocketServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SocketServiceServer will
+// result in compilation errors.
+type UnsafeSocketServiceServer interface {
+	mustEmbedUnimplementedSocketServiceServer()
+}
+
+func RegisterSocketServiceServer(s grpc.ServiceRegistrar, srv SocketServiceServer) {
+	s.RegisterService(&SocketService_ServiceDesc, srv)
+}
+
+func _SocketService_ReceiveRawMiniTicker_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RawMiniTickerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SocketServiceServer).ReceiveRawMiniTicker(m, &socketServiceReceiveRawMiniTickerServer{stream})
+}
+
+type SocketService_ReceiveRawMiniTickerServer interface {
+	Send(*RawResponse) error
+	grpc.ServerStream
+}
+
+type socketServiceReceiveRawMiniTickerServer struct {
+	grpc.ServerStream
+}
+
+func (x *socketServiceReceiveRawMiniTickerServer) Send(m *RawResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SocketService_ReceiveRawAggTrade_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RawAggTradeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SocketServiceServer).ReceiveRawAggTrade(m, &socketServiceReceiveRawAggTradeServer{stream})
+}
+
+type SocketService_ReceiveRawAggTradeServer interface {
+	Send(*RawResponse) error
+	grpc.ServerStream
+}
+
+type socketServiceReceiveRawAggTradeServer struct {
+	grpc.ServerStream
+}
+
+func (x *socketServiceReceiveRawAggTradeServer) Send(m *RawResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SocketService_ReceiveMiniTicker_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(MiniTickerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SocketServiceServer).ReceiveMiniTicker(m, &socketServiceReceiveMiniTickerServer{stream})
+}
+
+type SocketService_ReceiveMiniTickerServer interface {
+	Send(*MiniTicker) error
+	grpc.ServerStream
+}
+
+type socketServiceReceiveMiniTickerServer struct {
+	grpc.ServerStream
+}
+
+func (x *socketServiceReceiveMiniTickerServer) Send(m *MiniTicker) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SocketService_ReceiveAggTrade_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(AggTradeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SocketServiceServer).ReceiveAggTrade(m, &socketServiceReceiveAggTradeServer{stream})
+}
+
+type SocketService_ReceiveAggTradeServer interface {
+	Send(*AggTrade) error
+	grpc.ServerStream
+}
+
+type socketServiceReceiveAggTradeServer struct {
+	grpc.ServerStream
+}
+
+func (x *socketServiceReceiveAggTradeServer) Send(m *AggTrade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SocketService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SocketServiceServer).Subscribe(&socketServiceSubscribeServer{stream})
+}
+
+type SocketService_SubscribeServer interface {
+	Send(*AggTrade) error
+	Recv() (*SubscribeRequest, error)
+	grpc.ServerStream
+}
+
+type socketServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *socketServiceSubscribeServer) Send(m *AggTrade) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *socketServiceSubscribeServer) Recv() (*SubscribeRequest, error) {
+	m := new(SubscribeRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SocketService_ReceiveDepth_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DepthRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SocketServiceServer).ReceiveDepth(m, &socketServiceReceiveDepthServer{stream})
+}
+
+type SocketService_ReceiveDepthServer interface {
+	Send(*DepthUpdate) error
+	grpc.ServerStream
+}
+
+type socketServiceReceiveDepthServer struct {
+	grpc.ServerStream
+}
+
+func (x *socketServiceReceiveDepthServer) Send(m *DepthUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SocketService_GetDepthSnapshot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepthSnapshotRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SocketServiceServer).GetDepthSnapshot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/socket.SocketService/GetDepthSnapshot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SocketServiceServer).GetDepthSnapshot(ctx, req.(*DepthSnapshotRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SocketService_ReceiveBookTicker_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BookTickerRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SocketServiceServer).ReceiveBookTicker(m, &socketServiceReceiveBookTickerServer{stream})
+}
+
+type SocketService_ReceiveBookTickerServer interface {
+	Send(*BookTicker) error
+	grpc.ServerStream
+}
+
+type socketServiceReceiveBookTickerServer struct {
+	grpc.ServerStream
+}
+
+func (x *socketServiceReceiveBookTickerServer) Send(m *BookTicker) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SocketService_ReceiveKline_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(KlineRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(SocketServiceServer).ReceiveKline(m, &socketServiceReceiveKlineServer{stream})
+}
+
+type SocketService_ReceiveKlineServer interface {
+	Send(*Kline) error
+	grpc.ServerStream
+}
+
+type socketServiceReceiveKlineServer struct {
+	grpc.ServerStream
+}
+
+func (x *socketServiceReceiveKlineServer) Send(m *Kline) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _SocketService_ListSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSymbolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SocketServiceServer).ListSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/socket.SocketService/ListSymbols",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SocketServiceServer).ListSymbols(ctx, req.(*ListSymbolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SocketService_ServiceDesc is the grpc.ServiceDesc for SocketService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SocketService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "socket.SocketService",
+	HandlerType: (*SocketServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetDepthSnapshot",
+			Handler:    _SocketService_GetDepthSnapshot_Handler,
+		},
+		{
+			MethodName: "ListSymbols",
+			Handler:    _SocketService_ListSymbols_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReceiveRawMiniTicker",
+			Handler:       _SocketService_ReceiveRawMiniTicker_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReceiveRawAggTrade",
+			Handler:       _SocketService_ReceiveRawAggTrade_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReceiveMiniTicker",
+			Handler:       _SocketService_ReceiveMiniTicker_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReceiveAggTrade",
+			Handler:       _SocketService_ReceiveAggTrade_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _SocketService_Subscribe_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "ReceiveDepth",
+			Handler:       _SocketService_ReceiveDepth_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReceiveBookTicker",
+			Handler:       _SocketService_ReceiveBookTicker_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReceiveKline",
+			Handler:       _SocketService_ReceiveKline_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "socket/socket.proto",
+}